@@ -0,0 +1,806 @@
+package css
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ContainerRule represents the prelude of an @container rule, which is a comma
+// separated list of ContainerConditions.
+type ContainerRule struct {
+	Conditions []ContainerCondition
+	Tokens     Tokens
+}
+
+func (cr *ContainerRule) parse(c *cssParser) error {
+	for {
+		d := c.NewGoal()
+
+		var cc ContainerCondition
+
+		if err := cc.parse(&d); err != nil {
+			return c.Error("ContainerRule", err)
+		}
+
+		cr.Conditions = append(cr.Conditions, cc)
+
+		c.Score(d)
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenComma) {
+			break
+		}
+
+		c.AcceptRunWhitespace()
+	}
+
+	cr.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// ContainerCondition represents a single container condition, consisting of
+// an optional container Name and an optional Query, at least one of which will
+// be set.
+type ContainerCondition struct {
+	Name   *Token
+	Query  *ContainerQuery
+	Tokens Tokens
+}
+
+func (cc *ContainerCondition) parse(c *cssParser) error {
+	if tk := c.Peek(); tk.Type == TokenIdent {
+		switch strings.ToLower(tk.Data) {
+		case "not", "and", "or", "none":
+		default:
+			c.Skip()
+
+			cc.Name = c.GetLastToken()
+			d := c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if tk := d.Peek(); tk.Type != TokenOpenParen && tk.Type != TokenFunction && !(tk.Type == TokenIdent && strings.EqualFold(tk.Data, "not")) {
+				cc.Tokens = c.ToTokens()
+
+				return nil
+			}
+
+			c.Score(d)
+		}
+	}
+
+	d := c.NewGoal()
+	cc.Query = new(ContainerQuery)
+
+	if err := cc.Query.parse(&d); err != nil {
+		return c.Error("ContainerCondition", err)
+	}
+
+	c.Score(d)
+
+	cc.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// ContainerQuery represents a query against a container.
+//
+// When Not is set, the query is the negation of that ContainerQueryInParens;
+// otherwise the query is the combination of the InParens values, joined by
+// 'or' when Or is true and by 'and' otherwise.
+type ContainerQuery struct {
+	Not      *ContainerQueryInParens
+	InParens []ContainerQueryInParens
+	Or       bool
+	Tokens   Tokens
+}
+
+func (cq *ContainerQuery) parse(c *cssParser) error {
+	if c.AcceptIdent("not") {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		cq.Not = new(ContainerQueryInParens)
+
+		if err := cq.Not.parse(&d); err != nil {
+			return c.Error("ContainerQuery", err)
+		}
+
+		c.Score(d)
+	} else {
+		var operator string
+
+		for {
+			d := c.NewGoal()
+
+			var qip ContainerQueryInParens
+
+			if err := qip.parse(&d); err != nil {
+				return c.Error("ContainerQuery", err)
+			}
+
+			cq.InParens = append(cq.InParens, qip)
+
+			c.Score(d)
+
+			d = c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if operator == "" {
+				if d.AcceptIdent("and") {
+					operator = "and"
+				} else if d.AcceptIdent("or") {
+					operator = "or"
+					cq.Or = true
+				} else {
+					break
+				}
+			} else if !d.AcceptIdent(operator) {
+				if d.AcceptIdent("and") || d.AcceptIdent("or") {
+					return d.Error("ContainerQuery", ErrMixedOperators)
+				}
+
+				break
+			}
+
+			d.AcceptRunWhitespace()
+			c.Score(d)
+		}
+	}
+
+	cq.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// ContainerQueryInParens represents a single test within a ContainerQuery.
+//
+// Only one of Query, SizeFeature, Style, ScrollState, or GeneralEnclosed will
+// be set.
+type ContainerQueryInParens struct {
+	Query           *ContainerQuery
+	SizeFeature     *MediaFeature
+	Style           *StyleQuery
+	ScrollState     *MediaCondition
+	GeneralEnclosed Tokens
+	Tokens          Tokens
+}
+
+func (q *ContainerQueryInParens) parse(c *cssParser) error {
+	if c.AcceptFunction("style") {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		q.Style = new(StyleQuery)
+
+		if err := q.Style.parse(&d); err != nil {
+			return c.Error("ContainerQueryInParens", err)
+		}
+
+		c.Score(d)
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("ContainerQueryInParens", ErrMissingCloseParen)
+		}
+	} else if c.AcceptFunction("scroll-state") {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		q.ScrollState = new(MediaCondition)
+
+		if err := q.ScrollState.parseBareFeature(&d); err != nil {
+			return c.Error("ContainerQueryInParens", err)
+		}
+
+		c.Score(d)
+	} else if tk := c.Peek(); tk.Type == TokenFunction {
+		c.AcceptComponentValue()
+
+		q.GeneralEnclosed = c.ToTokens()
+	} else if tk.Type != TokenOpenParen {
+		return c.Error("ContainerQueryInParens", ErrInvalidContainerQuery)
+	} else {
+		d := c.NewGoal()
+		q.SizeFeature = new(MediaFeature)
+
+		if err := q.SizeFeature.parse(&d); err == nil {
+			c.Score(d)
+		} else {
+			q.SizeFeature = nil
+			d = c.NewGoal()
+
+			d.Skip()
+			d.AcceptRunWhitespace()
+
+			e := d.NewGoal()
+			q.Query = new(ContainerQuery)
+
+			if err := q.Query.parse(&e); err == nil {
+				d.Score(e)
+				d.AcceptRunWhitespace()
+			}
+
+			if d.Accept(TokenCloseParen) {
+				c.Score(d)
+			} else {
+				q.Query = nil
+
+				c.AcceptComponentValue()
+
+				q.GeneralEnclosed = c.ToTokens()
+			}
+		}
+	}
+
+	q.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func (m *MediaCondition) parseBareFeature(c *cssParser) error {
+	if c.Peek().Type != TokenIdent || c.AcceptIdent("not") {
+		*c = (*c)[:0]
+
+		if err := m.parse(c); err != nil {
+			return err
+		}
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("MediaCondition", ErrMissingCloseParen)
+		}
+
+		return nil
+	}
+
+	var mip MediaInParens
+
+	mip.Feature = new(MediaFeature)
+
+	if err := mip.Feature.parseContents(c); err != nil {
+		return c.Error("MediaCondition", err)
+	}
+
+	mip.Tokens = c.ToTokens()
+	m.InParens = []MediaInParens{mip}
+	m.Tokens = mip.Tokens
+
+	return nil
+}
+
+// StyleQuery represents the contents of a style() container query.
+//
+// When Feature is set, the query is a single StyleFeature; otherwise, the
+// query is structured as a ContainerQuery.
+type StyleQuery struct {
+	Not      *StyleInParens
+	InParens []StyleInParens
+	Or       bool
+	Feature  *StyleFeature
+	Tokens   Tokens
+}
+
+func (s *StyleQuery) parse(c *cssParser) error {
+	if tk := c.Peek(); tk.Type == TokenIdent && !strings.EqualFold(tk.Data, "not") {
+		d := c.NewGoal()
+		s.Feature = new(StyleFeature)
+
+		if err := s.Feature.parse(&d); err != nil {
+			return c.Error("StyleQuery", err)
+		}
+
+		c.Score(d)
+	} else if c.AcceptIdent("not") {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		s.Not = new(StyleInParens)
+
+		if err := s.Not.parse(&d); err != nil {
+			return c.Error("StyleQuery", err)
+		}
+
+		c.Score(d)
+	} else {
+		var operator string
+
+		for {
+			d := c.NewGoal()
+
+			var sip StyleInParens
+
+			if err := sip.parse(&d); err != nil {
+				return c.Error("StyleQuery", err)
+			}
+
+			s.InParens = append(s.InParens, sip)
+
+			c.Score(d)
+
+			d = c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if operator == "" {
+				if d.AcceptIdent("and") {
+					operator = "and"
+				} else if d.AcceptIdent("or") {
+					operator = "or"
+					s.Or = true
+				} else {
+					break
+				}
+			} else if !d.AcceptIdent(operator) {
+				if d.AcceptIdent("and") || d.AcceptIdent("or") {
+					return d.Error("StyleQuery", ErrMixedOperators)
+				}
+
+				break
+			}
+
+			d.AcceptRunWhitespace()
+			c.Score(d)
+		}
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// StyleInParens represents a single test within a StyleQuery.
+//
+// Only one of Query, Feature, or GeneralEnclosed will be set.
+type StyleInParens struct {
+	Query           *StyleQuery
+	Feature         *StyleFeature
+	GeneralEnclosed Tokens
+	Tokens          Tokens
+}
+
+func (s *StyleInParens) parse(c *cssParser) error {
+	if tk := c.Peek(); tk.Type == TokenFunction {
+		c.AcceptComponentValue()
+
+		s.GeneralEnclosed = c.ToTokens()
+	} else if !c.Accept(TokenOpenParen) {
+		return c.Error("StyleInParens", ErrInvalidContainerQuery)
+	} else {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		s.Query = new(StyleQuery)
+
+		if err := s.Query.parse(&d); err == nil {
+			d.AcceptRunWhitespace()
+
+			if d.Accept(TokenCloseParen) {
+				c.Score(d)
+
+				if s.Query.Feature != nil {
+					s.Feature = s.Query.Feature
+					s.Query = nil
+				}
+
+				s.Tokens = c.ToTokens()
+
+				return nil
+			}
+		}
+
+		s.Query = nil
+		*c = (*c)[:0]
+
+		c.AcceptComponentValue()
+
+		s.GeneralEnclosed = c.ToTokens()
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// StyleFeature represents a test of the computed value of a property.
+//
+// When Value is nil, the test is whether the property has a non-initial value.
+type StyleFeature struct {
+	Name   *Token
+	Value  Tokens
+	Tokens Tokens
+}
+
+func (s *StyleFeature) parse(c *cssParser) error {
+	if !c.Accept(TokenIdent) {
+		return c.Error("StyleFeature", ErrMissingName)
+	}
+
+	s.Name = c.GetLastToken()
+	d := c.NewGoal()
+
+	d.AcceptRunWhitespace()
+
+	if d.Accept(TokenColon) {
+		d.AcceptRunWhitespace()
+
+		e := d.NewGoal()
+
+		for e.Peek().Type != TokenCloseParen && e.AcceptComponentValue() {
+		}
+
+		s.Value = e.ToTokens().Trim()
+
+		d.Score(e)
+		c.Score(d)
+	}
+
+	s.Tokens = c.ToTokens().Trim()
+
+	return nil
+}
+
+// Container contains the details of a query container, used when evaluating
+// container queries.
+//
+// Width and Height, as well as the FontSize and RootFontSize values used to
+// resolve font-relative lengths, are in pixels. The Properties map contains
+// the computed values of any properties that may be tested by style queries,
+// and the ScrollState map contains the current values of any scroll-state
+// features.
+type Container struct {
+	Names        []string
+	Width        float64
+	Height       float64
+	Vertical     bool
+	FontSize     float64
+	RootFontSize float64
+	Properties   map[string]string
+	ScrollState  map[string]string
+}
+
+// Evaluate determines whether the rule applies to the given container.
+//
+// The container should be the nearest ancestor query container that satisfies
+// any container name in the conditions; a condition is only tested against the
+// given container when its name, if any, matches one of the container's names.
+func (cr *ContainerRule) Evaluate(container *Container) bool {
+	for n := range cr.Conditions {
+		if cr.Conditions[n].Evaluate(container) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Evaluate determines whether the condition holds for the given container.
+func (cc *ContainerCondition) Evaluate(container *Container) bool {
+	if cc.Name != nil && !containerHasName(container, cc.Name.Data) {
+		return false
+	}
+
+	return cc.Query == nil || cc.Query.evaluate(container) == queryTrue
+}
+
+func containerHasName(container *Container, name string) bool {
+	for _, n := range container.Names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type queryResult int8
+
+const (
+	queryUnknown queryResult = iota
+	queryFalse
+	queryTrue
+)
+
+func queryBool(b bool) queryResult {
+	if b {
+		return queryTrue
+	}
+
+	return queryFalse
+}
+
+func (q queryResult) not() queryResult {
+	switch q {
+	case queryTrue:
+		return queryFalse
+	case queryFalse:
+		return queryTrue
+	}
+
+	return queryUnknown
+}
+
+func combineQueryResults(or bool, results func(yield func(queryResult) bool)) queryResult {
+	short, result := queryFalse, queryTrue
+
+	if or {
+		short, result = queryTrue, queryFalse
+	}
+
+	for r := range results {
+		if r == short {
+			return short
+		} else if r == queryUnknown {
+			result = queryUnknown
+		}
+	}
+
+	return result
+}
+
+func (cq *ContainerQuery) evaluate(container *Container) queryResult {
+	if cq.Not != nil {
+		return cq.Not.evaluate(container).not()
+	}
+
+	return combineQueryResults(cq.Or, func(yield func(queryResult) bool) {
+		for n := range cq.InParens {
+			if !yield(cq.InParens[n].evaluate(container)) {
+				return
+			}
+		}
+	})
+}
+
+func (q *ContainerQueryInParens) evaluate(container *Container) queryResult {
+	switch {
+	case q.Query != nil:
+		return q.Query.evaluate(container)
+	case q.SizeFeature != nil:
+		return evaluateSizeFeature(q.SizeFeature, container)
+	case q.Style != nil:
+		return q.Style.evaluate(container)
+	case q.ScrollState != nil:
+		return evaluateScrollState(q.ScrollState, container)
+	}
+
+	return queryUnknown
+}
+
+func evaluateSizeFeature(f *MediaFeature, container *Container) queryResult {
+	name := f.FeatureName()
+	ranges := f.Ranges
+
+	if f.Value != nil {
+		cmp := ComparisonEqual
+
+		if n, ok := strings.CutPrefix(name, "min-"); ok {
+			name, cmp = n, ComparisonGreaterThanOrEqual
+		} else if n, ok := strings.CutPrefix(name, "max-"); ok {
+			name, cmp = n, ComparisonLessThanOrEqual
+		}
+
+		ranges = []MediaRange{{Comparison: cmp, Value: f.Value}}
+	}
+
+	inline, block := container.Width, container.Height
+
+	if container.Vertical {
+		inline, block = block, inline
+	}
+
+	var (
+		value   float64
+		isRatio bool
+	)
+
+	switch name {
+	case "width":
+		value = container.Width
+	case "height":
+		value = container.Height
+	case "inline-size":
+		value = inline
+	case "block-size":
+		value = block
+	case "aspect-ratio":
+		if container.Height == 0 {
+			return queryUnknown
+		}
+
+		value = container.Width / container.Height
+		isRatio = true
+	case "orientation":
+		if f.Value == nil {
+			return queryUnknown
+		}
+
+		orientation := "landscape"
+
+		if container.Height >= container.Width {
+			orientation = "portrait"
+		}
+
+		return queryBool(len(f.Value) == 1 && strings.EqualFold(f.Value[0].Data, orientation))
+	default:
+		return queryUnknown
+	}
+
+	if f.Value == nil && f.Ranges == nil {
+		return queryBool(value != 0)
+	}
+
+	for _, r := range ranges {
+		var (
+			v  float64
+			ok bool
+		)
+
+		if isRatio {
+			v, ok = parseRatio(r.Value)
+		} else {
+			v, ok = container.resolveLength(r.Value)
+		}
+
+		if !ok {
+			return queryUnknown
+		} else if !r.Comparison.Compare(value, v) {
+			return queryFalse
+		}
+	}
+
+	return queryTrue
+}
+
+func parseRatio(ts Tokens) (float64, bool) {
+	var (
+		values [2]float64
+		n      int
+	)
+
+	values[1] = 1
+
+	for _, tk := range ts {
+		switch tk.Type {
+		case TokenWhitespace, TokenComment:
+		case TokenNumber:
+			if n > 1 {
+				return 0, false
+			}
+
+			v, err := strconv.ParseFloat(tk.Data, 64)
+			if err != nil {
+				return 0, false
+			}
+
+			values[n] = v
+			n++
+		case TokenDelim:
+			if tk.Data != "/" || n != 1 {
+				return 0, false
+			}
+		default:
+			return 0, false
+		}
+	}
+
+	if n == 0 || values[1] == 0 {
+		return 0, false
+	}
+
+	return values[0] / values[1], true
+}
+
+func (c *Container) resolveLength(ts Tokens) (float64, bool) {
+	if len(ts) != 1 {
+		return 0, false
+	}
+
+	switch ts[0].Type {
+	case TokenNumber:
+		v, err := strconv.ParseFloat(ts[0].Data, 64)
+
+		return v, err == nil && v == 0
+	case TokenDimension:
+		v, unit, err := parseDimension(ts[0].Data)
+		if err != nil {
+			return 0, false
+		}
+
+		switch unit = strings.ToLower(unit); unit {
+		case "em":
+			return v * c.FontSize, c.FontSize != 0
+		case "rem":
+			return v * c.RootFontSize, c.RootFontSize != 0
+		}
+
+		return absoluteLength(v, unit)
+	}
+
+	return 0, false
+}
+
+func (s *StyleQuery) evaluate(container *Container) queryResult {
+	if s.Feature != nil {
+		return s.Feature.evaluate(container)
+	} else if s.Not != nil {
+		return s.Not.evaluate(container).not()
+	}
+
+	return combineQueryResults(s.Or, func(yield func(queryResult) bool) {
+		for n := range s.InParens {
+			if !yield(s.InParens[n].evaluate(container)) {
+				return
+			}
+		}
+	})
+}
+
+func (s *StyleInParens) evaluate(container *Container) queryResult {
+	switch {
+	case s.Query != nil:
+		return s.Query.evaluate(container)
+	case s.Feature != nil:
+		return s.Feature.evaluate(container)
+	}
+
+	return queryUnknown
+}
+
+func (s *StyleFeature) evaluate(container *Container) queryResult {
+	name := s.Name.Data
+
+	if !strings.HasPrefix(name, "--") {
+		name = strings.ToLower(name)
+	}
+
+	value, ok := container.Properties[name]
+
+	if s.Value == nil {
+		return queryBool(ok && strings.TrimSpace(value) != "" && !strings.EqualFold(strings.TrimSpace(value), "initial"))
+	}
+
+	return queryBool(ok && normaliseValue(value) == normaliseValue(s.Value.String()))
+}
+
+func normaliseValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func evaluateScrollState(m *MediaCondition, container *Container) queryResult {
+	if m.Not != nil {
+		return evaluateScrollStateInParens(m.Not, container).not()
+	}
+
+	return combineQueryResults(m.Or, func(yield func(queryResult) bool) {
+		for n := range m.InParens {
+			if !yield(evaluateScrollStateInParens(&m.InParens[n], container)) {
+				return
+			}
+		}
+	})
+}
+
+func evaluateScrollStateInParens(m *MediaInParens, container *Container) queryResult {
+	switch {
+	case m.Condition != nil:
+		return evaluateScrollState(m.Condition, container)
+	case m.Feature != nil:
+		if m.Feature.Ranges != nil {
+			return queryUnknown
+		}
+
+		value, ok := container.ScrollState[m.Feature.FeatureName()]
+
+		if m.Feature.Value == nil {
+			return queryBool(ok && value != "" && value != "none")
+		}
+
+		return queryBool(ok && strings.EqualFold(m.Feature.Value.String(), value))
+	}
+
+	return queryUnknown
+}
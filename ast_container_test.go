@@ -0,0 +1,113 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestContainer(t *testing.T) {
+	container := &Container{
+		Names:        []string{"card"},
+		Width:        500,
+		Height:       300,
+		FontSize:     20,
+		RootFontSize: 16,
+		Properties: map[string]string{
+			"--theme": " dark ",
+			"--empty": "",
+			"color":   "red",
+		},
+		ScrollState: map[string]string{
+			"stuck":   "top",
+			"snapped": "none",
+		},
+	}
+
+	for n, test := range [...]struct {
+		Input  string
+		Output bool
+		Err    error
+	}{
+		{ // 1
+			Input:  "(width > 400px)",
+			Output: true,
+		},
+		{ // 2
+			Input:  "(min-width: 30em)",
+			Output: false,
+		},
+		{ // 3
+			Input:  "card (400px <= inline-size < 40rem)",
+			Output: true,
+		},
+		{ // 4
+			Input:  "sidebar (width > 400px)",
+			Output: false,
+		},
+		{ // 5
+			Input:  "sidebar (width > 400px), card (orientation: landscape)",
+			Output: true,
+		},
+		{ // 6
+			Input:  "card",
+			Output: true,
+		},
+		{ // 7
+			Input:  "(aspect-ratio > 3/2) and (height)",
+			Output: true,
+		},
+		{ // 8
+			Input:  "style(--theme: dark)",
+			Output: true,
+		},
+		{ // 9
+			Input:  "style(--theme: light) or style(not (--empty))",
+			Output: true,
+		},
+		{ // 10
+			Input:  "style((color: red) and (--theme))",
+			Output: true,
+		},
+		{ // 11
+			Input:  "scroll-state(stuck: top) and (not scroll-state(snapped))",
+			Output: true,
+		},
+		{ // 12
+			Input:  "scroll-state((stuck: bottom) or (snapped: x))",
+			Output: false,
+		},
+		{ // 13
+			Input:  "not (unknown-feature: 1)",
+			Output: false,
+		},
+		{ // 14
+			Input:  "(width > 400px) or (unknown stuff)",
+			Output: true,
+		},
+		{ // 15
+			Input: "(width > 400px) and (height > 100px) or (width < 100px)",
+			Err:   ErrMixedOperators,
+		},
+		{ // 16
+			Input: "none (width > 400px)",
+			Err:   ErrInvalidContainerQuery,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser("@container " + test.Input + " {}"))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if out := s.Rules[0].AtRule.Container.Evaluate(container); out != test.Output {
+			t.Errorf("test %d: expecting %v, got %v", n+1, test.Output, out)
+		}
+	}
+}
@@ -0,0 +1,782 @@
+package css
+
+import (
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"vimagination.zapto.org/parser"
+)
+
+// CounterSystem represents the algorithm used to construct a counter
+// representation.
+type CounterSystem uint8
+
+// Counter systems.
+const (
+	CounterSystemSymbolic CounterSystem = iota
+	CounterSystemCyclic
+	CounterSystemNumeric
+	CounterSystemAlphabetic
+	CounterSystemAdditive
+	CounterSystemFixed
+	CounterSystemExtends
+)
+
+// CounterStyle represents the descriptors of an @counter-style rule.
+//
+// Descriptors that were not specified are left as their zero value, except
+// for Range, where a nil value means unspecified and a non-nil empty slice
+// represents 'auto'.
+//
+// The FirstSymbol is only used by the fixed system, and Extends only by the
+// extends system.
+//
+// An @counter-style rule with an invalid name or descriptor is ignored, with
+// AtRule.CounterStyle left nil and the reason recorded in AtRule.Err.
+type CounterStyle struct {
+	Name            string
+	System          CounterSystem
+	FirstSymbol     int
+	Extends         string
+	Symbols         []string
+	AdditiveSymbols []AdditiveSymbol
+	Negative        *CounterNegative
+	Prefix          *string
+	Suffix          *string
+	Range           []CounterRange
+	Pad             *CounterPad
+	Fallback        string
+	SpeakAs         string
+
+	fallback *CounterStyle
+}
+
+// AdditiveSymbol represents a single weighted symbol of an additive counter
+// style.
+type AdditiveSymbol struct {
+	Weight int
+	Symbol string
+}
+
+// CounterNegative represents the symbols placed around the representation of
+// a negative counter value.
+type CounterNegative struct {
+	Prefix, Suffix string
+}
+
+// CounterRange represents an inclusive range of counter values; infinite
+// bounds are represented by math.MinInt and math.MaxInt.
+type CounterRange struct {
+	Min, Max int
+}
+
+// CounterPad represents the minimum Length of a counter representation, and
+// the Symbol used to pad shorter representations.
+type CounterPad struct {
+	Length int
+	Symbol string
+}
+
+func (cs *CounterStyle) parse(c *cssParser) error {
+	name, err := parseCounterStyleName(c)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "decimal", "disc", "square", "circle", "disclosure-open", "disclosure-closed":
+		return c.Error("CounterStyle", ErrInvalidCounterStyleName)
+	}
+
+	cs.Name = name
+
+	return nil
+}
+
+func parseCounterStyleName(c *cssParser) (string, error) {
+	if !c.Accept(TokenIdent) {
+		return "", c.Error("CounterStyle", ErrInvalidCounterStyleName)
+	}
+
+	name := c.GetLastToken().Data
+
+	if strings.EqualFold(name, "none") || isCSSWideKeyword(name) {
+		return "", c.Error("CounterStyle", ErrInvalidCounterStyleName)
+	} else if _, ok := predefinedCounterStyleDefinitions[strings.ToLower(name)]; ok {
+		return strings.ToLower(name), nil
+	}
+
+	return name, nil
+}
+
+func (cs *CounterStyle) parseBlock(b *Block) error {
+	for _, d := range b.Declarations() {
+		c := newCSSParserFromTokens(d.Value)
+
+		var err error
+
+		c.AcceptRunWhitespace()
+
+		switch d.Property() {
+		case "system":
+			err = cs.parseSystem(&c)
+		case "symbols":
+			cs.Symbols = nil
+
+			for err == nil && c.Peek().Type != parser.TokenDone {
+				var symbol string
+
+				if symbol, err = parseCounterSymbol(&c); err == nil {
+					cs.Symbols = append(cs.Symbols, symbol)
+
+					c.AcceptRunWhitespace()
+				}
+			}
+		case "additive-symbols":
+			err = cs.parseAdditiveSymbols(&c)
+		case "negative":
+			cs.Negative = new(CounterNegative)
+
+			if cs.Negative.Prefix, err = parseCounterSymbol(&c); err == nil {
+				d := c.NewGoal()
+
+				if d.AcceptRunWhitespace() != parser.TokenDone {
+					c.Score(d)
+
+					cs.Negative.Suffix, err = parseCounterSymbol(&c)
+				}
+			}
+		case "prefix":
+			cs.Prefix = new(string)
+			*cs.Prefix, err = parseCounterSymbol(&c)
+		case "suffix":
+			cs.Suffix = new(string)
+			*cs.Suffix, err = parseCounterSymbol(&c)
+		case "range":
+			err = cs.parseRange(&c)
+		case "pad":
+			err = cs.parsePad(&c)
+		case "fallback":
+			cs.Fallback, err = parseCounterStyleName(&c)
+		case "speak-as":
+			cs.SpeakAs, err = parseKeyword(&c, "auto", "bullets", "numbers", "words", "spell-out")
+			if err != nil {
+				cs.SpeakAs, err = parseCounterStyleName(&c)
+			}
+		default:
+			continue
+		}
+
+		if err == nil && c.AcceptRunWhitespace() != parser.TokenDone {
+			err = c.Error("CounterStyle", ErrUnexpectedToken)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return cs.validate()
+}
+
+func (cs *CounterStyle) parseSystem(c *cssParser) error {
+	if c.AcceptIdent("fixed") {
+		cs.System = CounterSystemFixed
+		cs.FirstSymbol = 1
+
+		d := c.NewGoal()
+
+		if d.AcceptRunWhitespace() != parser.TokenDone {
+			c.Score(d)
+
+			first, err := parseInteger(c)
+			if err != nil {
+				return err
+			}
+
+			cs.FirstSymbol = first
+		}
+
+		return nil
+	} else if c.AcceptIdent("extends") {
+		cs.System = CounterSystemExtends
+
+		c.AcceptRunWhitespace()
+
+		name, err := parseCounterStyleName(c)
+		if err != nil {
+			return err
+		}
+
+		cs.Extends = name
+
+		return nil
+	}
+
+	system, err := parseKeyword(c, "cyclic", "numeric", "alphabetic", "symbolic", "additive")
+	if err != nil {
+		return err
+	}
+
+	switch system {
+	case "cyclic":
+		cs.System = CounterSystemCyclic
+	case "numeric":
+		cs.System = CounterSystemNumeric
+	case "alphabetic":
+		cs.System = CounterSystemAlphabetic
+	case "symbolic":
+		cs.System = CounterSystemSymbolic
+	case "additive":
+		cs.System = CounterSystemAdditive
+	}
+
+	return nil
+}
+
+func parseCounterSymbol(c *cssParser) (string, error) {
+	if c.Accept(TokenString) {
+		return Unquote(c.GetLastToken().Data)
+	} else if c.Accept(TokenIdent) {
+		return c.GetLastToken().Data, nil
+	} else if tk := c.Peek(); tk.Type == TokenURL || tk.Type == TokenFunction {
+		d := c.NewGoal()
+
+		d.AcceptComponentValue()
+		c.Score(d)
+
+		return d.ToTokens().String(), nil
+	}
+
+	return "", c.Error("CounterStyle", ErrInvalidCounterSymbol)
+}
+
+func parseInteger(c *cssParser) (int, error) {
+	if !c.Accept(TokenNumber) {
+		return 0, c.Error("Integer", ErrInvalidNumber)
+	}
+
+	n, err := strconv.Atoi(c.GetLastToken().Data)
+	if err != nil {
+		return 0, c.Error("Integer", ErrInvalidNumber)
+	}
+
+	return n, nil
+}
+
+func (cs *CounterStyle) parseAdditiveSymbols(c *cssParser) error {
+	cs.AdditiveSymbols = nil
+
+	for {
+		var (
+			as  AdditiveSymbol
+			err error
+		)
+
+		if c.Peek().Type == TokenNumber {
+			if as.Weight, err = parseInteger(c); err == nil {
+				c.AcceptRunWhitespace()
+
+				as.Symbol, err = parseCounterSymbol(c)
+			}
+		} else if as.Symbol, err = parseCounterSymbol(c); err == nil {
+			c.AcceptRunWhitespace()
+
+			as.Weight, err = parseInteger(c)
+		}
+
+		if err != nil {
+			return err
+		} else if as.Weight < 0 || len(cs.AdditiveSymbols) > 0 && as.Weight >= cs.AdditiveSymbols[len(cs.AdditiveSymbols)-1].Weight {
+			return c.Error("CounterStyle", ErrInvalidAdditiveSymbols)
+		}
+
+		cs.AdditiveSymbols = append(cs.AdditiveSymbols, as)
+
+		d := c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenComma) {
+			return nil
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+}
+
+func (cs *CounterStyle) parseRange(c *cssParser) error {
+	cs.Range = []CounterRange{}
+
+	if c.AcceptIdent("auto") {
+		return nil
+	}
+
+	for {
+		var (
+			bounds [2]int
+			err    error
+		)
+
+		for n := range bounds {
+			if n > 0 {
+				c.AcceptRunWhitespace()
+			}
+
+			if c.AcceptIdent("infinite") {
+				bounds[n] = math.MinInt
+
+				if n > 0 {
+					bounds[n] = math.MaxInt
+				}
+			} else if bounds[n], err = parseInteger(c); err != nil {
+				return err
+			}
+		}
+
+		if bounds[0] > bounds[1] {
+			return c.Error("CounterStyle", ErrInvalidCounterRange)
+		}
+
+		cs.Range = append(cs.Range, CounterRange{Min: bounds[0], Max: bounds[1]})
+
+		d := c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenComma) {
+			return nil
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+}
+
+func (cs *CounterStyle) parsePad(c *cssParser) error {
+	var err error
+
+	cs.Pad = new(CounterPad)
+
+	if c.Peek().Type == TokenNumber {
+		if cs.Pad.Length, err = parseInteger(c); err == nil {
+			c.AcceptRunWhitespace()
+
+			cs.Pad.Symbol, err = parseCounterSymbol(c)
+		}
+	} else if cs.Pad.Symbol, err = parseCounterSymbol(c); err == nil {
+		c.AcceptRunWhitespace()
+
+		cs.Pad.Length, err = parseInteger(c)
+	}
+
+	if err == nil && cs.Pad.Length < 0 {
+		return c.Error("CounterStyle", ErrInvalidNumber)
+	}
+
+	return err
+}
+
+func (cs *CounterStyle) validate() error {
+	switch cs.System {
+	case CounterSystemCyclic, CounterSystemFixed, CounterSystemSymbolic:
+		if len(cs.Symbols) == 0 {
+			return ErrInvalidCounterSymbol
+		}
+	case CounterSystemAlphabetic, CounterSystemNumeric:
+		if len(cs.Symbols) < 2 {
+			return ErrInvalidCounterSymbol
+		}
+	case CounterSystemAdditive:
+		if len(cs.AdditiveSymbols) == 0 {
+			return ErrInvalidAdditiveSymbols
+		}
+	case CounterSystemExtends:
+		if cs.Symbols != nil || cs.AdditiveSymbols != nil {
+			return ErrInvalidCounterSymbol
+		}
+	}
+
+	return nil
+}
+
+const maxCounterRepetitions = 60
+
+// Render returns the marker text for the given counter value, which is the
+// counter representation surrounded by the prefix and suffix of the style.
+func (cs *CounterStyle) Render(n int) string {
+	prefix, suffix := "", ". "
+
+	if cs.Prefix != nil {
+		prefix = *cs.Prefix
+	}
+
+	if cs.Suffix != nil {
+		suffix = *cs.Suffix
+	}
+
+	return prefix + cs.Representation(n) + suffix
+}
+
+// Representation returns the representation of the given counter value,
+// without the prefix and suffix.
+//
+// When the value is outside of the range of the style, or cannot be
+// represented by it, the fallback style is used, with decimal used as the last
+// resort.
+//
+// An unresolved style using the extends system, such as one taken directly
+// from an AtRule, is resolved against the predefined counter styles.
+func (cs *CounterStyle) Representation(n int) string {
+	seen := make(map[*CounterStyle]bool)
+
+	for style := cs; ; {
+		if style.System == CounterSystemExtends {
+			style = newCounterStyleResolver(nil).extend(style)
+		}
+
+		if r, ok := style.represent(n); ok {
+			return r
+		}
+
+		seen[style] = true
+
+		if style = style.fallbackStyle(); seen[style] {
+			style = predefinedCounterStyles()["decimal"]
+		}
+	}
+}
+
+func (cs *CounterStyle) fallbackStyle() *CounterStyle {
+	if cs.fallback != nil {
+		return cs.fallback
+	} else if fb, ok := predefinedCounterStyles()[cs.Fallback]; ok {
+		return fb
+	}
+
+	return predefinedCounterStyles()["decimal"]
+}
+
+func (cs *CounterStyle) inRange(n int) bool {
+	if len(cs.Range) == 0 {
+		switch cs.System {
+		case CounterSystemAlphabetic, CounterSystemSymbolic:
+			return n >= 1
+		case CounterSystemAdditive:
+			return n >= 0
+		}
+
+		return true
+	}
+
+	for _, r := range cs.Range {
+		if r.Min <= n && n <= r.Max {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cs *CounterStyle) usesNegative() bool {
+	switch cs.System {
+	case CounterSystemSymbolic, CounterSystemAlphabetic, CounterSystemNumeric, CounterSystemAdditive:
+		return true
+	}
+
+	return false
+}
+
+func (cs *CounterStyle) represent(n int) (string, bool) {
+	if !cs.inRange(n) {
+		return "", false
+	}
+
+	negative := n < 0 && cs.usesNegative()
+	value := uint(n)
+
+	if negative {
+		value = -value
+	}
+
+	var (
+		repr string
+		ok   bool
+	)
+
+	switch cs.System {
+	case CounterSystemCyclic:
+		l := len(cs.Symbols)
+		repr, ok = cs.Symbols[((n-1)%l+l)%l], true
+	case CounterSystemFixed:
+		if n >= cs.FirstSymbol && n-cs.FirstSymbol < len(cs.Symbols) {
+			repr, ok = cs.Symbols[n-cs.FirstSymbol], true
+		}
+	case CounterSystemSymbolic:
+		repr, ok = cs.symbolic(value)
+	case CounterSystemAlphabetic:
+		repr, ok = cs.alphabetic(value)
+	case CounterSystemNumeric:
+		repr, ok = cs.numeric(value), true
+	case CounterSystemAdditive:
+		repr, ok = cs.additive(value)
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	neg := CounterNegative{Prefix: "-"}
+
+	if cs.Negative != nil {
+		neg = *cs.Negative
+	}
+
+	if cs.Pad != nil {
+		length := utf8.RuneCountInString(repr)
+
+		if negative {
+			length += utf8.RuneCountInString(neg.Prefix) + utf8.RuneCountInString(neg.Suffix)
+		}
+
+		if length < cs.Pad.Length {
+			repr = strings.Repeat(cs.Pad.Symbol, cs.Pad.Length-length) + repr
+		}
+	}
+
+	if negative {
+		repr = neg.Prefix + repr + neg.Suffix
+	}
+
+	return repr, true
+}
+
+func (cs *CounterStyle) symbolic(n uint) (string, bool) {
+	l := uint(len(cs.Symbols))
+	count := (n + l - 1) / l
+
+	if n == 0 || count > maxCounterRepetitions {
+		return "", false
+	}
+
+	return strings.Repeat(cs.Symbols[(n-1)%l], int(count)), true
+}
+
+func (cs *CounterStyle) alphabetic(n uint) (string, bool) {
+	if n == 0 {
+		return "", false
+	}
+
+	var (
+		l       = uint(len(cs.Symbols))
+		symbols []string
+	)
+
+	for ; n > 0; n = (n - 1) / l {
+		symbols = append(symbols, cs.Symbols[(n-1)%l])
+	}
+
+	return joinReversed(symbols), true
+}
+
+func (cs *CounterStyle) numeric(n uint) string {
+	if n == 0 {
+		return cs.Symbols[0]
+	}
+
+	var (
+		l       = uint(len(cs.Symbols))
+		symbols []string
+	)
+
+	for ; n > 0; n /= l {
+		symbols = append(symbols, cs.Symbols[n%l])
+	}
+
+	return joinReversed(symbols)
+}
+
+func joinReversed(symbols []string) string {
+	var sb strings.Builder
+
+	for n := len(symbols) - 1; n >= 0; n-- {
+		sb.WriteString(symbols[n])
+	}
+
+	return sb.String()
+}
+
+func (cs *CounterStyle) additive(n uint) (string, bool) {
+	if n == 0 {
+		if last := cs.AdditiveSymbols[len(cs.AdditiveSymbols)-1]; last.Weight == 0 {
+			return last.Symbol, true
+		}
+
+		return "", false
+	}
+
+	var (
+		sb          strings.Builder
+		repetitions uint
+	)
+
+	for _, as := range cs.AdditiveSymbols {
+		if as.Weight == 0 {
+			continue
+		}
+
+		weight := uint(as.Weight)
+		count := n / weight
+
+		if repetitions += count; repetitions > maxCounterRepetitions {
+			return "", false
+		}
+
+		sb.WriteString(strings.Repeat(as.Symbol, int(count)))
+
+		n -= count * weight
+	}
+
+	if n != 0 {
+		return "", false
+	}
+
+	return sb.String(), true
+}
+
+type counterStyleResolver struct {
+	styles    map[string]*CounterStyle
+	resolved  map[string]*CounterStyle
+	resolving map[string]bool
+}
+
+func newCounterStyleResolver(styles map[string]*CounterStyle) *counterStyleResolver {
+	resolved := make(map[string]*CounterStyle)
+
+	for name, cs := range predefinedCounterStyles() {
+		resolved[name] = cs
+	}
+
+	return &counterStyleResolver{
+		styles:    styles,
+		resolved:  resolved,
+		resolving: make(map[string]bool),
+	}
+}
+
+func (r *counterStyleResolver) resolve(name string) *CounterStyle {
+	if cs, ok := r.styles[name]; ok && !r.resolving[name] {
+		r.resolving[name] = true
+		r.resolved[name] = r.extend(cs)
+
+		delete(r.styles, name)
+		delete(r.resolving, name)
+	}
+
+	return r.resolved[name]
+}
+
+func (r *counterStyleResolver) extend(cs *CounterStyle) *CounterStyle {
+	resolved := *cs
+
+	if cs.System != CounterSystemExtends {
+		return &resolved
+	}
+
+	base := r.resolve(cs.Extends)
+	if base == nil {
+		base = r.resolved["decimal"]
+	}
+
+	resolved.System = base.System
+	resolved.FirstSymbol = base.FirstSymbol
+	resolved.Symbols = base.Symbols
+	resolved.AdditiveSymbols = base.AdditiveSymbols
+
+	if resolved.Negative == nil {
+		resolved.Negative = base.Negative
+	}
+
+	if resolved.Prefix == nil {
+		resolved.Prefix = base.Prefix
+	}
+
+	if resolved.Suffix == nil {
+		resolved.Suffix = base.Suffix
+	}
+
+	if resolved.Range == nil {
+		resolved.Range = base.Range
+	}
+
+	if resolved.Pad == nil {
+		resolved.Pad = base.Pad
+	}
+
+	if resolved.Fallback == "" {
+		resolved.Fallback = base.Fallback
+	}
+
+	if resolved.SpeakAs == "" {
+		resolved.SpeakAs = base.SpeakAs
+	}
+
+	return &resolved
+}
+
+func (r *counterStyleResolver) resolveAll() map[string]*CounterStyle {
+	for name := range r.styles {
+		r.resolve(name)
+	}
+
+	for _, cs := range r.resolved {
+		if cs.fallback == nil {
+			if cs.fallback = r.resolved[cs.Fallback]; cs.fallback == nil {
+				cs.fallback = r.resolved["decimal"]
+			}
+		}
+	}
+
+	return r.resolved
+}
+
+// CounterStyles returns all of the counter styles available to the Sheet,
+// keyed by name; that is, the predefined counter styles along with those
+// defined by @counter-style rules, with extended styles and fallbacks
+// resolved.
+//
+// When a name is defined more than once, the last definition wins.
+func (s *Sheet) CounterStyles() map[string]*CounterStyle {
+	styles := make(map[string]*CounterStyle)
+
+	s.walkRules(func(r *Rule) {
+		if r.AtRule != nil && r.AtRule.CounterStyle != nil {
+			styles[r.AtRule.CounterStyle.Name] = r.AtRule.CounterStyle
+		}
+	})
+
+	return newCounterStyleResolver(styles).resolveAll()
+}
+
+// PredefinedCounterStyle returns the predefined counter style with the given
+// name, such as 'decimal', 'lower-roman', or 'cjk-decimal'.
+func PredefinedCounterStyle(name string) (*CounterStyle, bool) {
+	cs, ok := predefinedCounterStyles()[strings.ToLower(name)]
+
+	return cs, ok
+}
+
+var predefinedCounterStyles = sync.OnceValue(func() map[string]*CounterStyle {
+	r := &counterStyleResolver{
+		styles:    maps.Clone(predefinedCounterStyleDefinitions),
+		resolved:  make(map[string]*CounterStyle),
+		resolving: make(map[string]bool),
+	}
+
+	return r.resolveAll()
+})
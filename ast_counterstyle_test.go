@@ -0,0 +1,238 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestPredefinedCounterStyles(t *testing.T) {
+	for n, test := range [...]struct {
+		Style  string
+		Value  int
+		Output string
+	}{
+		{"decimal", 1, "1. "},
+		{"decimal", -12, "-12. "},
+		{"decimal", 0, "0. "},
+		{"decimal-leading-zero", 7, "07. "},
+		{"decimal-leading-zero", -7, "-7. "},
+		{"decimal-leading-zero", 123, "123. "},
+		{"lower-roman", 1994, "mcmxciv. "},
+		{"upper-roman", 4, "IV. "},
+		{"upper-roman", 4000, "4000. "},
+		{"upper-roman", 0, "0. "},
+		{"lower-alpha", 1, "a. "},
+		{"lower-alpha", 26, "z. "},
+		{"lower-alpha", 27, "aa. "},
+		{"upper-latin", 703, "AAA. "},
+		{"lower-alpha", 0, "0. "},
+		{"lower-alpha", -1, "-1. "},
+		{"lower-greek", 25, "αα. "},
+		{"cjk-decimal", 2024, "二〇二四、"},
+		{"cjk-decimal", -1, "-1、"},
+		{"hebrew", 15, "טו. "},
+		{"armenian", 2024, "ՍԻԴ. "},
+		{"georgian", 19999, "ჵჰშჟთ. "},
+		{"arabic-indic", 42, "٤٢. "},
+		{"disc", 5, "• "},
+		{"square", -5, "▪ "},
+		{"hiragana", 3, "う、"},
+	} {
+		cs, ok := PredefinedCounterStyle(test.Style)
+		if !ok {
+			t.Errorf("test %d: expecting predefined style %q", n+1, test.Style)
+		} else if out := cs.Render(test.Value); out != test.Output {
+			t.Errorf("test %d: expecting %s(%d) to render %q, got %q", n+1, test.Style, test.Value, test.Output, out)
+		}
+	}
+}
+
+func TestPredefinedCounterStyleDefinitions(t *testing.T) {
+	styles := predefinedCounterStyles()
+
+	if len(styles) != len(predefinedCounterStyleDefinitions) {
+		t.Errorf("expecting %d predefined styles, got %d", len(predefinedCounterStyleDefinitions), len(styles))
+	}
+
+	for name, def := range predefinedCounterStyleDefinitions {
+		cs, ok := styles[name]
+
+		switch {
+		case def.Name != name:
+			t.Errorf("style %s: has name %s", name, def.Name)
+		case def.System == CounterSystemExtends && predefinedCounterStyleDefinitions[def.Extends] == nil:
+			t.Errorf("style %s: extends unknown style %s", name, def.Extends)
+		case !ok:
+			t.Errorf("style %s: not resolved", name)
+		case cs.System == CounterSystemExtends:
+			t.Errorf("style %s: extended style not resolved", name)
+		case len(cs.Symbols) == 0 && len(cs.AdditiveSymbols) == 0:
+			t.Errorf("style %s: no symbols", name)
+		case cs.fallback == nil:
+			t.Errorf("style %s: no fallback", name)
+		default:
+			if _, ok := cs.represent(1); !ok {
+				t.Errorf("style %s: cannot represent 1", name)
+			}
+		}
+	}
+}
+
+func TestCounterStyles(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(`@counter-style thumbs {
+	system: cyclic;
+	symbols: "👍" "👎";
+	suffix: " ";
+}
+@counter-style fixed {
+	system: fixed 3;
+	symbols: a b c;
+	fallback: upper-roman;
+}
+@counter-style stars {
+	system: symbolic;
+	symbols: "*" "†";
+	range: 1 4, 10 infinite;
+	prefix: "(";
+	suffix: ")";
+}
+@counter-style dice {
+	system: additive;
+	additive-symbols: "⚅" 6, 5 "⚄", 4 "⚃", 3 "⚂", 2 "⚁", 1 "⚀";
+	negative: "(" ")";
+	pad: 3 "_";
+	range: infinite infinite;
+}
+@counter-style binary {
+	system: numeric;
+	symbols: "0" "1";
+}
+@counter-style padded-roman {
+	system: extends LOWER-ROMAN;
+	pad: 4 "-";
+	suffix: ":";
+}
+@counter-style loop-a {
+	system: extends loop-b;
+}
+@counter-style loop-b {
+	system: extends loop-a;
+	fallback: loop-c;
+}
+@counter-style loop-c {
+	system: fixed;
+	symbols: x;
+	fallback: loop-b;
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	styles := s.CounterStyles()
+
+	for n, test := range [...]struct {
+		Style  string
+		Value  int
+		Output string
+	}{
+		{"thumbs", 1, "👍 "},
+		{"thumbs", 2, "👎 "},
+		{"thumbs", 3, "👍 "},
+		{"thumbs", 0, "👎 "},
+		{"fixed", 4, "b. "},
+		{"fixed", 6, "VI. "},
+		{"stars", 3, "(**)"},
+		{"stars", 4, "(††)"},
+		{"stars", 5, "(5)"},
+		{"stars", 11, "(******)"},
+		{"dice", 13, "⚅⚅⚀. "},
+		{"dice", 0, "0. "},
+		{"dice", -3, "(⚂). "},
+		{"dice", 2, "__⚁. "},
+		{"binary", 10, "1010. "},
+		{"binary", -2, "-10. "},
+		{"padded-roman", 3, "-iii:"},
+		{"padded-roman", 5000, "5000:"},
+		{"loop-a", 12, "12. "},
+		{"loop-c", 5, "5. "},
+		{"lower-roman", 9, "ix. "},
+	} {
+		cs, ok := styles[test.Style]
+		if !ok {
+			t.Errorf("test %d: expecting style %q", n+1, test.Style)
+		} else if out := cs.Render(test.Value); out != test.Output {
+			t.Errorf("test %d: expecting %s(%d) to render %q, got %q", n+1, test.Style, test.Value, test.Output, out)
+		}
+	}
+}
+
+func TestCounterStyleErrors(t *testing.T) {
+	for n, test := range [...]struct {
+		Input string
+		Err   error
+	}{
+		{ // 1
+			Input: "@counter-style decimal { system: cyclic; symbols: a }",
+			Err:   ErrInvalidCounterStyleName,
+		},
+		{ // 2
+			Input: "@counter-style none { system: cyclic; symbols: a }",
+			Err:   ErrInvalidCounterStyleName,
+		},
+		{ // 3
+			Input: "@counter-style a { system: alphabetic; symbols: a }",
+			Err:   ErrInvalidCounterSymbol,
+		},
+		{ // 4
+			Input: "@counter-style a { system: additive; additive-symbols: 1 a, 5 b }",
+			Err:   ErrInvalidAdditiveSymbols,
+		},
+		{ // 5
+			Input: "@counter-style a { system: additive }",
+			Err:   ErrInvalidAdditiveSymbols,
+		},
+		{ // 6
+			Input: "@counter-style a { system: cyclic; symbols: a; range: 5 1 }",
+			Err:   ErrInvalidCounterRange,
+		},
+		{ // 7
+			Input: "@counter-style a { system: extends decimal; symbols: a }",
+			Err:   ErrInvalidCounterSymbol,
+		},
+		{ // 8
+			Input: "@counter-style a { system: random; symbols: a }",
+			Err:   ErrInvalidKeyword,
+		},
+		{ // 9
+			Input: "@counter-style a { system: fixed 1.5; symbols: a }",
+			Err:   ErrInvalidNumber,
+		},
+		{ // 10
+			Input: "@counter-style a { system: cyclic; symbols: a; pad: -1 '0' }",
+			Err:   ErrInvalidNumber,
+		},
+		{ // 11
+			Input: "@counter-style a;",
+			Err:   ErrMissingBlock,
+		},
+		{ // 12
+			Input: "@counter-style x { system: bogus }",
+			Err:   ErrInvalidKeyword,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input + " b {}"))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if len(s.Rules) != 2 {
+			t.Errorf("test %d: expecting 2 rules, got %d", n+1, len(s.Rules))
+		} else if a := s.Rules[0].AtRule; a.CounterStyle != nil {
+			t.Errorf("test %d: expecting no CounterStyle", n+1)
+		} else if !errors.Is(a.Err, test.Err) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, a.Err)
+		} else if _, ok := s.CounterStyles()["x"]; ok {
+			t.Errorf("test %d: expecting invalid counter style to be ignored", n+1)
+		}
+	}
+}
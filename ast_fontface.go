@@ -0,0 +1,533 @@
+package css
+
+import (
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// FontFace represents the descriptors of an @font-face rule.
+//
+// The metrics overrides, if set, are percentages.
+//
+// Invalid descriptors are ignored, as they would be by a browser, with the
+// reason for each recorded in Errors.
+type FontFace struct {
+	Family          string
+	Src             []FontSource
+	Weight          *FontRange
+	Style           *FontStyle
+	Stretch         *FontRange
+	UnicodeRange    []UnicodeRange
+	Display         string
+	AscentOverride  *float64
+	DescentOverride *float64
+	LineGapOverride *float64
+	SizeAdjust      *float64
+	Errors          []error
+}
+
+// FontSource represents a single entry in the src descriptor of an @font-face
+// rule.
+//
+// Only one of URL or Local will be set.
+type FontSource struct {
+	URL    string
+	Local  string
+	Format string
+	Tech   []string
+	Tokens Tokens
+}
+
+// FontRange represents a range of font-weight or font-stretch values. A single
+// value is represented with equal Min and Max values.
+type FontRange struct {
+	Auto     bool
+	Min, Max float64
+}
+
+// FontStyle represents the font-style descriptor of an @font-face rule.
+//
+// The angles, in degrees, are only set for the 'oblique' style.
+type FontStyle struct {
+	Auto               bool
+	Style              string
+	MinAngle, MaxAngle float64
+}
+
+// UnicodeRange represents an inclusive range of code points.
+type UnicodeRange struct {
+	Start, End rune
+}
+
+// Contains returns true if the given rune is within the range.
+func (u UnicodeRange) Contains(r rune) bool {
+	return u.Start <= r && r <= u.End
+}
+
+func (f *FontFace) parse(b *Block) error {
+	for _, d := range b.Declarations() {
+		c := newCSSParserFromTokens(d.Value)
+		valid := *f
+
+		var err error
+
+		switch d.Property() {
+		case "font-family":
+			f.Family, err = parseFamilyName(&c)
+		case "src":
+			err = f.parseSrc(&c)
+		case "font-weight":
+			f.Weight, err = parseFontRange(&c, fontWeight, TokenNumber)
+		case "font-style":
+			f.Style = new(FontStyle)
+			err = f.Style.parse(&c)
+		case "font-stretch", "font-width":
+			f.Stretch, err = parseFontRange(&c, fontStretch, TokenPercentage)
+		case "unicode-range":
+			err = f.parseUnicodeRange(&c)
+		case "font-display":
+			f.Display, err = parseKeyword(&c, "auto", "block", "swap", "fallback", "optional")
+		case "ascent-override":
+			f.AscentOverride, err = parseMetricsOverride(&c, true)
+		case "descent-override":
+			f.DescentOverride, err = parseMetricsOverride(&c, true)
+		case "line-gap-override":
+			f.LineGapOverride, err = parseMetricsOverride(&c, true)
+		case "size-adjust":
+			f.SizeAdjust, err = parseMetricsOverride(&c, false)
+		default:
+			continue
+		}
+
+		if err == nil && c.AcceptRunWhitespace() != parser.TokenDone {
+			err = c.Error("FontFace", ErrUnexpectedToken)
+		}
+
+		if err != nil {
+			*f = valid
+			f.Errors = append(f.Errors, err)
+		}
+	}
+
+	if f.Family == "" {
+		return ErrMissingFontFamily
+	} else if len(f.Src) == 0 {
+		return ErrMissingFontSource
+	}
+
+	return nil
+}
+
+func parseFamilyName(c *cssParser) (string, error) {
+	if c.Accept(TokenString) {
+		return Unquote(c.GetLastToken().Data)
+	}
+
+	var names []string
+
+	for {
+		if !c.Accept(TokenIdent) {
+			break
+		}
+
+		names = append(names, c.GetLastToken().Data)
+
+		d := c.NewGoal()
+
+		if d.AcceptRunWhitespace() != TokenIdent {
+			break
+		}
+
+		c.Score(d)
+	}
+
+	if len(names) == 0 {
+		return "", c.Error("FamilyName", ErrInvalidFamilyName)
+	}
+
+	return strings.Join(names, " "), nil
+}
+
+func parseKeyword(c *cssParser, keywords ...string) (string, error) {
+	for _, keyword := range keywords {
+		if c.AcceptIdent(keyword) {
+			return keyword, nil
+		}
+	}
+
+	return "", c.Error("Keyword", ErrInvalidKeyword)
+}
+
+func (f *FontFace) parseSrc(c *cssParser) error {
+	f.Src = nil
+
+	for {
+		d := c.NewGoal()
+
+		var fs FontSource
+
+		if err := fs.parse(&d); err != nil {
+			return c.Error("Src", err)
+		}
+
+		f.Src = append(f.Src, fs)
+
+		c.Score(d)
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenComma) {
+			return nil
+		}
+
+		c.AcceptRunWhitespace()
+	}
+}
+
+func (f *FontSource) parse(c *cssParser) error {
+	var err error
+
+	if c.AcceptFunction("local") {
+		c.AcceptRunWhitespace()
+
+		if f.Local, err = parseFamilyName(c); err != nil {
+			return c.Error("FontSource", err)
+		}
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("FontSource", ErrMissingCloseParen)
+		}
+
+		f.Tokens = c.ToTokens()
+
+		return nil
+	}
+
+	if f.URL, err = acceptURL(c); err != nil {
+		return c.Error("FontSource", err)
+	}
+
+	d := c.NewGoal()
+
+	d.AcceptRunWhitespace()
+
+	if d.AcceptFunction("format") {
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenIdent, TokenString) {
+			return d.Error("FontSource", ErrInvalidFontFeature)
+		}
+
+		f.Format = fontFeatureName(d.GetLastToken())
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenCloseParen) {
+			return d.Error("FontSource", ErrMissingCloseParen)
+		}
+
+		c.Score(d)
+
+		d = c.NewGoal()
+
+		d.AcceptRunWhitespace()
+	}
+
+	if d.AcceptFunction("tech") {
+		for {
+			d.AcceptRunWhitespace()
+
+			if !d.Accept(TokenIdent) {
+				return d.Error("FontSource", ErrInvalidFontFeature)
+			}
+
+			f.Tech = append(f.Tech, strings.ToLower(d.GetLastToken().Data))
+
+			d.AcceptRunWhitespace()
+
+			if d.Accept(TokenCloseParen) {
+				break
+			} else if !d.Accept(TokenComma) {
+				return d.Error("FontSource", ErrMissingCloseParen)
+			}
+		}
+
+		c.Score(d)
+	}
+
+	f.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func acceptURL(c *cssParser) (string, error) {
+	if c.Accept(TokenURL) {
+		return UnURL(c.GetLastToken().Data)
+	} else if c.AcceptFunction("url") {
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenString) {
+			return "", c.Error("URL", ErrBadURL)
+		}
+
+		url, err := Unquote(c.GetLastToken().Data)
+		if err != nil {
+			return "", c.Error("URL", err)
+		}
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return "", c.Error("URL", ErrMissingCloseParen)
+		}
+
+		return url, nil
+	}
+
+	return "", c.Error("URL", ErrMissingURL)
+}
+
+var (
+	fontWeight = map[string]float64{
+		"normal": 400,
+		"bold":   700,
+	}
+	fontStretch = map[string]float64{
+		"ultra-condensed": 50,
+		"extra-condensed": 62.5,
+		"condensed":       75,
+		"semi-condensed":  87.5,
+		"normal":          100,
+		"semi-expanded":   112.5,
+		"expanded":        125,
+		"extra-expanded":  150,
+		"ultra-expanded":  200,
+	}
+)
+
+func parseFontRange(c *cssParser, keywords map[string]float64, typ parser.TokenType) (*FontRange, error) {
+	if c.AcceptIdent("auto") {
+		return &FontRange{Auto: true}, nil
+	}
+
+	var (
+		values [2]float64
+		n      int
+	)
+
+	for ; n < 2; n++ {
+		if n > 0 {
+			d := c.NewGoal()
+
+			if d.AcceptRunWhitespace() == parser.TokenDone {
+				break
+			}
+
+			c.Score(d)
+		}
+
+		if c.Accept(TokenIdent) {
+			v, ok := keywords[strings.ToLower(c.GetLastToken().Data)]
+			if !ok {
+				return nil, c.Error("FontRange", ErrInvalidKeyword)
+			}
+
+			values[n] = v
+		} else if c.Accept(typ) {
+			data := strings.TrimSuffix(c.GetLastToken().Data, "%")
+
+			v, err := strconv.ParseFloat(data, 64)
+			if err != nil || v < 0 || typ == TokenNumber && (v < 1 || v > 1000) {
+				return nil, c.Error("FontRange", ErrInvalidFontRange)
+			}
+
+			values[n] = v
+		} else {
+			return nil, c.Error("FontRange", ErrUnexpectedToken)
+		}
+	}
+
+	if n == 1 {
+		values[1] = values[0]
+	}
+
+	return &FontRange{Min: min(values[0], values[1]), Max: max(values[0], values[1])}, nil
+}
+
+func (f *FontStyle) parse(c *cssParser) error {
+	if c.AcceptIdent("auto") {
+		f.Auto = true
+	} else if c.AcceptIdent("normal") {
+		f.Style = "normal"
+	} else if c.AcceptIdent("italic") {
+		f.Style = "italic"
+	} else if c.AcceptIdent("oblique") {
+		f.Style = "oblique"
+		f.MinAngle = 14
+		f.MaxAngle = 14
+
+		for n := range 2 {
+			d := c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if !d.Accept(TokenDimension) {
+				break
+			}
+
+			angle, err := parseAngle(d.GetLastToken().Data)
+			if err != nil || angle < -90 || angle > 90 {
+				return d.Error("FontStyle", ErrInvalidAngle)
+			}
+
+			c.Score(d)
+
+			if n == 0 {
+				f.MinAngle = angle
+			}
+
+			f.MaxAngle = angle
+		}
+
+		f.MinAngle, f.MaxAngle = min(f.MinAngle, f.MaxAngle), max(f.MinAngle, f.MaxAngle)
+	} else {
+		return c.Error("FontStyle", ErrInvalidKeyword)
+	}
+
+	return nil
+}
+
+func parseAngle(data string) (float64, error) {
+	v, unit, err := parseDimension(data)
+	if err != nil {
+		return 0, err
+	}
+
+	q, err := Quantity{Value: v, Unit: unit}.ConvertTo("deg")
+	if err != nil {
+		return 0, ErrInvalidAngle
+	}
+
+	return q.Value, nil
+}
+
+func (f *FontFace) parseUnicodeRange(c *cssParser) error {
+	f.UnicodeRange = nil
+
+	for {
+		d := c.NewGoal()
+
+	Loop:
+		for {
+			switch d.Peek().Type {
+			case TokenComma, TokenWhitespace, TokenComment, parser.TokenDone:
+				break Loop
+			}
+
+			d.Skip()
+		}
+
+		ur, err := parseUnicodeRange(d.ToTokens().String())
+		if err != nil {
+			return c.Error("UnicodeRange", err)
+		}
+
+		f.UnicodeRange = append(f.UnicodeRange, ur)
+
+		c.Score(d)
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenComma) {
+			return nil
+		}
+
+		c.AcceptRunWhitespace()
+	}
+}
+
+func parseUnicodeRange(str string) (UnicodeRange, error) {
+	if len(str) < 3 || str[0] != 'u' && str[0] != 'U' || str[1] != '+' {
+		return UnicodeRange{}, ErrInvalidUnicodeRange
+	}
+
+	start, rest, _ := strings.Cut(str[2:], "-")
+
+	if strings.Contains(start, "?") {
+		if rest != "" || len(start) > 6 {
+			return UnicodeRange{}, ErrInvalidUnicodeRange
+		}
+
+		first := strings.TrimRight(start, "?")
+
+		if strings.Contains(first, "?") {
+			return UnicodeRange{}, ErrInvalidUnicodeRange
+		}
+
+		s, err := parseCodePoint(first + strings.Repeat("0", len(start)-len(first)))
+		if err != nil {
+			return UnicodeRange{}, err
+		}
+
+		e, err := parseCodePoint(first + strings.Repeat("F", len(start)-len(first)))
+		if err != nil {
+			return UnicodeRange{}, err
+		}
+
+		return UnicodeRange{Start: s, End: e}, nil
+	}
+
+	s, err := parseCodePoint(start)
+	if err != nil {
+		return UnicodeRange{}, err
+	}
+
+	e := s
+
+	if rest != "" {
+		if e, err = parseCodePoint(rest); err != nil {
+			return UnicodeRange{}, err
+		}
+	}
+
+	if s > e {
+		return UnicodeRange{}, ErrInvalidUnicodeRange
+	}
+
+	return UnicodeRange{Start: s, End: e}, nil
+}
+
+func parseCodePoint(str string) (rune, error) {
+	if len(str) == 0 || len(str) > 6 || strings.Trim(str, hexDigits) != "" {
+		return 0, ErrInvalidUnicodeRange
+	}
+
+	cp, _ := strconv.ParseUint(str, 16, 32)
+
+	if cp > 0x10FFFF {
+		return 0, ErrInvalidUnicodeRange
+	}
+
+	return rune(cp), nil
+}
+
+func parseMetricsOverride(c *cssParser, allowNormal bool) (*float64, error) {
+	if allowNormal && c.AcceptIdent("normal") {
+		return nil, nil
+	}
+
+	if !c.Accept(TokenPercentage) {
+		return nil, c.Error("MetricsOverride", ErrInvalidPercentage)
+	}
+
+	data := c.GetLastToken().Data
+
+	v, err := strconv.ParseFloat(data[:len(data)-1], 64)
+	if err != nil || v < 0 {
+		return nil, c.Error("MetricsOverride", ErrInvalidPercentage)
+	}
+
+	return &v, nil
+}
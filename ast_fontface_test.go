@@ -0,0 +1,188 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestFontFace(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output FontFace
+		Errors []error
+		Err    error
+	}{
+		{ // 1
+			Input: `font-family: "My Font"; src: url(a.woff2) format("woff2"), url('a.woff') format(woff);`,
+			Output: FontFace{
+				Family: "My Font",
+				Src: []FontSource{
+					{URL: "a.woff2", Format: "woff2"},
+					{URL: "a.woff", Format: "woff"},
+				},
+			},
+		},
+		{ // 2
+			Input: `font-family: My Font; src: local(My Font), local("Other"), url(a.ttf) tech(color-COLRv1, variations); font-weight: 100 900; font-stretch: condensed 150%; font-style: oblique 20deg -10deg; font-display: swap`,
+			Output: FontFace{
+				Family: "My Font",
+				Src: []FontSource{
+					{Local: "My Font"},
+					{Local: "Other"},
+					{URL: "a.ttf", Tech: []string{"color-colrv1", "variations"}},
+				},
+				Weight:  &FontRange{Min: 100, Max: 900},
+				Stretch: &FontRange{Min: 75, Max: 150},
+				Style:   &FontStyle{Style: "oblique", MinAngle: -10, MaxAngle: 20},
+				Display: "swap",
+			},
+		},
+		{ // 3
+			Input: `font-family: a; src: url(a); font-weight: bold; font-style: oblique; unicode-range: U+0025-00FF, u+4??, U+A5; ascent-override: 90%; descent-override: normal; line-gap-override: 0%; size-adjust: 110%`,
+			Output: FontFace{
+				Family:          "a",
+				Src:             []FontSource{{URL: "a"}},
+				Weight:          &FontRange{Min: 700, Max: 700},
+				Style:           &FontStyle{Style: "oblique", MinAngle: 14, MaxAngle: 14},
+				UnicodeRange:    []UnicodeRange{{0x25, 0xff}, {0x400, 0x4ff}, {0xa5, 0xa5}},
+				AscentOverride:  ptr(90.0),
+				LineGapOverride: ptr(0.0),
+				SizeAdjust:      ptr(110.0),
+			},
+		},
+		{ // 4
+			Input: `font-family: a; src: url(a); font-weight: auto; font-style: auto; font-stretch: auto`,
+			Output: FontFace{
+				Family:  "a",
+				Src:     []FontSource{{URL: "a"}},
+				Weight:  &FontRange{Auto: true},
+				Style:   &FontStyle{Auto: true},
+				Stretch: &FontRange{Auto: true},
+			},
+		},
+		{ // 5
+			Input: `src: url(a)`,
+			Err:   ErrMissingFontFamily,
+		},
+		{ // 6
+			Input: `font-family: a`,
+			Err:   ErrMissingFontSource,
+		},
+		{ // 7
+			Input: `font-family: a; src: url(a); font-weight: 1001`,
+			Output: FontFace{
+				Family: "a",
+				Src:    []FontSource{{URL: "a"}},
+			},
+			Errors: []error{ErrInvalidFontRange},
+		},
+		{ // 8
+			Input: `font-family: a; src: url(a); unicode-range: U+0025, U+00FF-0025`,
+			Output: FontFace{
+				Family: "a",
+				Src:    []FontSource{{URL: "a"}},
+			},
+			Errors: []error{ErrInvalidUnicodeRange},
+		},
+		{ // 9
+			Input: `font-family: a; src: url(a); font-display: swap; font-display: fast`,
+			Output: FontFace{
+				Family:  "a",
+				Src:     []FontSource{{URL: "a"}},
+				Display: "swap",
+			},
+			Errors: []error{ErrInvalidKeyword},
+		},
+		{ // 10
+			Input: `font-family: a; src: url(b); src: url(a) format(woff) extra`,
+			Output: FontFace{
+				Family: "a",
+				Src:    []FontSource{{URL: "b"}},
+			},
+			Errors: []error{ErrUnexpectedToken},
+		},
+		{ // 11
+			Input: `font-family: a; src: url(a) format(woff) extra`,
+			Err:   ErrMissingFontSource,
+		},
+		{ // 12
+			Input: `font-family: a; font-style: oblique 100deg; src: url(a)`,
+			Output: FontFace{
+				Family: "a",
+				Src:    []FontSource{{URL: "a"}},
+			},
+			Errors: []error{ErrInvalidAngle},
+		},
+		{ // 13
+			Input: `font-family: a; src: url(a.woff); src: url(b.woff); unicode-range: U+0-7F; unicode-range: U+A5`,
+			Output: FontFace{
+				Family:       "a",
+				Src:          []FontSource{{URL: "b.woff"}},
+				UnicodeRange: []UnicodeRange{{0xa5, 0xa5}},
+			},
+		},
+		{ // 14
+			Input: `font-family: a; src: url(a); garbage`,
+			Output: FontFace{
+				Family: "a",
+				Src:    []FontSource{{URL: "a"}},
+			},
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser("@font-face {" + test.Input + "} a {}"))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		} else if len(s.Rules) != 2 {
+			t.Errorf("test %d: expecting 2 rules, got %d", n+1, len(s.Rules))
+
+			continue
+		}
+
+		a := s.Rules[0].AtRule
+
+		if test.Err != nil {
+			if a.FontFace != nil {
+				t.Errorf("test %d: expecting no FontFace", n+1)
+			} else if !errors.Is(a.Err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, a.Err)
+			}
+
+			continue
+		} else if a.Err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, a.Err)
+
+			continue
+		}
+
+		f := a.FontFace
+
+		for n := range f.Src {
+			f.Src[n].Tokens = nil
+		}
+
+		if len(f.Errors) != len(test.Errors) {
+			t.Errorf("test %d: expecting %d errors, got %d", n+1, len(test.Errors), len(f.Errors))
+		} else {
+			for m, err := range test.Errors {
+				if !errors.Is(f.Errors[m], err) {
+					t.Errorf("test %d: expecting error %d to be %v, got %v", n+1, m+1, err, f.Errors[m])
+				}
+			}
+		}
+
+		f.Errors = nil
+
+		if !reflect.DeepEqual(*f, test.Output) {
+			t.Errorf("test %d: expecting %+v, got %+v", n+1, test.Output, *f)
+		}
+	}
+}
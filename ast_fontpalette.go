@@ -0,0 +1,134 @@
+package css
+
+import (
+	"vimagination.zapto.org/parser"
+)
+
+// FontPaletteValues represents an @font-palette-values rule, which customises
+// the colour palette of a colour font.
+//
+// The BasePalette is either 'light', 'dark', or a palette index, with an
+// empty value meaning unspecified.
+type FontPaletteValues struct {
+	Name           string
+	FontFamily     []string
+	BasePalette    string
+	OverrideColors []PaletteColor
+}
+
+// PaletteColor represents a single entry of the override-colors descriptor,
+// replacing the colour at Index in the palette.
+type PaletteColor struct {
+	Index int
+	Color Tokens
+}
+
+func (f *FontPaletteValues) parse(c *cssParser) error {
+	name, err := parseDashedIdent(c)
+	if err != nil {
+		return c.Error("FontPaletteValues", err)
+	}
+
+	f.Name = name
+
+	return nil
+}
+
+func (f *FontPaletteValues) parseBlock(b *Block) error {
+	for _, d := range b.Declarations() {
+		c := newCSSParserFromTokens(d.Value)
+
+		var err error
+
+		c.AcceptRunWhitespace()
+
+		switch d.Property() {
+		case "font-family":
+			f.FontFamily, err = parseFamilyNames(&c)
+		case "base-palette":
+			if c.Peek().Type == TokenNumber {
+				var index int
+
+				if index, err = parseInteger(&c); err == nil && index < 0 {
+					err = c.Error("FontPaletteValues", ErrInvalidNumber)
+				}
+
+				f.BasePalette = c.GetLastToken().Data
+			} else {
+				f.BasePalette, err = parseKeyword(&c, "light", "dark")
+			}
+		case "override-colors":
+			err = f.parseOverrideColors(&c)
+		default:
+			continue
+		}
+
+		if err == nil && c.AcceptRunWhitespace() != parser.TokenDone {
+			err = c.Error("FontPaletteValues", ErrUnexpectedToken)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseFamilyNames(c *cssParser) ([]string, error) {
+	var names []string
+
+	for {
+		name, err := parseFamilyName(c)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+
+		d := c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenComma) {
+			return names, nil
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+}
+
+func (f *FontPaletteValues) parseOverrideColors(c *cssParser) error {
+	for {
+		index, err := parseInteger(c)
+		if err != nil {
+			return err
+		} else if index < 0 {
+			return c.Error("FontPaletteValues", ErrInvalidNumber)
+		}
+
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+
+		if !d.AcceptComponentValue() || !isColor(d.ToTokens()) {
+			return c.Error("FontPaletteValues", ErrInvalidColor)
+		}
+
+		f.OverrideColors = append(f.OverrideColors, PaletteColor{Index: index, Color: d.ToTokens()})
+
+		c.Score(d)
+
+		d = c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenComma) {
+			return nil
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+}
@@ -0,0 +1,85 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestFontPaletteValues(t *testing.T) {
+	for n, test := range [...]struct {
+		Input       string
+		Name        string
+		FontFamily  []string
+		BasePalette string
+		Overrides   map[int]string
+		Err         error
+	}{
+		{ // 1
+			Input:       "@font-palette-values --identifier { font-family: Bixa, \"Bixa Color\"; base-palette: 1; override-colors: 0 red, 1 #00ff00, 4 rgb(0 0 255) }",
+			Name:        "--identifier",
+			FontFamily:  []string{"Bixa", "Bixa Color"},
+			BasePalette: "1",
+			Overrides:   map[int]string{0: "red", 1: "#00ff00", 4: "rgb(0 0 255)"},
+		},
+		{ // 2
+			Input:       "@font-palette-values --dark { font-family: Nabla Color; base-palette: DARK }",
+			Name:        "--dark",
+			FontFamily:  []string{"Nabla Color"},
+			BasePalette: "dark",
+		},
+		{ // 3
+			Input: "@font-palette-values --a { override-colors: 0 reddish }",
+			Err:   ErrInvalidColor,
+		},
+		{ // 4
+			Input: "@font-palette-values --a { override-colors: -1 red }",
+			Err:   ErrInvalidNumber,
+		},
+		{ // 5
+			Input: "@font-palette-values --a { base-palette: -1 }",
+			Err:   ErrInvalidNumber,
+		},
+		{ // 6
+			Input: "@font-palette-values a {}",
+			Err:   ErrInvalidDashedIdent,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		fp := s.Rules[0].AtRule.FontPaletteValues
+
+		if fp.Name != test.Name {
+			t.Errorf("test %d: expecting name %q, got %q", n+1, test.Name, fp.Name)
+		} else if !reflect.DeepEqual(fp.FontFamily, test.FontFamily) {
+			t.Errorf("test %d: expecting font families %v, got %v", n+1, test.FontFamily, fp.FontFamily)
+		} else if fp.BasePalette != test.BasePalette {
+			t.Errorf("test %d: expecting base palette %q, got %q", n+1, test.BasePalette, fp.BasePalette)
+		} else if len(fp.OverrideColors) != len(test.Overrides) {
+			t.Errorf("test %d: expecting %d override colors, got %d", n+1, len(test.Overrides), len(fp.OverrideColors))
+		} else {
+			for _, pc := range fp.OverrideColors {
+				if color := pc.Color.String(); color != test.Overrides[pc.Index] {
+					t.Errorf("test %d: expecting colour %d to be %q, got %q", n+1, pc.Index, test.Overrides[pc.Index], color)
+				}
+			}
+		}
+	}
+}
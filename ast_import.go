@@ -0,0 +1,149 @@
+package css
+
+import (
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// ImportRule represents the prelude of an @import rule.
+//
+// When Layer is true, the imported sheet is placed in a cascade layer, which
+// is anonymous when LayerName is empty.
+type ImportRule struct {
+	URL       string
+	Layer     bool
+	LayerName string
+	Supports  *SupportsCondition
+	Media     *MediaQueryList
+	Tokens    Tokens
+}
+
+func (i *ImportRule) parse(c *cssParser) error {
+	var err error
+
+	if c.Accept(TokenURL) {
+		i.URL, err = UnURL(c.GetLastToken().Data)
+	} else if c.Accept(TokenString) {
+		i.URL, err = Unquote(c.GetLastToken().Data)
+	} else if c.AcceptFunction("url") {
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenString) {
+			return c.Error("ImportRule", ErrBadURL)
+		}
+
+		i.URL, err = Unquote(c.GetLastToken().Data)
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("ImportRule", ErrMissingCloseParen)
+		}
+	} else {
+		return c.Error("ImportRule", ErrMissingURL)
+	}
+
+	if err != nil {
+		return c.Error("ImportRule", err)
+	}
+
+	d := c.NewGoal()
+
+	d.AcceptRunWhitespace()
+
+	if d.AcceptIdent("layer") {
+		i.Layer = true
+
+		c.Score(d)
+	} else if d.AcceptFunction("layer") {
+		i.Layer = true
+
+		d.AcceptRunWhitespace()
+
+		if i.LayerName, err = acceptLayerName(&d); err != nil {
+			return c.Error("ImportRule", err)
+		}
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenCloseParen) {
+			return d.Error("ImportRule", ErrMissingCloseParen)
+		}
+
+		c.Score(d)
+	}
+
+	d = c.NewGoal()
+
+	d.AcceptRunWhitespace()
+
+	if d.AcceptFunction("supports") {
+		d.AcceptRunWhitespace()
+
+		e := d.NewGoal()
+		i.Supports = new(SupportsCondition)
+
+		if isDeclaration(e) {
+			var sip SupportsInParens
+
+			sip.Declaration = new(Declaration)
+
+			if err := sip.Declaration.parse(&e); err != nil {
+				return d.Error("ImportRule", err)
+			}
+
+			sip.Tokens = e.ToTokens()
+			i.Supports.InParens = []SupportsInParens{sip}
+			i.Supports.Tokens = sip.Tokens
+		} else if err := i.Supports.parse(&e); err != nil {
+			return d.Error("ImportRule", err)
+		}
+
+		d.Score(e)
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenCloseParen) {
+			return d.Error("ImportRule", ErrMissingCloseParen)
+		}
+
+		c.Score(d)
+	}
+
+	d = c.NewGoal()
+
+	if d.AcceptRunWhitespace() != parser.TokenDone {
+		c.Score(d)
+
+		d = c.NewGoal()
+		i.Media = new(MediaQueryList)
+
+		if err := i.Media.parse(&d); err != nil {
+			return c.Error("ImportRule", err)
+		}
+
+		c.Score(d)
+	}
+
+	i.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func acceptLayerName(c *cssParser) (string, error) {
+	var name strings.Builder
+
+	for {
+		if !c.Accept(TokenIdent) {
+			return "", c.Error("LayerName", ErrInvalidLayerName)
+		}
+
+		name.WriteString(c.GetLastToken().Data)
+
+		if !c.AcceptToken(parser.Token{Type: TokenDelim, Data: "."}) {
+			return name.String(), nil
+		}
+
+		name.WriteString(".")
+	}
+}
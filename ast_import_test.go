@@ -0,0 +1,134 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestImport(t *testing.T) {
+	for n, test := range [...]struct {
+		Input           string
+		URL, LayerName  string
+		Layer           bool
+		Supports, Media string
+		Err             error
+	}{
+		{ // 1
+			Input: `@import "a.css";`,
+			URL:   "a.css",
+		},
+		{ // 2
+			Input: `@import url(a.css);`,
+			URL:   "a.css",
+		},
+		{ // 3
+			Input:     `@import url("a.css") layer(base) supports(display:grid) screen and (min-width: 40em);`,
+			URL:       "a.css",
+			Layer:     true,
+			LayerName: "base",
+			Supports:  "display:grid",
+			Media:     "screen and (min-width: 40em)",
+		},
+		{ // 4
+			Input: `@import 'a\62 .css' layer;`,
+			URL:   "ab.css",
+			Layer: true,
+		},
+		{ // 5
+			Input:     `@import "a.css" layer(framework.base) supports((display: grid) and (not (display: inline-grid)));`,
+			URL:       "a.css",
+			Layer:     true,
+			LayerName: "framework.base",
+			Supports:  "(display: grid) and (not (display: inline-grid))",
+		},
+		{ // 6
+			Input: `@import "a.css" print, screen;`,
+			URL:   "a.css",
+			Media: "print, screen",
+		},
+		{ // 7
+			Input: `@charset "utf-8"; @layer a, b; @import "a.css";`,
+			URL:   "a.css",
+		},
+		{ // 8
+			Input: `@import;`,
+			Err:   ErrMissingURL,
+		},
+		{ // 9
+			Input: `@import "a.css" layer();`,
+			Err:   ErrInvalidLayerName,
+		},
+		{ // 10
+			Input: `a {} @import "a.css";`,
+			Err:   ErrInvalidImportPosition,
+		},
+		{ // 11
+			Input: `@layer a {} @import "a.css";`,
+			Err:   ErrInvalidImportPosition,
+		},
+		{ // 12
+			Input: `@import "a.css" {}`,
+			Err:   ErrUnexpectedBlock,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		var i *ImportRule
+
+		for _, r := range s.Rules {
+			if r.AtRule != nil && r.AtRule.Import != nil {
+				i = r.AtRule.Import
+			}
+		}
+
+		if i == nil {
+			t.Errorf("test %d: expecting import rule, got none", n+1)
+		} else if i.URL != test.URL {
+			t.Errorf("test %d: expecting URL %q, got %q", n+1, test.URL, i.URL)
+		} else if i.Layer != test.Layer {
+			t.Errorf("test %d: expecting layer %v, got %v", n+1, test.Layer, i.Layer)
+		} else if i.LayerName != test.LayerName {
+			t.Errorf("test %d: expecting layer name %q, got %q", n+1, test.LayerName, i.LayerName)
+		} else if i.Supports == nil && test.Supports != "" || i.Supports != nil && i.Supports.Tokens.String() != test.Supports {
+			t.Errorf("test %d: expecting supports %q, got %v", n+1, test.Supports, i.Supports)
+		} else if i.Media == nil && test.Media != "" || i.Media != nil && i.Media.Tokens.String() != test.Media {
+			t.Errorf("test %d: expecting media %q, got %v", n+1, test.Media, i.Media)
+		}
+	}
+}
+
+func TestMisplacedImport(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(`a{} @import "x"; b{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if len(s.Rules) != 3 {
+		t.Fatalf("expecting 3 rules, got %d", len(s.Rules))
+	}
+
+	a := s.Rules[1].AtRule
+
+	if a.Import != nil {
+		t.Errorf("expecting misplaced import to be ignored")
+	} else if !errors.Is(a.Err, ErrInvalidImportPosition) {
+		t.Errorf("expecting error %v, got %v", ErrInvalidImportPosition, a.Err)
+	} else if s.Rules[2].QualifiedRule == nil {
+		t.Errorf("expecting style rule after misplaced import")
+	}
+}
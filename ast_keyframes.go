@@ -0,0 +1,139 @@
+package css
+
+import (
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// Keyframes represents an @keyframes rule.
+//
+// Invalid keyframe blocks are dropped, as they would be by a browser, with the
+// reason for each recorded in Errors. An @keyframes rule with an invalid name
+// is ignored entirely, with AtRule.Keyframes left nil and the reason recorded
+// in AtRule.Err.
+type Keyframes struct {
+	Name      string
+	Keyframes []Keyframe
+	Errors    []error
+}
+
+func (k *Keyframes) parse(c *cssParser) error {
+	if c.Accept(TokenString) {
+		name, err := Unquote(c.GetLastToken().Data)
+		if err != nil {
+			return c.Error("Keyframes", err)
+		}
+
+		k.Name = name
+	} else if c.Accept(TokenIdent) {
+		k.Name = c.GetLastToken().Data
+
+		switch strings.ToLower(k.Name) {
+		case "none", "initial", "inherit", "unset", "revert", "revert-layer", "default":
+			return c.Error("Keyframes", ErrInvalidKeyframesName)
+		}
+	} else {
+		return c.Error("Keyframes", ErrInvalidKeyframesName)
+	}
+
+	return nil
+}
+
+func (k *Keyframes) parseBlock(b *Block) {
+	for _, bi := range b.Items {
+		if bi.Rule == nil || bi.Rule.QualifiedRule == nil {
+			k.Errors = append(k.Errors, ErrInvalidKeyframe)
+
+			continue
+		}
+
+		var kf Keyframe
+
+		if err := kf.parse(bi.Rule.QualifiedRule); err != nil {
+			k.Errors = append(k.Errors, err)
+		} else {
+			k.Keyframes = append(k.Keyframes, kf)
+		}
+	}
+}
+
+// Find returns the Keyframes whose selectors contain the given offset, in
+// percent.
+func (k *Keyframes) Find(offset float64) []*Keyframe {
+	var kfs []*Keyframe
+
+	for n := range k.Keyframes {
+		if kf := &k.Keyframes[n]; kf.Has(offset) {
+			kfs = append(kfs, kf)
+		}
+	}
+
+	return kfs
+}
+
+// Keyframe represents a single keyframe block in an @keyframes rule.
+//
+// The Selectors are normalised to percentages, so 'from' is represented as 0
+// and 'to' as 100.
+type Keyframe struct {
+	Selectors    []float64
+	Declarations []*Declaration
+	Rule         *QualifiedRule
+}
+
+func (k *Keyframe) parse(q *QualifiedRule) error {
+	c := newCSSParserFromTokens(q.Prelude)
+
+	c.AcceptRunWhitespace()
+
+	for {
+		if c.AcceptIdent("from") {
+			k.Selectors = append(k.Selectors, 0)
+		} else if c.AcceptIdent("to") {
+			k.Selectors = append(k.Selectors, 100)
+		} else if c.Accept(TokenPercentage) {
+			data := c.GetLastToken().Data
+
+			v, err := strconv.ParseFloat(data[:len(data)-1], 64)
+			if err != nil || v < 0 || v > 100 {
+				return c.Error("Keyframe", ErrInvalidKeyframeSelector)
+			}
+
+			k.Selectors = append(k.Selectors, v)
+		} else {
+			return c.Error("Keyframe", ErrInvalidKeyframeSelector)
+		}
+
+		c.AcceptRunWhitespace()
+
+		if c.Peek().Type == parser.TokenDone {
+			break
+		} else if !c.Accept(TokenComma) {
+			return c.Error("Keyframe", ErrInvalidKeyframeSelector)
+		}
+
+		c.AcceptRunWhitespace()
+	}
+
+	if len(q.Block.Rules()) > 0 {
+		return ErrInvalidKeyframe
+	}
+
+	k.Declarations = q.Block.Declarations()
+	k.Rule = q
+
+	return nil
+}
+
+// Has returns true if the Keyframe applies at the given offset, in percent.
+func (k *Keyframe) Has(offset float64) bool {
+	for _, s := range k.Selectors {
+		if s == offset {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestKeyframes(t *testing.T) {
+	for n, test := range [...]struct {
+		Input     string
+		Name      string
+		Selectors [][]float64
+		Errors    []error
+		Err       error
+	}{
+		{ // 1
+			Input:     "@keyframes spin { from { transform: rotate(0) } 50% { opacity: 0.5 } to { transform: rotate(1turn) } }",
+			Name:      "spin",
+			Selectors: [][]float64{{0}, {50}, {100}},
+		},
+		{ // 2
+			Input:     "@keyframes \"a b\" { FROM, TO { opacity: 0 } 12.5%,75% { opacity: 1 } }",
+			Name:      "a b",
+			Selectors: [][]float64{{0, 100}, {12.5, 75}},
+		},
+		{ // 3
+			Input:     "@-webkit-keyframes fade {}",
+			Name:      "fade",
+			Selectors: nil,
+		},
+		{ // 4
+			Input: "@keyframes none {}",
+			Err:   ErrInvalidKeyframesName,
+		},
+		{ // 5
+			Input:  "@keyframes a { 110% {} }",
+			Name:   "a",
+			Errors: []error{ErrInvalidKeyframeSelector},
+		},
+		{ // 6
+			Input:     "@keyframes a { middle {} to {} }",
+			Name:      "a",
+			Selectors: [][]float64{{100}},
+			Errors:    []error{ErrInvalidKeyframeSelector},
+		},
+		{ // 7
+			Input:     "@keyframes a { from to {} 150% { opacity: 0 } 50% { opacity: 1 } }",
+			Name:      "a",
+			Selectors: [][]float64{{50}},
+			Errors:    []error{ErrInvalidKeyframeSelector, ErrInvalidKeyframeSelector},
+		},
+		{ // 8
+			Input:     "@keyframes a { from { a { color: red } } to {} }",
+			Name:      "a",
+			Selectors: [][]float64{{100}},
+			Errors:    []error{ErrInvalidKeyframe},
+		},
+		{ // 9
+			Input: "@keyframes a;",
+			Err:   ErrMissingBlock,
+		},
+		{ // 10
+			Input: "@keyframes 5 { from { opacity: 0 } }",
+			Err:   ErrInvalidKeyframesName,
+		},
+		{ // 11
+			Input: "@keyframes INITIAL {}",
+			Err:   ErrInvalidKeyframesName,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input + " a {}"))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		} else if len(s.Rules) != 2 {
+			t.Errorf("test %d: expecting 2 rules, got %d", n+1, len(s.Rules))
+
+			continue
+		}
+
+		a := s.Rules[0].AtRule
+
+		if test.Err != nil {
+			if a.Keyframes != nil {
+				t.Errorf("test %d: expecting no Keyframes", n+1)
+			} else if !errors.Is(a.Err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, a.Err)
+			}
+
+			continue
+		} else if a.Err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, a.Err)
+
+			continue
+		}
+
+		k := a.Keyframes
+
+		var selectors [][]float64
+
+		for _, kf := range k.Keyframes {
+			selectors = append(selectors, kf.Selectors)
+		}
+
+		if k.Name != test.Name {
+			t.Errorf("test %d: expecting name %q, got %q", n+1, test.Name, k.Name)
+		} else if !reflect.DeepEqual(selectors, test.Selectors) {
+			t.Errorf("test %d: expecting selectors %v, got %v", n+1, test.Selectors, selectors)
+		} else if len(k.Errors) != len(test.Errors) {
+			t.Errorf("test %d: expecting %d errors, got %d", n+1, len(test.Errors), len(k.Errors))
+		} else {
+			for m, err := range test.Errors {
+				if !errors.Is(k.Errors[m], err) {
+					t.Errorf("test %d: expecting error %d to be %v, got %v", n+1, m+1, err, k.Errors[m])
+				}
+			}
+		}
+	}
+}
+
+func TestKeyframesFind(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser("@keyframes a { from, to { opacity: 0 } 50% { opacity: 1 } 0% { color: red } }"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	k := s.Rules[0].AtRule.Keyframes
+
+	if kfs := k.Find(0); len(kfs) != 2 {
+		t.Errorf("expecting 2 keyframes at 0%%, got %d", len(kfs))
+	} else if kfs := k.Find(50); len(kfs) != 1 || kfs[0].Declarations[0].Value.String() != "1" {
+		t.Errorf("expecting 1 keyframe at 50%%, got %d", len(kfs))
+	} else if kfs := k.Find(25); len(kfs) != 0 {
+		t.Errorf("expecting 0 keyframes at 25%%, got %d", len(kfs))
+	}
+}
@@ -0,0 +1,155 @@
+package css
+
+import (
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// LayerRule represents the prelude of an @layer rule.
+//
+// An @layer statement declares one or more Names; an @layer block has at most
+// one Name, with no Names denoting an anonymous layer.
+type LayerRule struct {
+	Names  []string
+	Tokens Tokens
+}
+
+func (l *LayerRule) parse(c *cssParser) error {
+	if c.Peek().Type == parser.TokenDone {
+		return nil
+	}
+
+	for {
+		name, err := acceptLayerName(c)
+		if err != nil {
+			return c.Error("LayerRule", err)
+		}
+
+		l.Names = append(l.Names, name)
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenComma) {
+			break
+		}
+
+		c.AcceptRunWhitespace()
+	}
+
+	l.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// LayerOrder returns the full, dot separated names of all of the cascade
+// layers declared in the Sheet, in order of increasing precedence.
+//
+// Layers are ordered by their first declaration, with each layer ordered after
+// all of its sub-layers. Anonymous layers are given names of the form
+// '<anonymous-N>', which cannot clash with a declared layer name.
+func (s *Sheet) LayerOrder() []string {
+	var l layerOrder
+
+	for n := range s.Rules {
+		l.walk(&s.Rules[n], nil)
+	}
+
+	return l.root.flatten(nil)
+}
+
+type layerTree struct {
+	order    []string
+	children map[string]*layerTree
+}
+
+func (l *layerTree) declare(names []string) {
+	for _, name := range names {
+		if l.children == nil {
+			l.children = make(map[string]*layerTree)
+		}
+
+		child, ok := l.children[name]
+		if !ok {
+			child = new(layerTree)
+			l.children[name] = child
+			l.order = append(l.order, name)
+		}
+
+		l = child
+	}
+}
+
+func (l *layerTree) flatten(prefix []string) []string {
+	var names []string
+
+	for _, name := range l.order {
+		full := append(prefix[:len(prefix):len(prefix)], name)
+		names = append(names, l.children[name].flatten(full)...)
+		names = append(names, strings.Join(full, "."))
+	}
+
+	return names
+}
+
+type layerOrder struct {
+	root      layerTree
+	anonymous int
+}
+
+func (l *layerOrder) anonymousName() string {
+	l.anonymous++
+
+	return "<anonymous-" + strconv.Itoa(l.anonymous) + ">"
+}
+
+func (l *layerOrder) layerPath(prefix []string, name string) []string {
+	path := prefix[:len(prefix):len(prefix)]
+
+	if name == "" {
+		return append(path, l.anonymousName())
+	}
+
+	return append(path, strings.Split(name, ".")...)
+}
+
+func (l *layerOrder) walk(r *Rule, prefix []string) {
+	if r.AtRule != nil {
+		l.walkAtRule(r.AtRule, prefix)
+	} else if r.QualifiedRule != nil {
+		l.walkBlock(&r.QualifiedRule.Block, prefix)
+	}
+}
+
+func (l *layerOrder) walkAtRule(a *AtRule, prefix []string) {
+	switch {
+	case a.Layer != nil && a.Block == nil:
+		for _, name := range a.Layer.Names {
+			l.root.declare(l.layerPath(prefix, name))
+		}
+	case a.Layer != nil:
+		var name string
+
+		if len(a.Layer.Names) > 0 {
+			name = a.Layer.Names[0]
+		}
+
+		path := l.layerPath(prefix, name)
+
+		l.root.declare(path)
+		l.walkBlock(a.Block, path)
+	case a.Import != nil:
+		if a.Import.Layer {
+			l.root.declare(l.layerPath(prefix, a.Import.LayerName))
+		}
+	case a.Block != nil:
+		l.walkBlock(a.Block, prefix)
+	}
+}
+
+func (l *layerOrder) walkBlock(b *Block, prefix []string) {
+	for _, r := range b.Rules() {
+		l.walk(r, prefix)
+	}
+}
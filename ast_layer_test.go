@@ -0,0 +1,69 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestLayerOrder(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output []string
+		Err    error
+	}{
+		{ // 1
+			Input:  "a {}",
+			Output: nil,
+		},
+		{ // 2
+			Input:  "@layer reset, base;\n@layer base { a {} }\n@layer reset {}",
+			Output: []string{"reset", "base"},
+		},
+		{ // 3
+			Input:  "@import 'a.css' layer(framework.base);\n@layer framework { @layer theme { a {} } }\n@layer utilities;",
+			Output: []string{"framework.base", "framework.theme", "framework", "utilities"},
+		},
+		{ // 4
+			Input:  "@layer { a {} }\n@layer { b {} }\n@layer a",
+			Output: []string{"<anonymous-1>", "<anonymous-2>", "a"},
+		},
+		{ // 5
+			Input:  "@media screen { @layer a.b, c; }\n@supports (display: grid) { @layer a { @layer d; } }\n@layer a.b.e;",
+			Output: []string{"a.b.e", "a.b", "a.d", "a", "c"},
+		},
+		{ // 6
+			Input:  "@import 'a.css' layer;\n@layer x;",
+			Output: []string{"<anonymous-1>", "x"},
+		},
+		{ // 7
+			Input: "@layer;",
+			Err:   ErrInvalidLayerName,
+		},
+		{ // 8
+			Input: "@layer a, b {}",
+			Err:   ErrTooManyLayerNames,
+		},
+		{ // 9
+			Input: "@layer a.;",
+			Err:   ErrInvalidLayerName,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if out := s.LayerOrder(); !reflect.DeepEqual(out, test.Output) {
+			t.Errorf("test %d: expecting layer order %v, got %v", n+1, test.Output, out)
+		}
+	}
+}
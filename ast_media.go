@@ -0,0 +1,469 @@
+package css
+
+import (
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// MediaQueryList represents a comma separated list of media queries.
+type MediaQueryList struct {
+	Queries []MediaQuery
+	Tokens  Tokens
+}
+
+func (m *MediaQueryList) parse(c *cssParser) error {
+	for {
+		d := c.NewGoal()
+
+		var mq MediaQuery
+
+		if err := mq.parse(&d); err != nil {
+			return c.Error("MediaQueryList", err)
+		}
+
+		m.Queries = append(m.Queries, mq)
+
+		c.Score(d)
+
+		d = c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenComma) {
+			break
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+
+	m.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// MediaQuery represents a single media query.
+//
+// A MediaQuery will either have a Type, optionally combined with a Condition,
+// or only a Condition.
+type MediaQuery struct {
+	Not       bool
+	Only      bool
+	Type      *Token
+	Condition *MediaCondition
+	Tokens    Tokens
+}
+
+func (m *MediaQuery) parse(c *cssParser) error {
+	d := c.NewGoal()
+
+	if d.AcceptIdent("not") {
+		m.Not = true
+	} else if d.AcceptIdent("only") {
+		m.Only = true
+	}
+
+	if m.Not || m.Only {
+		d.AcceptRunWhitespace()
+	}
+
+	if tk := d.Peek(); tk.Type == TokenIdent && !isReservedMediaType(tk.Data) {
+		c.Score(d)
+		c.Skip()
+
+		m.Type = c.GetLastToken()
+		d = c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if d.AcceptIdent("and") {
+			d.AcceptRunWhitespace()
+
+			e := d.NewGoal()
+			m.Condition = new(MediaCondition)
+
+			if err := m.Condition.parse(&e); err != nil {
+				return d.Error("MediaQuery", err)
+			} else if m.Condition.Or {
+				return d.Error("MediaQuery", ErrInvalidMediaQuery)
+			}
+
+			d.Score(e)
+			c.Score(d)
+		}
+	} else if m.Only {
+		return d.Error("MediaQuery", ErrMissingMediaType)
+	} else {
+		m.Not = false
+		d = c.NewGoal()
+		m.Condition = new(MediaCondition)
+
+		if err := m.Condition.parse(&d); err != nil {
+			return c.Error("MediaQuery", err)
+		}
+
+		c.Score(d)
+	}
+
+	m.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func isReservedMediaType(name string) bool {
+	switch strings.ToLower(name) {
+	case "not", "and", "or", "only", "layer":
+		return true
+	}
+
+	return false
+}
+
+// MediaCondition represents a condition in a media query.
+//
+// When Not is set, the condition is the negation of that MediaInParens;
+// otherwise the condition is the combination of the InParens values, joined by
+// 'or' when Or is true and by 'and' otherwise.
+type MediaCondition struct {
+	Not      *MediaInParens
+	InParens []MediaInParens
+	Or       bool
+	Tokens   Tokens
+}
+
+func (m *MediaCondition) parse(c *cssParser) error {
+	if c.AcceptIdent("not") {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		m.Not = new(MediaInParens)
+
+		if err := m.Not.parse(&d); err != nil {
+			return c.Error("MediaCondition", err)
+		}
+
+		c.Score(d)
+	} else {
+		var operator string
+
+		for {
+			d := c.NewGoal()
+
+			var mip MediaInParens
+
+			if err := mip.parse(&d); err != nil {
+				return c.Error("MediaCondition", err)
+			}
+
+			m.InParens = append(m.InParens, mip)
+
+			c.Score(d)
+
+			d = c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if operator == "" {
+				if d.AcceptIdent("and") {
+					operator = "and"
+				} else if d.AcceptIdent("or") {
+					operator = "or"
+					m.Or = true
+				} else {
+					break
+				}
+			} else if !d.AcceptIdent(operator) {
+				if d.AcceptIdent("and") || d.AcceptIdent("or") {
+					return d.Error("MediaCondition", ErrMixedOperators)
+				}
+
+				break
+			}
+
+			d.AcceptRunWhitespace()
+			c.Score(d)
+		}
+	}
+
+	m.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// MediaInParens represents a single test within a MediaCondition.
+//
+// Only one of Condition, Feature, or GeneralEnclosed will be set.
+type MediaInParens struct {
+	Condition       *MediaCondition
+	Feature         *MediaFeature
+	GeneralEnclosed Tokens
+	Tokens          Tokens
+}
+
+func (m *MediaInParens) parse(c *cssParser) error {
+	if tk := c.Peek(); tk.Type == TokenFunction {
+		c.AcceptComponentValue()
+
+		m.GeneralEnclosed = c.ToTokens()
+	} else if tk.Type != TokenOpenParen {
+		return c.Error("MediaInParens", ErrInvalidMediaQuery)
+	} else {
+		d := c.NewGoal()
+		m.Feature = new(MediaFeature)
+
+		if err := m.Feature.parse(&d); err == nil {
+			c.Score(d)
+		} else {
+			m.Feature = nil
+			d = c.NewGoal()
+
+			d.Skip()
+			d.AcceptRunWhitespace()
+
+			e := d.NewGoal()
+			m.Condition = new(MediaCondition)
+
+			if err := m.Condition.parse(&e); err == nil {
+				d.Score(e)
+				d.AcceptRunWhitespace()
+			}
+
+			if d.Accept(TokenCloseParen) {
+				c.Score(d)
+			} else {
+				m.Condition = nil
+
+				c.AcceptComponentValue()
+
+				m.GeneralEnclosed = c.ToTokens()
+			}
+		}
+	}
+
+	m.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// Comparison represents a comparison operator in a range context.
+type Comparison uint8
+
+// Comparison operators.
+const (
+	ComparisonEqual Comparison = iota
+	ComparisonLessThan
+	ComparisonLessThanOrEqual
+	ComparisonGreaterThan
+	ComparisonGreaterThanOrEqual
+)
+
+// Flip returns the equivalent Comparison for when the operands are swapped.
+func (c Comparison) Flip() Comparison {
+	switch c {
+	case ComparisonLessThan:
+		return ComparisonGreaterThan
+	case ComparisonLessThanOrEqual:
+		return ComparisonGreaterThanOrEqual
+	case ComparisonGreaterThan:
+		return ComparisonLessThan
+	case ComparisonGreaterThanOrEqual:
+		return ComparisonLessThanOrEqual
+	}
+
+	return c
+}
+
+// Compare applies the Comparison to the given values.
+func (c Comparison) Compare(a, b float64) bool {
+	switch c {
+	case ComparisonLessThan:
+		return a < b
+	case ComparisonLessThanOrEqual:
+		return a <= b
+	case ComparisonGreaterThan:
+		return a > b
+	case ComparisonGreaterThanOrEqual:
+		return a >= b
+	}
+
+	return a == b
+}
+
+func (c *cssParser) acceptComparison() (Comparison, bool) {
+	var cmp Comparison
+
+	if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "="}) {
+		return ComparisonEqual, true
+	} else if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "<"}) {
+		cmp = ComparisonLessThan
+	} else if c.AcceptToken(parser.Token{Type: TokenDelim, Data: ">"}) {
+		cmp = ComparisonGreaterThan
+	} else {
+		return 0, false
+	}
+
+	if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "="}) {
+		cmp++
+	}
+
+	return cmp, true
+}
+
+// MediaFeature represents a feature test, such as '(color)',
+// '(min-width: 40em)', or '(400px <= width < 700px)'.
+//
+// A boolean test has only a Name; a plain test has a Name and Value; and a range
+// test has a Name and one or two Ranges, normalised such that the feature is
+// always the left operand of each Comparison.
+type MediaFeature struct {
+	Name   *Token
+	Value  Tokens
+	Ranges []MediaRange
+	Tokens Tokens
+}
+
+// MediaRange represents a single comparison in a range test.
+type MediaRange struct {
+	Comparison Comparison
+	Value      Tokens
+}
+
+func (m *MediaFeature) parse(c *cssParser) error {
+	if !c.Accept(TokenOpenParen) {
+		return c.Error("MediaFeature", ErrInvalidMediaFeature)
+	}
+
+	return m.parseContents(c)
+}
+
+func (m *MediaFeature) parseContents(c *cssParser) error {
+	c.AcceptRunWhitespace()
+
+	d := c.NewGoal()
+
+	if d.Accept(TokenIdent) {
+		name := d.GetLastToken()
+
+		d.AcceptRunWhitespace()
+
+		if d.Accept(TokenCloseParen) {
+			m.Name = name
+
+			c.Score(d)
+
+			m.Tokens = c.ToTokens()
+
+			return nil
+		} else if d.Accept(TokenColon) {
+			m.Name = name
+
+			d.AcceptRunWhitespace()
+
+			m.Value = acceptRangeValue(&d)
+
+			if len(m.Value) == 0 || !d.Accept(TokenCloseParen) {
+				return d.Error("MediaFeature", ErrInvalidMediaFeature)
+			}
+
+			c.Score(d)
+
+			m.Tokens = c.ToTokens()
+
+			return nil
+		}
+	}
+
+	var (
+		values      [3]Tokens
+		comparisons [2]Comparison
+		parts       int
+	)
+
+	for {
+		values[parts] = acceptRangeValue(c)
+
+		if len(values[parts]) == 0 {
+			return c.Error("MediaFeature", ErrInvalidMediaFeature)
+		}
+
+		parts++
+
+		if c.Accept(TokenCloseParen) {
+			break
+		} else if parts == 3 {
+			return c.Error("MediaFeature", ErrInvalidMediaFeature)
+		}
+
+		cmp, ok := c.acceptComparison()
+		if !ok {
+			return c.Error("MediaFeature", ErrInvalidMediaFeature)
+		}
+
+		comparisons[parts-1] = cmp
+
+		c.AcceptRunWhitespace()
+	}
+
+	switch parts {
+	case 2:
+		if isFeatureName(values[0]) {
+			m.Name = &values[0][0]
+			m.Ranges = []MediaRange{{Comparison: comparisons[0], Value: values[1]}}
+		} else if isFeatureName(values[1]) {
+			m.Name = &values[1][0]
+			m.Ranges = []MediaRange{{Comparison: comparisons[0].Flip(), Value: values[0]}}
+		} else {
+			return c.Error("MediaFeature", ErrInvalidMediaFeature)
+		}
+	case 3:
+		if !isFeatureName(values[1]) || comparisons[0] == ComparisonEqual || comparisons[1] == ComparisonEqual || (comparisons[0] < ComparisonGreaterThan) != (comparisons[1] < ComparisonGreaterThan) {
+			return c.Error("MediaFeature", ErrInvalidMediaFeature)
+		}
+
+		m.Name = &values[1][0]
+		m.Ranges = []MediaRange{
+			{Comparison: comparisons[0].Flip(), Value: values[0]},
+			{Comparison: comparisons[1], Value: values[2]},
+		}
+	default:
+		return c.Error("MediaFeature", ErrInvalidMediaFeature)
+	}
+
+	m.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func isFeatureName(ts Tokens) bool {
+	return len(ts) == 1 && ts[0].Type == TokenIdent
+}
+
+func acceptRangeValue(c *cssParser) Tokens {
+	d := c.NewGoal()
+
+Loop:
+	for {
+		switch tk := d.Peek(); tk.Type {
+		case TokenCloseParen, parser.TokenDone:
+			break Loop
+		case TokenDelim:
+			if tk.Data == "<" || tk.Data == ">" || tk.Data == "=" {
+				break Loop
+			}
+		}
+
+		d.AcceptComponentValue()
+	}
+
+	value := d.ToTokens().Trim()
+
+	c.Score(d)
+
+	return value
+}
+
+// FeatureName returns the lowercased name of the feature.
+func (m *MediaFeature) FeatureName() string {
+	return strings.ToLower(m.Name.Data)
+}
@@ -0,0 +1,132 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestMediaQueryList(t *testing.T) {
+	for n, test := range [...]struct {
+		Input   string
+		Queries int
+		Check   func(*MediaQueryList) bool
+		Err     error
+	}{
+		{ // 1
+			Input:   "screen",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				return m.Queries[0].Type.Data == "screen" && m.Queries[0].Condition == nil
+			},
+		},
+		{ // 2
+			Input:   "not print and (color), only screen",
+			Queries: 2,
+			Check: func(m *MediaQueryList) bool {
+				return m.Queries[0].Not && m.Queries[0].Condition.InParens[0].Feature.FeatureName() == "color" && m.Queries[1].Only
+			},
+		},
+		{ // 3
+			Input:   "(min-width: 40em) and (orientation: landscape)",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				c := m.Queries[0].Condition
+
+				return m.Queries[0].Type == nil && len(c.InParens) == 2 && c.InParens[0].Feature.Value.String() == "40em" && !c.Or
+			},
+		},
+		{ // 4
+			Input:   "(400px <= width < 700px)",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				f := m.Queries[0].Condition.InParens[0].Feature
+
+				return f.FeatureName() == "width" && len(f.Ranges) == 2 && f.Ranges[0].Comparison == ComparisonGreaterThanOrEqual && f.Ranges[0].Value.String() == "400px" && f.Ranges[1].Comparison == ComparisonLessThan && f.Ranges[1].Value.String() == "700px"
+			},
+		},
+		{ // 5
+			Input:   "(16/9 < aspect-ratio)",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				f := m.Queries[0].Condition.InParens[0].Feature
+
+				return f.FeatureName() == "aspect-ratio" && f.Ranges[0].Comparison == ComparisonGreaterThan && f.Ranges[0].Value.String() == "16/9"
+			},
+		},
+		{ // 6
+			Input:   "not ((hover) or (pointer: fine))",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				c := m.Queries[0].Condition
+
+				return c.Not != nil && c.Not.Condition != nil && c.Not.Condition.Or
+			},
+		},
+		{ // 7
+			Input:   "(unknown stuff)",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				return m.Queries[0].Condition.InParens[0].GeneralEnclosed.String() == "(unknown stuff)"
+			},
+		},
+		{ // 8
+			Input: "screen and (color) or (hover)",
+			Err:   ErrInvalidMediaQuery,
+		},
+		{ // 9
+			Input: "(color) and (hover) or (pointer)",
+			Err:   ErrMixedOperators,
+		},
+		{ // 10
+			Input: "only (color)",
+			Err:   ErrMissingMediaType,
+		},
+		{ // 11
+			Input:   "(400px < width = 700px)",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				p := m.Queries[0].Condition.InParens[0]
+
+				return p.Feature == nil && p.GeneralEnclosed != nil
+			},
+		},
+		{ // 12
+			Input:   "(700px >= width > 400px)",
+			Queries: 1,
+			Check: func(m *MediaQueryList) bool {
+				f := m.Queries[0].Condition.InParens[0].Feature
+
+				return f.FeatureName() == "width" && f.Ranges[0].Comparison == ComparisonLessThanOrEqual && f.Ranges[1].Comparison == ComparisonGreaterThan
+			},
+		},
+	} {
+		c := newCSSParserFromTokens(tokenise(t, test.Input))
+
+		var m MediaQueryList
+
+		if err := parseTrimmed(&c, m.parse); test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if len(m.Queries) != test.Queries {
+			t.Errorf("test %d: expecting %d queries, got %d", n+1, test.Queries, len(m.Queries))
+		} else if !test.Check(&m) {
+			t.Errorf("test %d: check failed", n+1)
+		}
+	}
+}
+
+func tokenise(t *testing.T, input string) Tokens {
+	t.Helper()
+
+	c, err := newCSSParser(CreateTokeniser(parser.NewStringTokeniser(input), true))
+	if err != nil {
+		t.Fatalf("unexpected error tokenising %q: %s", input, err)
+	}
+
+	return Tokens(c[:cap(c)-1])
+}
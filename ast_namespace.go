@@ -0,0 +1,34 @@
+package css
+
+// NamespaceRule represents the prelude of an @namespace rule.
+//
+// The Prefix is empty when declaring the default namespace.
+type NamespaceRule struct {
+	Prefix string
+	URI    string
+	Tokens Tokens
+}
+
+func (n *NamespaceRule) parse(c *cssParser) error {
+	if c.Accept(TokenIdent) {
+		n.Prefix = c.GetLastToken().Data
+
+		c.AcceptRunWhitespace()
+	}
+
+	var err error
+
+	if c.Accept(TokenString) {
+		n.URI, err = Unquote(c.GetLastToken().Data)
+	} else {
+		n.URI, err = acceptURL(c)
+	}
+
+	if err != nil {
+		return c.Error("NamespaceRule", err)
+	}
+
+	n.Tokens = c.ToTokens()
+
+	return nil
+}
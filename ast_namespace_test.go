@@ -0,0 +1,103 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestNamespace(t *testing.T) {
+	for n, test := range [...]struct {
+		Input      string
+		Namespaces map[string]string
+		Selectors  string
+		Err        error
+	}{
+		{ // 1
+			Input:      "@namespace \"http://www.w3.org/1999/xhtml\";",
+			Namespaces: map[string]string{"": "http://www.w3.org/1999/xhtml"},
+		},
+		{ // 2
+			Input:      "@namespace svg url(http://www.w3.org/2000/svg); svg|rect, *|*, |foo {}",
+			Namespaces: map[string]string{"svg": "http://www.w3.org/2000/svg"},
+			Selectors:  "svg|rect, *|*, |foo",
+		},
+		{ // 3
+			Input:      "@namespace xlink url(\"http://www.w3.org/1999/xlink\"); a[xlink|href], [*|title] {}",
+			Namespaces: map[string]string{"xlink": "http://www.w3.org/1999/xlink"},
+			Selectors:  "a[xlink|href], [*|title]",
+		},
+		{ // 4
+			Input: "svg|rect {}",
+			Err:   ErrUndeclaredNamespace,
+		},
+		{ // 5
+			Input: "@namespace svg url(http://www.w3.org/2000/svg); a[xlink|href] {}",
+			Err:   ErrUndeclaredNamespace,
+		},
+		{ // 6
+			Input: "@media screen { :not(svg|a) {} }",
+			Err:   ErrUndeclaredNamespace,
+		},
+		{ // 7
+			Input: "@namespace svg;",
+			Err:   ErrMissingURL,
+		},
+		{ // 8
+			Input: "@namespace svg \"http://www.w3.org/2000/svg\" {}",
+			Err:   ErrUnexpectedBlock,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(s.Namespaces, test.Namespaces) {
+			t.Errorf("test %d: expecting namespaces %v, got %v", n+1, test.Namespaces, s.Namespaces)
+		} else if test.Selectors != "" {
+			if out := describeSelectors(s.Rules[len(s.Rules)-1].QualifiedRule.Selectors); out != test.Selectors {
+				t.Errorf("test %d: expecting selectors %q, got %q", n+1, test.Selectors, out)
+			}
+		}
+	}
+}
+
+func TestNamespaceURI(t *testing.T) {
+	ns := map[string]string{"": "html", "svg": "svg-ns"}
+
+	for n, test := range [...]struct {
+		Input string
+		URI   string
+		Any   bool
+	}{
+		{"a {}", "html", false},
+		{"svg|a {}", "svg-ns", false},
+		{"|a {}", "", false},
+		{"*|a {}", "", true},
+	} {
+		c := newCSSParserFromTokens(tokenise(t, test.Input[:len(test.Input)-3]))
+
+		var w WQName
+
+		if err := w.parse(&c, ns, true); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if uri, any := w.NamespaceURI(ns); uri != test.URI || any != test.Any {
+			t.Errorf("test %d: expecting %q, %v, got %q, %v", n+1, test.URI, test.Any, uri, any)
+		}
+	}
+}
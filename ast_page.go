@@ -0,0 +1,214 @@
+package css
+
+import (
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// PageRule represents an @page rule, with its page selectors, page
+// descriptors, and margin boxes.
+//
+// Nested rules that are not margin boxes are dropped, with the reason for each
+// recorded in Errors.
+type PageRule struct {
+	Selectors    []PageSelector
+	Declarations []*Declaration
+	MarginBoxes  []MarginBox
+	Errors       []error
+}
+
+func (p *PageRule) parse(c *cssParser) error {
+	if c.Peek().Type == parser.TokenDone {
+		return nil
+	}
+
+	for {
+		d := c.NewGoal()
+
+		var ps PageSelector
+
+		if err := ps.parse(&d); err != nil {
+			return c.Error("PageRule", err)
+		}
+
+		p.Selectors = append(p.Selectors, ps)
+
+		c.Score(d)
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenComma) {
+			return nil
+		}
+
+		c.AcceptRunWhitespace()
+	}
+}
+
+func (p *PageRule) parseBlock(b *Block) {
+	for _, bi := range b.Items {
+		if bi.Declaration != nil {
+			p.Declarations = append(p.Declarations, bi.Declaration)
+		} else if bi.Rule.AtRule != nil && isMarginBox(bi.Rule.AtRule.Name()) && bi.Rule.AtRule.Block != nil {
+			p.MarginBoxes = append(p.MarginBoxes, MarginBox{
+				Name:         bi.Rule.AtRule.Name(),
+				Declarations: bi.Rule.AtRule.Block.Declarations(),
+				AtRule:       bi.Rule.AtRule,
+			})
+		} else {
+			p.Errors = append(p.Errors, Error{
+				Err:     ErrInvalidMarginBox,
+				Parsing: "PageRule",
+				Token:   bi.Tokens[0],
+			})
+		}
+	}
+}
+
+func isMarginBox(name string) bool {
+	switch name {
+	case "top-left-corner", "top-left", "top-center", "top-right", "top-right-corner",
+		"bottom-left-corner", "bottom-left", "bottom-center", "bottom-right", "bottom-right-corner",
+		"left-top", "left-middle", "left-bottom",
+		"right-top", "right-middle", "right-bottom":
+		return true
+	}
+
+	return false
+}
+
+// PageSelector represents a single selector in the prelude of an @page rule,
+// such as 'chapter:first:right'.
+//
+// The Pseudo classes are lowercased.
+type PageSelector struct {
+	Name   string
+	Pseudo []string
+	Tokens Tokens
+}
+
+func (p *PageSelector) parse(c *cssParser) error {
+	if c.Accept(TokenIdent) {
+		p.Name = c.GetLastToken().Data
+	}
+
+	for c.Accept(TokenColon) {
+		if !c.Accept(TokenIdent) {
+			return c.Error("PageSelector", ErrInvalidPagePseudoClass)
+		}
+
+		pseudo := strings.ToLower(c.GetLastToken().Data)
+
+		switch pseudo {
+		case "left", "right", "first", "blank":
+		default:
+			return c.Error("PageSelector", ErrInvalidPagePseudoClass)
+		}
+
+		p.Pseudo = append(p.Pseudo, pseudo)
+	}
+
+	if p.Name == "" && len(p.Pseudo) == 0 {
+		return c.Error("PageSelector", ErrInvalidPageSelector)
+	}
+
+	p.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// MarginBox represents a margin at-rule, such as '@top-center', within an
+// @page rule.
+type MarginBox struct {
+	Name         string
+	Declarations []*Declaration
+	AtRule       *AtRule
+}
+
+var pageSizes = map[string][2]float64{
+	"a5":     {148 * 96 / 25.4, 210 * 96 / 25.4},
+	"a4":     {210 * 96 / 25.4, 297 * 96 / 25.4},
+	"a3":     {297 * 96 / 25.4, 420 * 96 / 25.4},
+	"b5":     {176 * 96 / 25.4, 250 * 96 / 25.4},
+	"b4":     {250 * 96 / 25.4, 353 * 96 / 25.4},
+	"jis-b5": {182 * 96 / 25.4, 257 * 96 / 25.4},
+	"jis-b4": {257 * 96 / 25.4, 364 * 96 / 25.4},
+	"letter": {8.5 * 96, 11 * 96},
+	"legal":  {8.5 * 96, 14 * 96},
+	"ledger": {11 * 96, 17 * 96},
+}
+
+// Size resolves the last 'size' descriptor of the PageRule into the physical
+// dimensions of the page, in CSS pixels.
+//
+// Returns false if there is no size descriptor, the size is 'auto' or
+// orientation only, or the size is invalid.
+func (p *PageRule) Size() (width, height float64, ok bool) {
+	for _, d := range p.Declarations {
+		if d.Property() == "size" {
+			width, height, ok = parsePageSize(d.Value)
+		}
+	}
+
+	return width, height, ok
+}
+
+func parsePageSize(value Tokens) (float64, float64, bool) {
+	var (
+		lengths     []float64
+		size        [2]float64
+		hasSize     bool
+		orientation string
+	)
+
+	for _, tk := range value {
+		switch tk.Type {
+		case TokenWhitespace, TokenComment:
+		case TokenDimension:
+			v, unit, err := parseDimension(tk.Data)
+			if err != nil {
+				return 0, 0, false
+			}
+
+			l, ok := absoluteLength(v, unit)
+			if !ok || l < 0 || hasSize || orientation != "" {
+				return 0, 0, false
+			}
+
+			lengths = append(lengths, l)
+		case TokenIdent:
+			keyword := strings.ToLower(tk.Data)
+
+			if ps, ok := pageSizes[keyword]; ok && !hasSize && lengths == nil {
+				size = ps
+				hasSize = true
+			} else if (keyword == "portrait" || keyword == "landscape") && orientation == "" && lengths == nil {
+				orientation = keyword
+			} else {
+				return 0, 0, false
+			}
+		default:
+			return 0, 0, false
+		}
+	}
+
+	switch len(lengths) {
+	case 0:
+	case 1:
+		return lengths[0], lengths[0], true
+	case 2:
+		return lengths[0], lengths[1], true
+	default:
+		return 0, 0, false
+	}
+
+	if !hasSize {
+		return 0, 0, false
+	}
+
+	if orientation == "landscape" {
+		return size[1], size[0], true
+	}
+
+	return size[0], size[1], true
+}
@@ -0,0 +1,174 @@
+package css
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestPage(t *testing.T) {
+	for n, test := range [...]struct {
+		Input        string
+		Selectors    []PageSelector
+		Declarations int
+		MarginBoxes  []string
+		Errors       []error
+		Err          error
+	}{
+		{ // 1
+			Input:        "@page { margin: 1cm }",
+			Declarations: 1,
+		},
+		{ // 2
+			Input:        "@page :first { size: A4; @top-center { content: \"Title\" } margin: 2cm; @bottom-right-corner { content: counter(page) } }",
+			Selectors:    []PageSelector{{Pseudo: []string{"first"}}},
+			Declarations: 2,
+			MarginBoxes:  []string{"top-center", "bottom-right-corner"},
+		},
+		{ // 3
+			Input:     "@page chapter:LEFT:blank, :right, index {}",
+			Selectors: []PageSelector{{Name: "chapter", Pseudo: []string{"left", "blank"}}, {Pseudo: []string{"right"}}, {Name: "index"}},
+		},
+		{ // 4
+			Input: "@page :middle {}",
+			Err:   ErrInvalidPagePseudoClass,
+		},
+		{ // 5
+			Input: "@page a, {}",
+			Err:   ErrInvalidPageSelector,
+		},
+		{ // 6
+			Input:        "@page { @top-middle { content: none } margin: 1cm; @top-left {} }",
+			Declarations: 1,
+			MarginBoxes:  []string{"top-left"},
+			Errors:       []error{ErrInvalidMarginBox},
+		},
+		{ // 7
+			Input: "@page :first;",
+			Err:   ErrMissingBlock,
+		},
+		{ // 8
+			Input:        "@page { a {} margin: 1cm; @media print {} @bottom-left; }",
+			Declarations: 1,
+			Errors:       []error{ErrInvalidMarginBox, ErrInvalidMarginBox},
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input + " b {}"))
+		if err == nil && len(s.Rules) != 2 {
+			t.Errorf("test %d: expecting 2 rules, got %d", n+1, len(s.Rules))
+
+			continue
+		} else if err == nil {
+			err = s.Rules[0].AtRule.Err
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		p := s.Rules[0].AtRule.Page
+
+		var marginBoxes []string
+
+		for n := range p.Selectors {
+			p.Selectors[n].Tokens = nil
+		}
+
+		for _, mb := range p.MarginBoxes {
+			marginBoxes = append(marginBoxes, mb.Name)
+		}
+
+		if !reflect.DeepEqual(p.Selectors, test.Selectors) {
+			t.Errorf("test %d: expecting selectors %v, got %v", n+1, test.Selectors, p.Selectors)
+		} else if len(p.Declarations) != test.Declarations {
+			t.Errorf("test %d: expecting %d declarations, got %d", n+1, test.Declarations, len(p.Declarations))
+		} else if !reflect.DeepEqual(marginBoxes, test.MarginBoxes) {
+			t.Errorf("test %d: expecting margin boxes %v, got %v", n+1, test.MarginBoxes, marginBoxes)
+		} else if len(p.Errors) != len(test.Errors) {
+			t.Errorf("test %d: expecting %d errors, got %d", n+1, len(test.Errors), len(p.Errors))
+		} else {
+			for m, err := range test.Errors {
+				if !errors.Is(p.Errors[m], err) {
+					t.Errorf("test %d: expecting error %d to be %v, got %v", n+1, m+1, err, p.Errors[m])
+				}
+			}
+		}
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	for n, test := range [...]struct {
+		Size          string
+		Width, Height float64
+		OK            bool
+	}{
+		{ // 1
+			Size: "auto",
+		},
+		{ // 2
+			Size:   "A4",
+			Width:  793.7,
+			Height: 1122.52,
+			OK:     true,
+		},
+		{ // 3
+			Size:   "a4 landscape",
+			Width:  1122.52,
+			Height: 793.7,
+			OK:     true,
+		},
+		{ // 4
+			Size:   "landscape letter",
+			Width:  1056,
+			Height: 816,
+			OK:     true,
+		},
+		{ // 5
+			Size:   "10cm",
+			Width:  377.95,
+			Height: 377.95,
+			OK:     true,
+		},
+		{ // 6
+			Size:   "8.5in 11in",
+			Width:  816,
+			Height: 1056,
+			OK:     true,
+		},
+		{ // 7
+			Size: "landscape",
+		},
+		{ // 8
+			Size: "10em",
+		},
+		{ // 9
+			Size: "a4 10cm",
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser("@page { size: " + test.Size + " }"))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		width, height, ok := s.Rules[0].AtRule.Page.Size()
+
+		if ok != test.OK {
+			t.Errorf("test %d: expecting ok %v, got %v", n+1, test.OK, ok)
+		} else if math.Abs(width-test.Width) > 0.01 || math.Abs(height-test.Height) > 0.01 {
+			t.Errorf("test %d: expecting size %vx%v, got %vx%v", n+1, test.Width, test.Height, width, height)
+		}
+	}
+}
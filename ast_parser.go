@@ -17,6 +17,31 @@ type Token struct {
 // Tokens is a collection of Token values.
 type Tokens []Token
 
+// String returns the concatenated data of the Tokens.
+func (t Tokens) String() string {
+	var sb strings.Builder
+
+	for _, tk := range t {
+		sb.WriteString(tk.Data)
+	}
+
+	return sb.String()
+}
+
+// Trim returns the Tokens with any leading and trailing whitespace and
+// comments removed.
+func (t Tokens) Trim() Tokens {
+	for len(t) > 0 && (t[0].Type == TokenWhitespace || t[0].Type == TokenComment) {
+		t = t[1:]
+	}
+
+	for len(t) > 0 && (t[len(t)-1].Type == TokenWhitespace || t[len(t)-1].Type == TokenComment) {
+		t = t[:len(t)-1]
+	}
+
+	return t
+}
+
 // Comments is a collection of Comment Tokens.
 type Comments []*Token
 
@@ -56,7 +81,7 @@ func newCSSParser(t *parser.Tokeniser) (cssParser, error) {
 			)
 
 			for n, c := range tk.Data {
-				if strings.ContainsRune(whitespace, c) {
+				if strings.ContainsRune(newline, c) {
 					lastLT = n + 1
 					linePos = 0
 
@@ -79,6 +104,25 @@ func newCSSParser(t *parser.Tokeniser) (cssParser, error) {
 	return tokens[0:0:len(tokens)], err
 }
 
+func newCSSParserFromTokens(ts Tokens) cssParser {
+	tokens := make(cssParser, len(ts), len(ts)+1)
+
+	copy(tokens, ts)
+
+	var done Token
+
+	if len(ts) > 0 {
+		last := ts[len(ts)-1]
+		done.Pos = last.Pos + uint64(len(last.Data))
+		done.Line = last.Line
+		done.LinePos = last.LinePos + uint64(len(last.Data))
+	}
+
+	done.Type = parser.TokenDone
+
+	return append(tokens, done)[0 : 0 : len(ts)+1]
+}
+
 func (c cssParser) NewGoal() cssParser {
 	return c[len(c):]
 }
@@ -183,6 +227,50 @@ func (c *cssParser) SkipDepth() bool {
 	return true
 }
 
+func (c *cssParser) AcceptComponentValue() bool {
+	switch c.next().Type {
+	case parser.TokenDone, parser.TokenError:
+		c.backup()
+
+		return false
+	case TokenOpenParen, TokenOpenBracket, TokenOpenBrace, TokenFunction:
+		for depth := 1; depth > 0; {
+			switch c.next().Type {
+			case TokenOpenParen, TokenOpenBracket, TokenOpenBrace, TokenFunction:
+				depth++
+			case TokenCloseParen, TokenCloseBracket, TokenCloseBrace:
+				depth--
+			case parser.TokenDone, parser.TokenError:
+				c.backup()
+
+				return true
+			}
+		}
+	}
+
+	return true
+}
+
+func (c *cssParser) AcceptIdent(ident string) bool {
+	if tk := c.next(); tk.Type == TokenIdent && strings.EqualFold(tk.Data, ident) {
+		return true
+	}
+
+	c.backup()
+
+	return false
+}
+
+func (c *cssParser) AcceptFunction(name string) bool {
+	if tk := c.next(); tk.Type == TokenFunction && strings.EqualFold(tk.Data[:len(tk.Data)-1], name) {
+		return true
+	}
+
+	c.backup()
+
+	return false
+}
+
 func (c *cssParser) AcceptToken(tk parser.Token) bool {
 	if c.next().Token == tk {
 		return true
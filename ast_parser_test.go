@@ -0,0 +1,60 @@
+package css
+
+import (
+	"testing"
+)
+
+func TestParserPositions(t *testing.T) {
+	tks := tokenise(t, "a b\n\tc\n\nd")
+
+	for n, test := range [...]struct {
+		Data               string
+		Pos, Line, LinePos uint64
+	}{
+		{ // 1
+			Data: "a",
+		},
+		{ // 2
+			Data:    " ",
+			Pos:     1,
+			LinePos: 1,
+		},
+		{ // 3
+			Data:    "b",
+			Pos:     2,
+			LinePos: 2,
+		},
+		{ // 4
+			Data:    "\n\t",
+			Pos:     3,
+			LinePos: 3,
+		},
+		{ // 5
+			Data:    "c",
+			Pos:     5,
+			Line:    1,
+			LinePos: 1,
+		},
+		{ // 6
+			Data:    "\n\n",
+			Pos:     6,
+			Line:    1,
+			LinePos: 2,
+		},
+		{ // 7
+			Data: "d",
+			Pos:  8,
+			Line: 3,
+		},
+	} {
+		if n >= len(tks) {
+			t.Fatalf("test %d: expecting token %q, got none", n+1, test.Data)
+		}
+
+		if tk := tks[n]; tk.Data != test.Data {
+			t.Errorf("test %d: expecting token %q, got %q", n+1, test.Data, tk.Data)
+		} else if tk.Pos != test.Pos || tk.Line != test.Line || tk.LinePos != test.LinePos {
+			t.Errorf("test %d: expecting position %d (%d:%d), got %d (%d:%d)", n+1, test.Pos, test.Line, test.LinePos, tk.Pos, tk.Line, tk.LinePos)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package css
+
+import "strings"
+
+// PositionTry represents an @position-try rule, which defines a named set of
+// positioning declarations to try when an anchored element overflows.
+type PositionTry struct {
+	Name         string
+	Declarations []*Declaration
+}
+
+func (p *PositionTry) parse(c *cssParser) error {
+	name, err := parseDashedIdent(c)
+	if err != nil {
+		return c.Error("PositionTry", err)
+	}
+
+	p.Name = name
+
+	return nil
+}
+
+func parseDashedIdent(c *cssParser) (string, error) {
+	if !c.Accept(TokenIdent) || !strings.HasPrefix(c.GetLastToken().Data, "--") {
+		return "", ErrInvalidDashedIdent
+	}
+
+	return c.GetLastToken().Data, nil
+}
@@ -0,0 +1,66 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestPositionTry(t *testing.T) {
+	for n, test := range [...]struct {
+		Input        string
+		Name         string
+		Declarations []string
+		Err          error
+	}{
+		{ // 1
+			Input:        "@position-try --below { top: anchor(bottom); left: anchor(left) }",
+			Name:         "--below",
+			Declarations: []string{"top", "left"},
+		},
+		{ // 2
+			Input: "@position-try below {}",
+			Err:   ErrInvalidDashedIdent,
+		},
+		{ // 3
+			Input: "@position-try --a --b {}",
+			Err:   ErrUnexpectedToken,
+		},
+		{ // 4
+			Input: "@position-try --a;",
+			Err:   ErrMissingBlock,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		pt := s.Rules[0].AtRule.PositionTry
+
+		if pt.Name != test.Name {
+			t.Errorf("test %d: expecting name %q, got %q", n+1, test.Name, pt.Name)
+		} else if len(pt.Declarations) != len(test.Declarations) {
+			t.Errorf("test %d: expecting %d declarations, got %d", n+1, len(test.Declarations), len(pt.Declarations))
+		} else {
+			for m, d := range pt.Declarations {
+				if d.Property() != test.Declarations[m] {
+					t.Errorf("test %d.%d: expecting property %q, got %q", n+1, m+1, test.Declarations[m], d.Property())
+				}
+			}
+		}
+	}
+}
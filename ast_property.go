@@ -0,0 +1,559 @@
+package css
+
+import (
+	"slices"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// PropertyRule represents an @property rule, which registers a custom
+// property.
+//
+// The InitialValue may only be empty when the Syntax is universal.
+type PropertyRule struct {
+	Name         string
+	Syntax       *PropertySyntax
+	Inherits     bool
+	InitialValue Tokens
+}
+
+func (p *PropertyRule) parse(c *cssParser) error {
+	if !c.Accept(TokenIdent) || !strings.HasPrefix(c.GetLastToken().Data, "--") {
+		return c.Error("PropertyRule", ErrInvalidPropertyName)
+	}
+
+	p.Name = c.GetLastToken().Data
+
+	return nil
+}
+
+func (p *PropertyRule) parseBlock(b *Block) error {
+	var hasInherits, hasInitialValue bool
+
+	for _, d := range b.Declarations() {
+		c := newCSSParserFromTokens(d.Value)
+
+		var err error
+
+		c.AcceptRunWhitespace()
+
+		switch d.Property() {
+		case "syntax":
+			err = p.parseSyntax(&c)
+		case "inherits":
+			var inherits string
+
+			inherits, err = parseKeyword(&c, "true", "false")
+			p.Inherits = inherits == "true"
+			hasInherits = true
+		case "initial-value":
+			p.InitialValue = d.Value.Trim()
+			hasInitialValue = true
+
+			continue
+		default:
+			continue
+		}
+
+		if err == nil && c.AcceptRunWhitespace() != parser.TokenDone {
+			err = c.Error("PropertyRule", ErrUnexpectedToken)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.Syntax == nil {
+		return ErrMissingSyntax
+	} else if !hasInherits {
+		return ErrMissingInherits
+	} else if !hasInitialValue && !p.Syntax.Universal {
+		return ErrMissingInitialValue
+	} else if !p.Syntax.Match(p.InitialValue) || !isComputationallyIndependent(p.InitialValue) {
+		if len(p.InitialValue) == 0 {
+			return ErrInvalidInitialValue
+		}
+
+		return Error{
+			Err:     ErrInvalidInitialValue,
+			Parsing: "PropertyRule",
+			Token:   p.InitialValue[0],
+		}
+	}
+
+	return nil
+}
+
+func (p *PropertyRule) parseSyntax(c *cssParser) error {
+	if !c.Accept(TokenString) {
+		return c.Error("PropertyRule", ErrInvalidSyntax)
+	}
+
+	str, err := Unquote(c.GetLastToken().Data)
+	if err != nil {
+		return c.Error("PropertyRule", err)
+	}
+
+	if p.Syntax, err = ParsePropertySyntax(str); err != nil {
+		return c.Error("PropertyRule", err)
+	}
+
+	return nil
+}
+
+func isComputationallyIndependent(value Tokens) bool {
+	for _, tk := range value {
+		switch tk.Type {
+		case TokenFunction:
+			switch strings.ToLower(tk.Data) {
+			case "var(", "attr(":
+				return false
+			}
+		case TokenDimension:
+			if _, unit, err := parseDimension(tk.Data); err != nil || isRelativeLengthUnit(unit) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// SyntaxMultiplier specifies how many times a SyntaxComponent may be repeated.
+type SyntaxMultiplier uint8
+
+// Syntax multipliers.
+const (
+	SyntaxMultiplierNone  SyntaxMultiplier = iota
+	SyntaxMultiplierSpace                  // '+', a space separated list
+	SyntaxMultiplierComma                  // '#', a comma separated list
+)
+
+// PropertySyntax represents the parsed syntax descriptor of an @property
+// rule, such as '<length> | <percentage>+ | auto'.
+//
+// A Universal syntax, '*', accepts any value and has no Components.
+type PropertySyntax struct {
+	Universal  bool
+	Components []SyntaxComponent
+}
+
+// SyntaxComponent represents a single alternative in a PropertySyntax.
+//
+// Only one of Type, the name of a supported data type such as 'length', or
+// Ident, a literal identifier, will be set.
+type SyntaxComponent struct {
+	Type       string
+	Ident      string
+	Multiplier SyntaxMultiplier
+}
+
+var syntaxTypes = map[string]func(Tokens) bool{
+	"angle":              isAngle,
+	"color":              isColor,
+	"custom-ident":       isCustomIdent,
+	"image":              isImage,
+	"integer":            isInteger,
+	"length":             isLength,
+	"length-percentage":  isLengthPercentage,
+	"number":             isNumber,
+	"percentage":         isPercentage,
+	"resolution":         isResolution,
+	"string":             isString,
+	"time":               isTime,
+	"transform-function": isTransformFunction,
+	"transform-list":     isTransformFunction,
+	"url":                isURL,
+}
+
+// ParsePropertySyntax parses the contents of an @property syntax descriptor.
+func ParsePropertySyntax(str string) (*PropertySyntax, error) {
+	str = strings.Trim(str, whitespace)
+
+	if str == "*" {
+		return &PropertySyntax{Universal: true}, nil
+	}
+
+	var p PropertySyntax
+
+	for _, component := range strings.Split(str, "|") {
+		var sc SyntaxComponent
+
+		component = strings.Trim(component, whitespace)
+
+		if strings.HasSuffix(component, "+") {
+			sc.Multiplier = SyntaxMultiplierSpace
+		} else if strings.HasSuffix(component, "#") {
+			sc.Multiplier = SyntaxMultiplierComma
+		}
+
+		if sc.Multiplier != SyntaxMultiplierNone {
+			component = component[:len(component)-1]
+		}
+
+		if len(component) > 2 && component[0] == '<' && component[len(component)-1] == '>' {
+			sc.Type = component[1 : len(component)-1]
+
+			if _, ok := syntaxTypes[sc.Type]; !ok || sc.Type == "transform-list" && sc.Multiplier != SyntaxMultiplierNone {
+				return nil, ErrInvalidSyntax
+			}
+		} else if isIdent(component) && !isCSSWideKeyword(component) && !strings.EqualFold(component, "default") {
+			sc.Ident = component
+		} else {
+			return nil, ErrInvalidSyntax
+		}
+
+		p.Components = append(p.Components, sc)
+	}
+
+	return &p, nil
+}
+
+func isIdent(str string) bool {
+	c, err := newCSSParser(CreateTokeniser(parser.NewStringTokeniser(str), false))
+
+	return err == nil && c.Accept(TokenIdent) && c.Peek().Type == parser.TokenDone
+}
+
+func isCSSWideKeyword(ident string) bool {
+	switch strings.ToLower(ident) {
+	case "initial", "inherit", "unset", "revert", "revert-layer":
+		return true
+	}
+
+	return false
+}
+
+// Match returns true if the given value matches the syntax.
+func (p *PropertySyntax) Match(value Tokens) bool {
+	if p.Universal {
+		return true
+	}
+
+	values := componentValues(value)
+
+	for _, sc := range p.Components {
+		if sc.match(values) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func componentValues(ts Tokens) []Tokens {
+	var values []Tokens
+
+	c := newCSSParserFromTokens(ts)
+
+	for {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+
+		if !d.AcceptComponentValue() {
+			return values
+		}
+
+		values = append(values, d.ToTokens())
+
+		c.Score(d)
+	}
+}
+
+func (s *SyntaxComponent) match(values []Tokens) bool {
+	if len(values) == 0 {
+		return false
+	}
+
+	switch s.Multiplier {
+	case SyntaxMultiplierSpace:
+		for _, v := range values {
+			if !s.matchValue(v) {
+				return false
+			}
+		}
+
+		return true
+	case SyntaxMultiplierComma:
+		if len(values)%2 == 0 {
+			return false
+		}
+
+		for n, v := range values {
+			if n%2 == 1 {
+				if v[0].Type != TokenComma {
+					return false
+				}
+			} else if !s.matchValue(v) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if s.Type == "transform-list" {
+		for _, v := range values {
+			if !isTransformFunction(v) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return len(values) == 1 && s.matchValue(values[0])
+}
+
+func (s *SyntaxComponent) matchValue(v Tokens) bool {
+	if s.Ident != "" {
+		return v[0].Type == TokenIdent && v[0].Data == s.Ident
+	}
+
+	return syntaxTypes[s.Type](v)
+}
+
+func functionName(tk Token) string {
+	if tk.Type != TokenFunction {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSuffix(tk.Data, "("))
+}
+
+func isMathFunction(v Tokens) bool {
+	switch functionName(v[0]) {
+	case "calc", "min", "max", "clamp", "round", "mod", "rem", "sin", "cos", "tan", "asin", "acos", "atan", "atan2", "pow", "sqrt", "hypot", "log", "exp", "abs", "sign":
+		return true
+	}
+
+	return false
+}
+
+func dimensionUnit(tk Token) string {
+	if tk.Type != TokenDimension {
+		return ""
+	}
+
+	_, unit, err := parseDimension(tk.Data)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(unit)
+}
+
+func isZero(tk Token) bool {
+	if tk.Type != TokenNumber {
+		return false
+	}
+
+	v, _, err := parseDimension(tk.Data)
+
+	return err == nil && v == 0
+}
+
+// isMathOfType returns true when the value is a math function that resolves
+// to one of the given categories, with any percentages resolving against the
+// percentages category.
+//
+// Calculations whose type depends on substitutions, such as var(), are
+// assumed to be of the right type.
+func isMathOfType(v Tokens, percentages UnitCategory, categories ...UnitCategory) bool {
+	if !isMathFunction(v) {
+		return false
+	}
+
+	m, err := ParseMath(v)
+	if err != nil {
+		return false
+	}
+
+	t, err := m.Type(percentages)
+
+	return err == nil && (t == UnitUnknown || slices.Contains(categories, t))
+}
+
+func isLength(v Tokens) bool {
+	return isLengthUnit(dimensionUnit(v[0])) || isZero(v[0]) || isMathOfType(v, UnitPercentage, UnitLength)
+}
+
+func isPercentage(v Tokens) bool {
+	return v[0].Type == TokenPercentage || isMathOfType(v, UnitPercentage, UnitPercentage)
+}
+
+func isLengthPercentage(v Tokens) bool {
+	return isLengthUnit(dimensionUnit(v[0])) || isZero(v[0]) || v[0].Type == TokenPercentage || isMathOfType(v, UnitLength, UnitLength)
+}
+
+func isNumber(v Tokens) bool {
+	return v[0].Type == TokenNumber || isMathOfType(v, UnitPercentage, UnitNumber)
+}
+
+func isInteger(v Tokens) bool {
+	return v[0].Type == TokenNumber && !strings.ContainsAny(v[0].Data, ".eE") || isMathOfType(v, UnitPercentage, UnitNumber)
+}
+
+func isAngle(v Tokens) bool {
+	switch dimensionUnit(v[0]) {
+	case "deg", "grad", "rad", "turn":
+		return true
+	}
+
+	return isMathOfType(v, UnitPercentage, UnitAngle)
+}
+
+func isTime(v Tokens) bool {
+	switch dimensionUnit(v[0]) {
+	case "s", "ms":
+		return true
+	}
+
+	return isMathOfType(v, UnitPercentage, UnitTime)
+}
+
+func isResolution(v Tokens) bool {
+	switch dimensionUnit(v[0]) {
+	case "dpi", "dpcm", "dppx", "x":
+		return true
+	}
+
+	return isMathOfType(v, UnitPercentage, UnitResolution)
+}
+
+func isString(v Tokens) bool {
+	return v[0].Type == TokenString
+}
+
+func isCustomIdent(v Tokens) bool {
+	return v[0].Type == TokenIdent && !isCSSWideKeyword(v[0].Data) && !strings.EqualFold(v[0].Data, "default")
+}
+
+func isURL(v Tokens) bool {
+	switch functionName(v[0]) {
+	case "url", "src":
+		return true
+	}
+
+	return v[0].Type == TokenURL
+}
+
+func isImage(v Tokens) bool {
+	switch functionName(v[0]) {
+	case "image", "image-set", "cross-fade", "element", "paint",
+		"linear-gradient", "radial-gradient", "conic-gradient",
+		"repeating-linear-gradient", "repeating-radial-gradient", "repeating-conic-gradient":
+		return true
+	}
+
+	return isURL(v)
+}
+
+func isColor(v Tokens) bool {
+	switch tk := v[0]; tk.Type {
+	case TokenHash:
+		switch len(tk.Data) {
+		case 4, 5, 7, 9:
+			return strings.Trim(tk.Data[1:], hexDigits) == ""
+		}
+	case TokenIdent:
+		name := strings.ToLower(tk.Data)
+
+		_, ok := namedColors[name]
+
+		return ok || name == "transparent" || name == "currentcolor"
+	case TokenFunction:
+		switch functionName(tk) {
+		case "rgb", "rgba", "hsl", "hsla", "hwb", "lab", "lch", "oklab", "oklch", "color", "color-mix", "light-dark":
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTransformFunction(v Tokens) bool {
+	switch functionName(v[0]) {
+	case "matrix", "matrix3d", "perspective",
+		"rotate", "rotate3d", "rotatex", "rotatey", "rotatez",
+		"scale", "scale3d", "scalex", "scaley", "scalez",
+		"skew", "skewx", "skewy",
+		"translate", "translate3d", "translatex", "translatey", "translatez":
+		return true
+	}
+
+	return false
+}
+
+// PropertyRegistrations returns the custom properties registered by the
+// @property rules in the Sheet, keyed by name.
+//
+// When a property is registered more than once, the last registration wins.
+func (s *Sheet) PropertyRegistrations() map[string]*PropertyRule {
+	registrations := make(map[string]*PropertyRule)
+
+	s.walkRules(func(r *Rule) {
+		if r.AtRule != nil && r.AtRule.Property != nil {
+			registrations[r.AtRule.Property.Name] = r.AtRule.Property
+		}
+	})
+
+	return registrations
+}
+
+// CheckPropertyValues checks the values given to registered custom properties
+// against the syntax of their registrations, returning an error for each
+// invalid declaration.
+//
+// Values that are CSS-wide keywords, or that contain var() or attr()
+// references, cannot be checked and are skipped.
+func (s *Sheet) CheckPropertyValues() []error {
+	var (
+		registrations = s.PropertyRegistrations()
+		errs          []error
+	)
+
+	s.walkRules(func(r *Rule) {
+		b := r.block()
+		if b == nil || r.AtRule != nil && r.AtRule.Name() == "property" {
+			return
+		}
+
+		for _, d := range b.Declarations() {
+			p, ok := registrations[d.Name.Data]
+			if !ok {
+				continue
+			}
+
+			value := d.Value.Trim()
+
+			if len(value) == 1 && value[0].Type == TokenIdent && isCSSWideKeyword(value[0].Data) || hasReference(value) {
+				continue
+			}
+
+			if !p.Syntax.Match(value) {
+				errs = append(errs, Error{
+					Err:     ErrInvalidPropertyValue,
+					Parsing: "Declaration",
+					Token:   *d.Name,
+				})
+			}
+		}
+	})
+
+	return errs
+}
+
+func hasReference(value Tokens) bool {
+	for _, tk := range value {
+		switch functionName(tk) {
+		case "var", "attr":
+			return true
+		}
+	}
+
+	return false
+}
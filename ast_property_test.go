@@ -0,0 +1,231 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestPropertyRule(t *testing.T) {
+	for n, test := range [...]struct {
+		Input        string
+		Name         string
+		Syntax       *PropertySyntax
+		Inherits     bool
+		InitialValue string
+		Err          error
+	}{
+		{ // 1
+			Input:        "@property --angle { syntax: '<angle>'; inherits: false; initial-value: 0deg; }",
+			Name:         "--angle",
+			Syntax:       &PropertySyntax{Components: []SyntaxComponent{{Type: "angle"}}},
+			InitialValue: "0deg",
+		},
+		{ // 2
+			Input:    "@property --any { syntax: '*'; inherits: true }",
+			Name:     "--any",
+			Syntax:   &PropertySyntax{Universal: true},
+			Inherits: true,
+		},
+		{ // 3
+			Input:        "@property --x { syntax: '<length>+ | <percentage># | auto'; inherits: false; initial-value: 1px 2px }",
+			Name:         "--x",
+			Syntax:       &PropertySyntax{Components: []SyntaxComponent{{Type: "length", Multiplier: SyntaxMultiplierSpace}, {Type: "percentage", Multiplier: SyntaxMultiplierComma}, {Ident: "auto"}}},
+			InitialValue: "1px 2px",
+		},
+		{ // 4
+			Input:        "@property --t { syntax: '<transform-list>'; inherits: false; initial-value: rotate(10deg) scale(2) }",
+			Name:         "--t",
+			Syntax:       &PropertySyntax{Components: []SyntaxComponent{{Type: "transform-list"}}},
+			InitialValue: "rotate(10deg) scale(2)",
+		},
+		{ // 5
+			Input: "@property --a { syntax: '<angle>'; inherits: false; initial-value: 10px }",
+			Err:   ErrInvalidInitialValue,
+		},
+		{ // 6
+			Input: "@property --a { syntax: '<length>'; inherits: false; initial-value: 2em }",
+			Err:   ErrInvalidInitialValue,
+		},
+		{ // 7
+			Input: "@property --a { syntax: '<length>'; inherits: false }",
+			Err:   ErrMissingInitialValue,
+		},
+		{ // 8
+			Input: "@property --a { syntax: '<length>'; initial-value: 0 }",
+			Err:   ErrMissingInherits,
+		},
+		{ // 9
+			Input: "@property --a { inherits: false }",
+			Err:   ErrMissingSyntax,
+		},
+		{ // 10
+			Input: "@property --a { syntax: '<colour>'; inherits: false; initial-value: red }",
+			Err:   ErrInvalidSyntax,
+		},
+		{ // 11
+			Input: "@property --a { syntax: '<transform-list>+'; inherits: false; initial-value: scale(1) }",
+			Err:   ErrInvalidSyntax,
+		},
+		{ // 12
+			Input: "@property a { syntax: '*'; inherits: false }",
+			Err:   ErrInvalidPropertyName,
+		},
+		{ // 13
+			Input: "@property --a { syntax: '<color>'; inherits: maybe; initial-value: red }",
+			Err:   ErrInvalidKeyword,
+		},
+		{ // 14
+			Input: "@property --a { syntax: '<color>'; inherits: false; initial-value: var(--b) }",
+			Err:   ErrInvalidInitialValue,
+		},
+		{ // 15
+			Input: "@property --a;",
+			Err:   ErrMissingBlock,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input + " a {}"))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		} else if len(s.Rules) != 2 {
+			t.Errorf("test %d: expecting 2 rules, got %d", n+1, len(s.Rules))
+
+			continue
+		}
+
+		a := s.Rules[0].AtRule
+
+		if test.Err != nil {
+			if a.Property != nil {
+				t.Errorf("test %d: expecting no PropertyRule", n+1)
+			} else if !errors.Is(a.Err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, a.Err)
+			}
+
+			continue
+		} else if a.Err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, a.Err)
+
+			continue
+		}
+
+		p := a.Property
+
+		if p.Name != test.Name {
+			t.Errorf("test %d: expecting name %q, got %q", n+1, test.Name, p.Name)
+		} else if !reflect.DeepEqual(p.Syntax, test.Syntax) {
+			t.Errorf("test %d: expecting syntax %v, got %v", n+1, test.Syntax, p.Syntax)
+		} else if p.Inherits != test.Inherits {
+			t.Errorf("test %d: expecting inherits %v, got %v", n+1, test.Inherits, p.Inherits)
+		} else if iv := p.InitialValue.String(); iv != test.InitialValue {
+			t.Errorf("test %d: expecting initial value %q, got %q", n+1, test.InitialValue, iv)
+		}
+	}
+}
+
+func TestPropertySyntaxMatch(t *testing.T) {
+	for n, test := range [...]struct {
+		Syntax, Value string
+		Match         bool
+	}{
+		{"<length>", "10px", true},
+		{"<length>", "0", true},
+		{"<length>", "10", false},
+		{"<length>", "calc(1px + 2em)", true},
+		{"<length>", "calc(1s + 2ms)", false},
+		{"<length>", "calc(10% + 1px)", false},
+		{"<length>", "calc(var(--a) * 2)", true},
+		{"<length-percentage>", "calc(10% + 1px)", true},
+		{"<length-percentage>", "calc(10% + 1)", false},
+		{"<percentage>", "calc(10% * 2)", true},
+		{"<percentage>", "min(10%, 1px)", false},
+		{"<number>", "calc(1px / 1px)", true},
+		{"<integer>", "calc(1px)", false},
+		{"<time>", "max(1s, 1px)", false},
+		{"<angle>", "calc(1turn / 2)", true},
+		{"<length>", "10px 20px", false},
+		{"<length-percentage>", "50%", true},
+		{"<integer>", "3", true},
+		{"<integer>", "3.5", false},
+		{"<number>", "3.5", true},
+		{"<color>", "#ff0", true},
+		{"<color>", "#ff", false},
+		{"<color>", "RebeccaPurple", true},
+		{"<color>", "oklch(70% 0.1 200)", true},
+		{"<color>", "redish", false},
+		{"<image>", "linear-gradient(red, blue)", true},
+		{"<image>", "url(a.png)", true},
+		{"<url>", "url(\"a.png\")", true},
+		{"<string>", "'a'", true},
+		{"<custom-ident>", "foo", true},
+		{"<custom-ident>", "inherit", false},
+		{"<time>", "1s", true},
+		{"<resolution>", "2x", true},
+		{"<resolution>", "calc(2x + 1s)", false},
+		{"<length>#", "1px, 2px ,3px", true},
+		{"<length>#", "1px, 2px,", false},
+		{"<length>#", "1px 2px", false},
+		{"<length>+", "1px 2px", true},
+		{"<length>+", "1px, 2px", false},
+		{"small | medium | large", "medium", true},
+		{"small | medium | large", "Medium", false},
+		{"<length> | auto", "auto", true},
+		{"<transform-function>", "translateX(1px)", true},
+		{"<transform-list>", "", false},
+		{"*", "", true},
+	} {
+		p, err := ParsePropertySyntax(test.Syntax)
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if match := p.Match(tokenise(t, test.Value)); match != test.Match {
+			t.Errorf("test %d: expecting %q to match %q: %v, got %v", n+1, test.Value, test.Syntax, test.Match, match)
+		}
+	}
+}
+
+func TestCheckPropertyValues(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(`@property --size {
+	syntax: '<length>';
+	inherits: false;
+	initial-value: 0px;
+}
+a {
+	--size: 10px;
+	--other: red;
+}
+@media screen {
+	b {
+		--size: red;
+		--size: var(--x);
+		--size: inherit;
+	}
+}
+c {
+	--size: 1px 2px;
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	errs := s.CheckPropertyValues()
+
+	var lines []uint64
+
+	for _, err := range errs {
+		var e Error
+
+		if !errors.As(err, &e) || !errors.Is(err, ErrInvalidPropertyValue) {
+			t.Errorf("expecting ErrInvalidPropertyValue, got %v", err)
+		}
+
+		lines = append(lines, e.Token.Line+1)
+	}
+
+	if expected := []uint64{12, 18}; !reflect.DeepEqual(lines, expected) {
+		t.Errorf("expecting errors on lines %v, got %v", expected, lines)
+	}
+}
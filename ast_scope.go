@@ -0,0 +1,75 @@
+package css
+
+// ScopeRule represents the prelude of an @scope rule, such as
+// '(.card) to (.content)'.
+//
+// Either, or both, of Start and End may be nil. When the Start is nil, the
+// scope root is the parent of the element containing the stylesheet, or the
+// subject of the parent style rule when nested.
+//
+// The End is parsed as a relative selector list, with any selectors that are
+// not Anchored implicitly relative to the scope root, as though prefixed by
+// ':scope'.
+//
+// The style rules within the Block of an @scope rule are likewise parsed as
+// relative selectors.
+type ScopeRule struct {
+	Start  *SelectorList
+	End    *SelectorList
+	Tokens Tokens
+}
+
+func (s *ScopeRule) parse(c *cssParser) error {
+	if c.Accept(TokenOpenParen) {
+		s.Start = new(SelectorList)
+
+		if err := parseScopeSelectors(c, s.Start, false); err != nil {
+			return c.Error("ScopeRule", err)
+		}
+
+		c.AcceptRunWhitespace()
+	}
+
+	if c.AcceptIdent("to") {
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenOpenParen) {
+			return c.Error("ScopeRule", ErrInvalidScope)
+		}
+
+		s.End = new(SelectorList)
+
+		if err := parseScopeSelectors(c, s.End, true); err != nil {
+			return c.Error("ScopeRule", err)
+		}
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func parseScopeSelectors(c *cssParser, s *SelectorList, relative bool) error {
+	d := newCSSParserFromTokens(acceptFunctionArguments(c))
+
+	return d.parseSelectorList(s, nil, relative)
+}
+
+// resolveNamespaces reparses the selector lists, which were parsed without
+// checking their namespace prefixes, against the declared namespaces.
+func (s *ScopeRule) resolveNamespaces(ns map[string]string) error {
+	for _, sl := range [...]*SelectorList{s.Start, s.End} {
+		if sl == nil {
+			continue
+		}
+
+		c := newCSSParserFromTokens(sl.Tokens)
+		*sl = SelectorList{}
+
+		if err := c.parseSelectorList(sl, ns, sl == s.End); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestScope(t *testing.T) {
+	for n, test := range [...]struct {
+		Input    string
+		Start    string
+		End      string
+		Rules    []string
+		Anchored []bool
+		Err      error
+	}{
+		{ // 1
+			Input:    "@scope (.card) to (.content) { img { border: none } :scope > p {} }",
+			Start:    ".card",
+			End:      ".content",
+			Rules:    []string{"img", ":scope > p"},
+			Anchored: []bool{false, true},
+		},
+		{ // 2
+			Input:    "@scope { > a, :is(&) b {} }",
+			Rules:    []string{"> a, :is(&) b"},
+			Anchored: []bool{false},
+		},
+		{ // 3
+			Input: "@scope to (:scope > .end) { color: red }",
+			End:   ":scope > .end",
+		},
+		{ // 4
+			Input:    "@namespace svg \"http://www.w3.org/2000/svg\"; @scope (svg|svg, .a) { svg|rect {} }",
+			Start:    "svg|svg, .a",
+			Rules:    []string{"svg|rect"},
+			Anchored: []bool{false},
+		},
+		{ // 5
+			Input: "@scope (.a) to .b {}",
+			Err:   ErrInvalidScope,
+		},
+		{ // 6
+			Input: "@scope (.a) (.b) {}",
+			Err:   ErrUnexpectedToken,
+		},
+		{ // 7
+			Input: "@scope (svg|a) {}",
+			Err:   ErrUndeclaredNamespace,
+		},
+		{ // 8
+			Input: "@scope (.a);",
+			Err:   ErrMissingBlock,
+		},
+		{ // 9
+			Input: "@scope (.a >) {}",
+			Err:   ErrInvalidSelector,
+		},
+		{ // 10
+			Input: "@scope (.a) to (> .b, .c) {}",
+			Start: ".a",
+			End:   "> .b, .c",
+		},
+		{ // 11
+			Input: "@scope (> .a) {}",
+			Err:   ErrInvalidSelector,
+		},
+		{ // 12
+			Input: "@namespace svg \"http://www.w3.org/2000/svg\"; @scope to (svg|a) {}",
+			End:   "svg|a",
+		},
+		{ // 13
+			Input: "@scope to (svg|a) {}",
+			Err:   ErrUndeclaredNamespace,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		a := s.Rules[len(s.Rules)-1].AtRule
+
+		var start, end string
+
+		if a.Scope.Start != nil {
+			start = describeSelectors(a.Scope.Start)
+		}
+
+		if a.Scope.End != nil {
+			end = describeSelectors(a.Scope.End)
+		}
+
+		if start != test.Start {
+			t.Errorf("test %d: expecting start %q, got %q", n+1, test.Start, start)
+		} else if end != test.End {
+			t.Errorf("test %d: expecting end %q, got %q", n+1, test.End, end)
+		}
+
+		rules := a.Block.Rules()
+
+		if len(rules) != len(test.Rules) {
+			t.Errorf("test %d: expecting %d rules, got %d", n+1, len(test.Rules), len(rules))
+
+			continue
+		}
+
+		for m, r := range rules {
+			if sel := describeSelectors(r.QualifiedRule.Selectors); sel != test.Rules[m] {
+				t.Errorf("test %d.%d: expecting selectors %q, got %q", n+1, m+1, test.Rules[m], sel)
+			} else if anchored := r.QualifiedRule.Selectors.Selectors[0].Anchored(); anchored != test.Anchored[m] {
+				t.Errorf("test %d.%d: expecting anchored %v, got %v", n+1, m+1, test.Anchored[m], anchored)
+			}
+		}
+	}
+}
+
+func TestScopePrelude(t *testing.T) {
+	for n, test := range [...]struct {
+		Input      string
+		Start, End string
+		Anchored   []bool
+	}{
+		{ // 1
+			Input:    "@scope (.a) to (.b, > .c, :scope .d) {}",
+			Start:    ".a",
+			End:      ".b, > .c, :scope .d",
+			Anchored: []bool{false, false, true},
+		},
+		{ // 2
+			Input: "@scope (svg|a) {}",
+			Start: "svg|a",
+		},
+	} {
+		c := newCSSParserFromTokens(tokenise(t, test.Input))
+
+		var r Rule
+
+		if err := r.parse(&c, false); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		scope := r.AtRule.Scope
+
+		var start, end string
+
+		if scope.Start != nil {
+			start = describeSelectors(scope.Start)
+		}
+
+		if scope.End != nil {
+			end = describeSelectors(scope.End)
+		}
+
+		if start != test.Start {
+			t.Errorf("test %d: expecting start %q, got %q", n+1, test.Start, start)
+		} else if end != test.End {
+			t.Errorf("test %d: expecting end %q, got %q", n+1, test.End, end)
+		} else {
+			for m, anchored := range test.Anchored {
+				if a := scope.End.Selectors[m].Anchored(); a != anchored {
+					t.Errorf("test %d.%d: expecting anchored %v, got %v", n+1, m+1, anchored, a)
+				}
+			}
+		}
+	}
+}
+
+func TestScopeUndeclaredNamespace(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser("@scope (svg|a) { b {} } c {}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if len(s.Rules) != 2 {
+		t.Fatalf("expecting 2 rules, got %d", len(s.Rules))
+	}
+
+	a := s.Rules[0].AtRule
+
+	if a.Scope != nil {
+		t.Errorf("expecting no Scope")
+	} else if !errors.Is(a.Err, ErrUndeclaredNamespace) {
+		t.Errorf("expecting error %v, got %v", ErrUndeclaredNamespace, a.Err)
+	} else if s.Rules[1].QualifiedRule.Selectors == nil {
+		t.Errorf("expecting following rule to be parsed")
+	}
+}
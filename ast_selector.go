@@ -0,0 +1,557 @@
+package css
+
+import (
+	"errors"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// SelectorList represents a comma separated list of complex selectors.
+type SelectorList struct {
+	Selectors []ComplexSelector
+	Tokens    Tokens
+}
+
+func (s *SelectorList) parse(c *cssParser, ns map[string]string, relative bool) error {
+	for {
+		d := c.NewGoal()
+
+		var cs ComplexSelector
+
+		if err := cs.parse(&d, ns, relative); err != nil {
+			return c.Error("SelectorList", err)
+		}
+
+		s.Selectors = append(s.Selectors, cs)
+
+		c.Score(d)
+
+		d = c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if !d.Accept(TokenComma) {
+			break
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// parseForgiving parses a forgiving selector list, as taken by :is() and
+// :where(), in which invalid selectors are dropped rather than invalidating
+// the whole list, and which may be empty.
+func (s *SelectorList) parseForgiving(c *cssParser, ns map[string]string) {
+	for {
+		d := c.NewGoal()
+
+		for tk := d.Peek().Type; tk != TokenComma && tk != parser.TokenDone; tk = d.Peek().Type {
+			if !d.AcceptComponentValue() {
+				break
+			}
+		}
+
+		e := newCSSParserFromTokens(d.ToTokens())
+
+		var cs ComplexSelector
+
+		if parseTrimmed(&e, func(c *cssParser) error { return cs.parse(c, ns, false) }) == nil {
+			s.Selectors = append(s.Selectors, cs)
+		}
+
+		c.Score(d)
+
+		if !c.Accept(TokenComma) {
+			break
+		}
+	}
+
+	s.Tokens = c.ToTokens()
+}
+
+// Combinator represents the relationship between two compound selectors.
+type Combinator uint8
+
+// Combinators.
+const (
+	CombinatorNone Combinator = iota
+	CombinatorDescendant
+	CombinatorChild
+	CombinatorNextSibling
+	CombinatorSubsequentSibling
+	CombinatorColumn
+)
+
+// ComplexSelector represents a sequence of compound selectors separated by
+// combinators.
+//
+// The Combinator of each CompoundSelector specifies its relationship with the
+// preceding CompoundSelector. For relative selectors, such as those within
+// :has() or nested style rules, the first CompoundSelector may also have a
+// Combinator, which relates it to the anchor element.
+type ComplexSelector struct {
+	Compounds []CompoundSelector
+	Tokens    Tokens
+}
+
+func (cs *ComplexSelector) parse(c *cssParser, ns map[string]string, relative bool) error {
+	for {
+		var combinator Combinator
+
+		d := c.NewGoal()
+
+		if len(cs.Compounds) > 0 || relative {
+			d.AcceptRunWhitespace()
+
+			hadSpace := len(d) > 0
+
+			if cmb, ok := d.acceptCombinator(); ok {
+				combinator = cmb
+
+				d.AcceptRunWhitespace()
+			} else if len(cs.Compounds) == 0 {
+				d = c.NewGoal()
+			} else if !hadSpace || !canStartCompound(d.Peek()) {
+				break
+			} else {
+				combinator = CombinatorDescendant
+			}
+		}
+
+		e := d.NewGoal()
+		compound := CompoundSelector{Combinator: combinator}
+
+		if err := compound.parse(&e, ns); err != nil {
+			return d.Error("ComplexSelector", err)
+		}
+
+		d.Score(e)
+		c.Score(d)
+
+		cs.Compounds = append(cs.Compounds, compound)
+	}
+
+	cs.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// Anchored returns true if the selector explicitly references its anchor
+// element, with either a nesting selector ('&') or ':scope', anywhere within
+// it, including within the selector arguments of pseudo-classes.
+func (cs *ComplexSelector) Anchored() bool {
+	for _, compound := range cs.Compounds {
+		for _, ss := range compound.Selectors {
+			if ss.Nesting != nil || ss.Scope != nil {
+				return true
+			}
+
+			for _, p := range [...]*PseudoSelector{ss.PseudoClass, ss.PseudoElement} {
+				if p == nil || p.Selectors == nil {
+					continue
+				}
+
+				for n := range p.Selectors.Selectors {
+					if p.Selectors.Selectors[n].Anchored() {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *cssParser) acceptCombinator() (Combinator, bool) {
+	if c.AcceptToken(parser.Token{Type: TokenDelim, Data: ">"}) {
+		return CombinatorChild, true
+	} else if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "+"}) {
+		return CombinatorNextSibling, true
+	} else if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "~"}) {
+		return CombinatorSubsequentSibling, true
+	}
+
+	d := c.NewGoal()
+
+	if d.AcceptToken(parser.Token{Type: TokenDelim, Data: "|"}) && d.AcceptToken(parser.Token{Type: TokenDelim, Data: "|"}) {
+		c.Score(d)
+
+		return CombinatorColumn, true
+	}
+
+	return CombinatorNone, false
+}
+
+func canStartCompound(tk parser.Token) bool {
+	switch tk.Type {
+	case TokenIdent, TokenHash, TokenColon, TokenOpenBracket:
+		return true
+	case TokenDelim:
+		switch tk.Data {
+		case "*", ".", "|", "&":
+			return true
+		}
+	}
+
+	return false
+}
+
+// CompoundSelector represents an optional type selector followed by a
+// sequence of simple selectors, with no combinators between them.
+type CompoundSelector struct {
+	Combinator Combinator
+	Type       *WQName
+	Selectors  []SimpleSelector
+	Tokens     Tokens
+}
+
+func (cs *CompoundSelector) parse(c *cssParser, ns map[string]string) error {
+	d := c.NewGoal()
+
+	var name WQName
+
+	if err := name.parse(&d, ns, true); err == nil {
+		cs.Type = &name
+
+		c.Score(d)
+	} else if errors.Is(err, ErrUndeclaredNamespace) {
+		return c.Error("CompoundSelector", err)
+	}
+
+	for canStartSimpleSelector(c.Peek()) {
+		d := c.NewGoal()
+
+		var ss SimpleSelector
+
+		if err := ss.parse(&d, ns); err != nil {
+			return c.Error("CompoundSelector", err)
+		}
+
+		cs.Selectors = append(cs.Selectors, ss)
+
+		c.Score(d)
+	}
+
+	if cs.Type == nil && len(cs.Selectors) == 0 {
+		return c.Error("CompoundSelector", ErrInvalidSelector)
+	}
+
+	cs.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func canStartSimpleSelector(tk parser.Token) bool {
+	switch tk.Type {
+	case TokenHash, TokenColon, TokenOpenBracket:
+		return true
+	case TokenDelim:
+		return tk.Data == "." || tk.Data == "&"
+	}
+
+	return false
+}
+
+// WQName represents a possibly namespace qualified name, as used in type and
+// attribute selectors.
+//
+// When HasNamespace is true, a namespace prefix was specified, and a nil
+// Namespace indicates that the name is in no namespace; a Namespace of '*'
+// indicates any namespace. The Name may be '*' for type selectors.
+type WQName struct {
+	HasNamespace bool
+	Namespace    *Token
+	Name         *Token
+	Tokens       Tokens
+}
+
+func (w *WQName) parse(c *cssParser, ns map[string]string, allowStar bool) error {
+	star := parser.Token{Type: TokenDelim, Data: "*"}
+	pipe := parser.Token{Type: TokenDelim, Data: "|"}
+
+	if c.Accept(TokenIdent) || c.AcceptToken(star) {
+		first := c.GetLastToken()
+		d := c.NewGoal()
+
+		if d.AcceptToken(pipe) && (d.Accept(TokenIdent) || allowStar && d.AcceptToken(star)) {
+			w.HasNamespace = true
+			w.Namespace = first
+			w.Name = d.GetLastToken()
+
+			c.Score(d)
+		} else if first.Type == TokenDelim && !allowStar {
+			return c.Error("WQName", ErrInvalidSelector)
+		} else {
+			w.Name = first
+		}
+	} else if c.AcceptToken(pipe) {
+		if !c.Accept(TokenIdent) && !(allowStar && c.AcceptToken(star)) {
+			return c.Error("WQName", ErrInvalidSelector)
+		}
+
+		w.HasNamespace = true
+		w.Name = c.GetLastToken()
+	} else {
+		return c.Error("WQName", ErrInvalidSelector)
+	}
+
+	// A nil ns skips the check, leaving it to a later pass with the declared
+	// namespaces, as with the prelude of an @scope rule.
+	if ns != nil && w.Namespace != nil && w.Namespace.Type == TokenIdent {
+		if _, ok := ns[w.Namespace.Data]; !ok {
+			return Error{
+				Err:     ErrUndeclaredNamespace,
+				Parsing: "WQName",
+				Token:   *w.Namespace,
+			}
+		}
+	}
+
+	w.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// NamespaceURI returns the namespace URI that the name belongs to, using the
+// given namespace declarations, such as Sheet.Namespaces.
+//
+// The any return will be true when the name matches elements in any namespace.
+func (w *WQName) NamespaceURI(ns map[string]string) (uri string, any bool) {
+	if !w.HasNamespace {
+		uri, ok := ns[""]
+
+		return uri, !ok
+	} else if w.Namespace == nil {
+		return "", false
+	} else if w.Namespace.Type == TokenDelim {
+		return "", true
+	}
+
+	return ns[w.Namespace.Data], false
+}
+
+// SimpleSelector represents a single simple selector within a
+// CompoundSelector.
+//
+// Only one of ID, Class, Attribute, PseudoClass, PseudoElement, Nesting, or
+// Scope will be set, with Scope representing the ':scope' pseudo-class.
+type SimpleSelector struct {
+	ID            *Token
+	Class         *Token
+	Attribute     *AttributeSelector
+	PseudoClass   *PseudoSelector
+	PseudoElement *PseudoSelector
+	Nesting       *Token
+	Scope         *Token
+	Tokens        Tokens
+}
+
+func (s *SimpleSelector) parse(c *cssParser, ns map[string]string) error {
+	if c.Accept(TokenHash) {
+		s.ID = c.GetLastToken()
+	} else if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "."}) {
+		if !c.Accept(TokenIdent) {
+			return c.Error("SimpleSelector", ErrInvalidSelector)
+		}
+
+		s.Class = c.GetLastToken()
+	} else if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "&"}) {
+		s.Nesting = c.GetLastToken()
+	} else if c.Peek().Type == TokenOpenBracket {
+		d := c.NewGoal()
+		s.Attribute = new(AttributeSelector)
+
+		if err := s.Attribute.parse(&d, ns); err != nil {
+			return c.Error("SimpleSelector", err)
+		}
+
+		c.Score(d)
+	} else if c.Accept(TokenColon) {
+		element := c.Accept(TokenColon)
+		d := c.NewGoal()
+		pseudo := new(PseudoSelector)
+
+		if err := pseudo.parse(&d, ns); err != nil {
+			return c.Error("SimpleSelector", err)
+		}
+
+		c.Score(d)
+
+		if element {
+			s.PseudoElement = pseudo
+		} else if pseudo.Name == "scope" && !pseudo.Function {
+			s.Scope = c.GetLastToken()
+		} else {
+			s.PseudoClass = pseudo
+		}
+	} else {
+		return c.Error("SimpleSelector", ErrInvalidSelector)
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// AttributeSelector represents an attribute selector, such as '[href]' or
+// '[svg|href^="#" i]'.
+//
+// The Matcher is empty for a presence test, otherwise it is one of '=', '~=',
+// '|=', '^=', '$=', or '*='.
+type AttributeSelector struct {
+	Name     WQName
+	Matcher  string
+	Value    *Token
+	Modifier string
+	Tokens   Tokens
+}
+
+func (a *AttributeSelector) parse(c *cssParser, ns map[string]string) error {
+	c.Accept(TokenOpenBracket)
+	c.AcceptRunWhitespace()
+
+	d := c.NewGoal()
+
+	if err := a.Name.parse(&d, ns, false); err != nil {
+		return c.Error("AttributeSelector", err)
+	}
+
+	c.Score(d)
+	c.AcceptRunWhitespace()
+
+	if !c.Accept(TokenCloseBracket) {
+		if c.AcceptToken(parser.Token{Type: TokenDelim, Data: "="}) {
+			a.Matcher = "="
+		} else if c.Accept(TokenDelim) {
+			if m := c.GetLastToken().Data; strings.Contains("~|^$*", m) && c.AcceptToken(parser.Token{Type: TokenDelim, Data: "="}) {
+				a.Matcher = m + "="
+			} else {
+				return c.Error("AttributeSelector", ErrInvalidAttributeSelector)
+			}
+		} else {
+			return c.Error("AttributeSelector", ErrInvalidAttributeSelector)
+		}
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenIdent, TokenString) {
+			return c.Error("AttributeSelector", ErrInvalidAttributeSelector)
+		}
+
+		a.Value = c.GetLastToken()
+
+		c.AcceptRunWhitespace()
+
+		if c.AcceptIdent("i") || c.AcceptIdent("s") {
+			a.Modifier = strings.ToLower(c.GetLastToken().Data)
+
+			c.AcceptRunWhitespace()
+		}
+
+		if !c.Accept(TokenCloseBracket) {
+			return c.Error("AttributeSelector", ErrInvalidAttributeSelector)
+		}
+	}
+
+	a.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// PseudoSelector represents a pseudo-class or pseudo-element, such as ':hover',
+// ':nth-child(2n+1)', or '::before'.
+//
+// The Name is lowercased. For functional pseudo-classes that take a selector
+// list, such as ':is()', ':not()', and ':has()', the parsed list is stored in
+// Selectors; otherwise, the Arguments contain the raw function arguments.
+type PseudoSelector struct {
+	Name      string
+	Function  bool
+	Arguments Tokens
+	Selectors *SelectorList
+	Tokens    Tokens
+}
+
+func (p *PseudoSelector) parse(c *cssParser, ns map[string]string) error {
+	if c.Accept(TokenIdent) {
+		p.Name = strings.ToLower(c.GetLastToken().Data)
+	} else if c.Accept(TokenFunction) {
+		data := c.GetLastToken().Data
+		p.Name = strings.ToLower(data[:len(data)-1])
+		p.Function = true
+		p.Arguments = acceptFunctionArguments(c)
+
+		switch p.Name {
+		case "is", "where":
+			d := newCSSParserFromTokens(p.Arguments)
+			p.Selectors = new(SelectorList)
+
+			d.AcceptRunWhitespace()
+			p.Selectors.parseForgiving(&d, ns)
+		case "not", "matches", "-webkit-any", "has", "host", "host-context", "slotted", "cue":
+			d := newCSSParserFromTokens(p.Arguments)
+			p.Selectors = new(SelectorList)
+
+			if err := d.parseSelectorList(p.Selectors, ns, p.Name == "has"); err != nil {
+				return c.Error("PseudoSelector", err)
+			}
+		}
+	} else {
+		return c.Error("PseudoSelector", ErrInvalidSelector)
+	}
+
+	p.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func (c *cssParser) parseSelectorList(s *SelectorList, ns map[string]string, relative bool) error {
+	return parseTrimmed(c, func(c *cssParser) error {
+		return s.parse(c, ns, relative)
+	})
+}
+
+func (r *Rule) parseSelectors(ns map[string]string, nested bool) {
+	if q := r.QualifiedRule; q != nil {
+		c := newCSSParserFromTokens(q.Prelude)
+		q.Selectors = new(SelectorList)
+
+		if err := c.parseSelectorList(q.Selectors, ns, nested); err != nil {
+			q.Selectors = nil
+			q.Err = err
+
+			return
+		}
+
+		q.Block.parseSelectors(ns, true)
+	} else if a := r.AtRule; a != nil && a.Block != nil && a.Err == nil && a.Keyframes == nil {
+		switch a.BlockKind() {
+		case BlockKindRules:
+			a.Block.parseSelectors(ns, nested)
+		case BlockKindMixed:
+			if a.Scope != nil {
+				if err := a.Scope.resolveNamespaces(ns); err != nil {
+					a.Scope = nil
+					a.Err = err
+
+					return
+				}
+			}
+
+			a.Block.parseSelectors(ns, true)
+		}
+	}
+}
+
+func (b *Block) parseSelectors(ns map[string]string, nested bool) {
+	for _, r := range b.Rules() {
+		r.parseSelectors(ns, nested)
+	}
+}
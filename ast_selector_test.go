@@ -0,0 +1,226 @@
+package css
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func describeSelectors(s *SelectorList) string {
+	var sb strings.Builder
+
+	for n, cs := range s.Selectors {
+		if n > 0 {
+			sb.WriteString(", ")
+		}
+
+		for m, compound := range cs.Compounds {
+			combinator := [...]string{"", " ", " > ", " + ", " ~ ", " || "}[compound.Combinator]
+
+			if m == 0 {
+				combinator = strings.TrimPrefix(combinator, " ")
+			}
+
+			sb.WriteString(combinator)
+
+			if w := compound.Type; w != nil {
+				if w.HasNamespace {
+					if w.Namespace != nil {
+						sb.WriteString(w.Namespace.Data)
+					}
+
+					sb.WriteString("|")
+				}
+
+				sb.WriteString(w.Name.Data)
+			}
+
+			for _, ss := range compound.Selectors {
+				switch {
+				case ss.ID != nil:
+					sb.WriteString(ss.ID.Data)
+				case ss.Class != nil:
+					sb.WriteString("." + ss.Class.Data)
+				case ss.Nesting != nil:
+					sb.WriteString("&")
+				case ss.Scope != nil:
+					sb.WriteString(":scope")
+				case ss.Attribute != nil:
+					sb.WriteString("[")
+
+					if ss.Attribute.Name.Namespace != nil {
+						sb.WriteString(ss.Attribute.Name.Namespace.Data + "|")
+					}
+
+					sb.WriteString(ss.Attribute.Name.Name.Data + ss.Attribute.Matcher)
+
+					if ss.Attribute.Value != nil {
+						sb.WriteString(ss.Attribute.Value.Data)
+					}
+
+					if ss.Attribute.Modifier != "" {
+						sb.WriteString(" " + ss.Attribute.Modifier)
+					}
+
+					sb.WriteString("]")
+				case ss.PseudoClass != nil, ss.PseudoElement != nil:
+					p := ss.PseudoClass
+
+					if p == nil {
+						p = ss.PseudoElement
+
+						sb.WriteString(":")
+					}
+
+					sb.WriteString(":" + p.Name)
+
+					if p.Selectors != nil {
+						sb.WriteString("(" + describeSelectors(p.Selectors) + ")")
+					} else if p.Function {
+						sb.WriteString("(" + p.Arguments.String() + ")")
+					}
+				}
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func TestSelectors(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output string
+		Err    error
+	}{
+		{ // 1
+			Input:  "a, .b#c , div  >  p {}",
+			Output: "a, .b#c, div > p",
+		},
+		{ // 2
+			Input:  "ul li + li ~ *::before {}",
+			Output: "ul li + li ~ *::before",
+		},
+		{ // 3
+			Input:  "col.selected || td {}",
+			Output: "col.selected || td",
+		},
+		{ // 4
+			Input:  "a[href^=\"http\" i][target] {}",
+			Output: "a[href^=\"http\" i][target]",
+		},
+		{ // 5
+			Input:  "[lang|=en] {}",
+			Output: "[lang|=en]",
+		},
+		{ // 6
+			Input:  "a:not(.b, c):nth-child(2n+1):HOVER {}",
+			Output: "a:not(.b, c):nth-child(2n+1):hover",
+		},
+		{ // 7
+			Input:  "section:has(> h1, + p) {}",
+			Output: "section:has(> h1, + p)",
+		},
+		{ // 8
+			Input:  ".a { &:hover {} > .b {} .c & {} }",
+			Output: ".a",
+		},
+		{ // 9
+			Input: "a > {}",
+			Err:   ErrInvalidSelector,
+		},
+		{ // 10
+			Input: "a[href=] {}",
+			Err:   ErrInvalidAttributeSelector,
+		},
+		{ // 11
+			Input: "a:not(>b) {}",
+			Err:   ErrInvalidSelector,
+		},
+		{ // 12
+			Input: ".a { b ! {} }",
+			Err:   ErrUnexpectedToken,
+		},
+		{ // 13
+			Input:  "a:is(>b) {}",
+			Output: "a:is()",
+		},
+		{ // 14
+			Input:  ":is() {}",
+			Output: ":is()",
+		},
+		{ // 15
+			Input:  ":where(.a, :bogus(()), b c, [x=]) {}",
+			Output: ":where(.a, :bogus(()), b c)",
+		},
+		{ // 16
+			Input: "*html {}",
+			Err:   ErrUnexpectedToken,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		if out := describeSelectors(s.Rules[0].QualifiedRule.Selectors); out != test.Output {
+			t.Errorf("test %d: expecting selectors %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
+
+func TestInvalidSelectorRule(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser("a > { b {} } *html {} c {}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if len(s.Rules) != 3 {
+		t.Fatalf("expecting 3 rules, got %d", len(s.Rules))
+	}
+
+	for n, r := range s.Rules[:2] {
+		if q := r.QualifiedRule; q.Selectors != nil || q.Err == nil {
+			t.Errorf("rule %d: expecting selector error", n+1)
+		}
+	}
+
+	if q := s.Rules[0].QualifiedRule.Block.Rules()[0].QualifiedRule; q.Selectors != nil {
+		t.Errorf("expecting nested rule of invalid rule to be ignored")
+	} else if out := describeSelectors(s.Rules[2].QualifiedRule.Selectors); out != "c" {
+		t.Errorf("expecting selectors %q, got %q", "c", out)
+	}
+}
+
+func TestNestedSelectors(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(".a { &:hover {} > .b {} @media screen { .c & {} } }"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got []string
+
+	for _, r := range s.Rules[0].QualifiedRule.Block.Rules() {
+		if r.QualifiedRule != nil {
+			got = append(got, describeSelectors(r.QualifiedRule.Selectors))
+		} else {
+			got = append(got, describeSelectors(r.AtRule.Block.Rules()[0].QualifiedRule.Selectors))
+		}
+	}
+
+	if expected := "&:hover; > .b; .c &"; strings.Join(got, "; ") != expected {
+		t.Errorf("expecting nested selectors %q, got %q", expected, strings.Join(got, "; "))
+	}
+}
@@ -1,7 +1,12 @@
 package css
 
-import "vimagination.zapto.org/parser"
+import (
+	"strings"
 
+	"vimagination.zapto.org/parser"
+)
+
+// ParseSheet parses CSS input into a Sheet.
 func ParseSheet(t parser.Tokeniser) (*Sheet, error) {
 	c, err := newCSSParser(CreateTokeniser(t, true))
 	if err != nil {
@@ -16,20 +21,48 @@ func ParseSheet(t parser.Tokeniser) (*Sheet, error) {
 	return s, nil
 }
 
+// Sheet represents a CSS stylesheet.
+//
+// Namespaces maps the prefixes declared by @namespace rules to their namespace
+// URIs, with the default namespace, if declared, stored with an empty prefix.
 type Sheet struct {
-	Rules  []Rule
-	Tokens Tokens
+	Rules      []Rule
+	Namespaces map[string]string
+	Tokens     Tokens
 }
 
 func (s *Sheet) parse(c *cssParser) error {
+	importsAllowed := true
+	ns := make(map[string]string)
+
 	for c.AcceptRunWhitespace() != parser.TokenDone {
 		d := c.NewGoal()
 		var r Rule
 
-		if err := r.parse(&d); err != nil {
+		if err := r.parse(&d, false); err != nil {
 			return c.Error("Sheet", err)
 		}
 
+		if r.AtRule != nil && r.AtRule.Import != nil {
+			if !importsAllowed {
+				r.AtRule.Import = nil
+				r.AtRule.Err = Error{
+					Err:     ErrInvalidImportPosition,
+					Parsing: "Sheet",
+					Token:   r.Tokens[0],
+				}
+			}
+		} else if !r.allowedBeforeImport() {
+			importsAllowed = false
+		}
+
+		if r.AtRule != nil && r.AtRule.Namespace != nil {
+			ns[r.AtRule.Namespace.Prefix] = r.AtRule.Namespace.URI
+			s.Namespaces = ns
+		}
+
+		r.parseSelectors(ns, false)
+
 		s.Rules = append(s.Rules, r)
 
 		c.Score(d)
@@ -40,6 +73,9 @@ func (s *Sheet) parse(c *cssParser) error {
 	return nil
 }
 
+// Rule represents a top-level item in a Sheet or a nested rule in a Block.
+//
+// Only one of CommentDelimiter, AtRule, or QualifiedRule will be set.
 type Rule struct {
 	CommentDelimiter *Token
 	AtRule           *AtRule
@@ -47,14 +83,14 @@ type Rule struct {
 	Tokens           Tokens
 }
 
-func (r *Rule) parse(c *cssParser) error {
+func (r *Rule) parse(c *cssParser, nested bool) error {
 	if c.Accept(TokenCDO, TokenCDC) {
 		r.CommentDelimiter = c.GetLastToken()
 	} else if tk := c.Peek(); tk.Type == TokenAtKeyword {
 		d := c.NewGoal()
 		r.AtRule = new(AtRule)
 
-		if err := r.AtRule.parse(&d); err != nil {
+		if err := r.AtRule.parse(&d, nested); err != nil {
 			return c.Error("Rule", err)
 		}
 
@@ -63,7 +99,7 @@ func (r *Rule) parse(c *cssParser) error {
 		d := c.NewGoal()
 		r.QualifiedRule = new(QualifiedRule)
 
-		if err := r.QualifiedRule.parse(&d); err != nil {
+		if err := r.QualifiedRule.parse(&d, nested); err != nil {
 			return c.Error("Rule", err)
 		}
 
@@ -75,14 +111,582 @@ func (r *Rule) parse(c *cssParser) error {
 	return nil
 }
 
-type AtRule struct{}
+func (r *Rule) block() *Block {
+	if r.QualifiedRule != nil {
+		return &r.QualifiedRule.Block
+	} else if r.AtRule != nil {
+		return r.AtRule.Block
+	}
+
+	return nil
+}
+
+func (r *Rule) walk(fn func(*Rule)) {
+	fn(r)
+
+	if b := r.block(); b != nil {
+		for _, nr := range b.Rules() {
+			nr.walk(fn)
+		}
+	}
+}
+
+func (s *Sheet) walkRules(fn func(*Rule)) {
+	for n := range s.Rules {
+		s.Rules[n].walk(fn)
+	}
+}
+
+func (r *Rule) allowedBeforeImport() bool {
+	if r.CommentDelimiter != nil {
+		return true
+	} else if r.AtRule == nil || r.AtRule.Block != nil {
+		return false
+	}
+
+	switch r.AtRule.Name() {
+	case "charset", "layer":
+		return true
+	}
+
+	return false
+}
+
+// AtRule represents an at-rule, such as @media or @import.
+//
+// The Prelude contains all of the tokens between the AtKeyword and either the
+// terminating semi-colon or the Block. For recognised at-rules, the Prelude
+// (and, where appropriate, the Block) is additionally parsed into one of the
+// typed fields; for at-rules added with RegisterAtRule, the result of parsing
+// the Prelude is stored in Custom.
+//
+// A recognised at-rule whose prelude or descriptors make it invalid, such as an
+// @font-face without a src, is kept with its typed field left nil, and the
+// reason recorded in Err; a browser would ignore such a rule. The same applies
+// to an at-rule with a Block where none is allowed.
+type AtRule struct {
+	AtKeyword         *Token
+	Prelude           Tokens
+	Block             *Block
+	Supports          *SupportsCondition
+	Import            *ImportRule
+	FontFace          *FontFace
+	Keyframes         *Keyframes
+	Layer             *LayerRule
+	Container         *ContainerRule
+	Page              *PageRule
+	Namespace         *NamespaceRule
+	Property          *PropertyRule
+	CounterStyle      *CounterStyle
+	Scope             *ScopeRule
+	ViewTransition    *ViewTransition
+	PositionTry       *PositionTry
+	FontPaletteValues *FontPaletteValues
+	Custom            any
+	Err               error
+	Tokens            Tokens
+}
+
+func (a *AtRule) parse(c *cssParser, nested bool) error {
+	c.Accept(TokenAtKeyword)
+
+	a.AtKeyword = c.GetLastToken()
+	d := c.NewGoal()
+
+Loop:
+	for {
+		switch d.Peek().Type {
+		case TokenSemiColon, TokenCloseBrace, parser.TokenDone:
+			a.Prelude = d.ToTokens()
+
+			c.Score(d)
+			c.Accept(TokenSemiColon)
+
+			break Loop
+		case TokenOpenBrace:
+			a.Prelude = d.ToTokens()
+
+			c.Score(d)
+
+			kind := a.BlockKind()
+
+			if kind == BlockKindNone {
+				a.Err = c.Error("AtRule", ErrUnexpectedBlock)
+				kind = BlockKindMixed
+			} else if kind == BlockKindRules && nested {
+				kind = BlockKindMixed
+			}
+
+			d = c.NewGoal()
+			a.Block = new(Block)
+
+			if err := a.Block.parse(&d, kind, nested); err != nil {
+				return c.Error("AtRule", err)
+			}
+
+			c.Score(d)
+
+			break Loop
+		default:
+			d.AcceptComponentValue()
+		}
+	}
+
+	if a.Err == nil {
+		if err := a.parseTyped(); err != nil {
+			*a = AtRule{
+				AtKeyword: a.AtKeyword,
+				Prelude:   a.Prelude,
+				Block:     a.Block,
+				Err:       err,
+			}
+		}
+	}
+
+	a.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// Name returns the lowercased name of the at-rule, without the leading '@'.
+func (a *AtRule) Name() string {
+	return strings.ToLower(strings.TrimPrefix(a.AtKeyword.Data, "@"))
+}
+
+func (a *AtRule) parseTyped() error {
+	p := newCSSParserFromTokens(a.Prelude)
+
+	switch a.Name() {
+	case "supports":
+		a.Supports = new(SupportsCondition)
+
+		return parseTrimmed(&p, a.Supports.parse)
+	case "import":
+		a.Import = new(ImportRule)
+
+		return parseTrimmed(&p, a.Import.parse)
+	case "font-face":
+		if len(a.Prelude.Trim()) > 0 {
+			return ErrUnexpectedPrelude
+		} else if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.FontFace = new(FontFace)
+
+		return a.FontFace.parse(a.Block)
+	case "keyframes", "-webkit-keyframes", "-moz-keyframes", "-o-keyframes":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.Keyframes = new(Keyframes)
+
+		if err := parseTrimmed(&p, a.Keyframes.parse); err != nil {
+			return err
+		}
+
+		a.Keyframes.parseBlock(a.Block)
+	case "layer":
+		a.Layer = new(LayerRule)
+
+		if err := parseTrimmed(&p, a.Layer.parse); err != nil {
+			return err
+		} else if a.Block == nil && len(a.Layer.Names) == 0 {
+			return ErrInvalidLayerName
+		} else if a.Block != nil && len(a.Layer.Names) > 1 {
+			return ErrTooManyLayerNames
+		}
+	case "container":
+		a.Container = new(ContainerRule)
+
+		return parseTrimmed(&p, a.Container.parse)
+	case "page":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.Page = new(PageRule)
+
+		if err := parseTrimmed(&p, a.Page.parse); err != nil {
+			return err
+		}
+
+		a.Page.parseBlock(a.Block)
+	case "namespace":
+		a.Namespace = new(NamespaceRule)
+
+		return parseTrimmed(&p, a.Namespace.parse)
+	case "property":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.Property = new(PropertyRule)
+
+		if err := parseTrimmed(&p, a.Property.parse); err != nil {
+			return err
+		}
+
+		return a.Property.parseBlock(a.Block)
+	case "counter-style":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.CounterStyle = new(CounterStyle)
+
+		if err := parseTrimmed(&p, a.CounterStyle.parse); err != nil {
+			return err
+		}
+
+		return a.CounterStyle.parseBlock(a.Block)
+	case "scope":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.Scope = new(ScopeRule)
+
+		return parseTrimmed(&p, a.Scope.parse)
+	case "starting-style":
+		if len(a.Prelude.Trim()) > 0 {
+			return ErrUnexpectedPrelude
+		} else if a.Block == nil {
+			return ErrMissingBlock
+		}
+	case "view-transition":
+		if len(a.Prelude.Trim()) > 0 {
+			return ErrUnexpectedPrelude
+		} else if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.ViewTransition = new(ViewTransition)
+
+		a.ViewTransition.parse(a.Block)
+	case "position-try":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.PositionTry = new(PositionTry)
+
+		if err := parseTrimmed(&p, a.PositionTry.parse); err != nil {
+			return err
+		}
+
+		a.PositionTry.Declarations = a.Block.Declarations()
+	case "font-palette-values":
+		if a.Block == nil {
+			return ErrMissingBlock
+		}
+
+		a.FontPaletteValues = new(FontPaletteValues)
+
+		if err := parseTrimmed(&p, a.FontPaletteValues.parse); err != nil {
+			return err
+		}
+
+		return a.FontPaletteValues.parseBlock(a.Block)
+	default:
+		if def, ok := registeredAtRule(a.Name()); ok {
+			return a.parseRegistered(def)
+		}
+	}
+
+	return nil
+}
+
+func parseTrimmed(c *cssParser, fn func(*cssParser) error) error {
+	c.AcceptRunWhitespace()
+
+	d := c.NewGoal()
+
+	if err := fn(&d); err != nil {
+		return err
+	}
+
+	c.Score(d)
+
+	if c.AcceptRunWhitespace() != parser.TokenDone {
+		return c.Error("Prelude", ErrUnexpectedToken)
+	}
+
+	return nil
+}
+
+// QualifiedRule represents a style rule, consisting of a Prelude, usually a
+// list of selectors, and a Block.
+//
+// For style rules, the Prelude is additionally parsed into Selectors. When the
+// Prelude is not a valid selector list, Selectors is left nil and the reason
+// recorded in Err; a browser would ignore such a rule.
+type QualifiedRule struct {
+	Prelude   Tokens
+	Selectors *SelectorList
+	Block     Block
+	Err       error
+	Tokens    Tokens
+}
+
+func (q *QualifiedRule) parse(c *cssParser, nested bool) error {
+	for {
+		switch c.Peek().Type {
+		case TokenSemiColon:
+			if nested {
+				return c.Error("QualifiedRule", ErrMissingBlock)
+			}
+
+			c.Skip()
+		case TokenOpenBrace:
+			q.Prelude = c.ToTokens()
+			d := c.NewGoal()
+
+			if err := q.Block.parse(&d, BlockKindMixed, true); err != nil {
+				return c.Error("QualifiedRule", err)
+			}
+
+			c.Score(d)
+
+			q.Tokens = c.ToTokens()
+
+			return nil
+		case TokenCloseBrace, parser.TokenDone:
+			return c.Error("QualifiedRule", ErrMissingBlock)
+		default:
+			c.AcceptComponentValue()
+		}
+	}
+}
+
+// Block represents the contents of a curly brace block, which can contain both
+// Declarations and nested Rules.
+//
+// Items that cannot be parsed, such as a declaration without a colon, are
+// dropped, with the reasons recorded in Errors.
+type Block struct {
+	Items  []BlockItem
+	Errors []error
+	Tokens Tokens
+}
+
+// BlockKind specifies what a Block may contain.
+type BlockKind uint8
+
+// Block kinds.
+const (
+	BlockKindMixed        BlockKind = iota // Declarations and Rules
+	BlockKindDeclarations                  // Declarations and at-rules
+	BlockKindRules                         // Rules only
+	BlockKindNone                          // No Block allowed
+)
+
+func (b *Block) parse(c *cssParser, kind BlockKind, nested bool) error {
+	c.Accept(TokenOpenBrace)
+
+	for {
+		switch c.AcceptRunWhitespace() {
+		case TokenCloseBrace:
+			c.Skip()
+
+			b.Tokens = c.ToTokens()
+
+			return nil
+		case TokenSemiColon:
+			c.Skip()
+
+			continue
+		case parser.TokenDone:
+			return c.Error("Block", ErrMissingCloseBrace)
+		}
+
+		d := c.NewGoal()
+
+		var bi BlockItem
+
+		if err := bi.parse(&d, kind, nested); err != nil {
+			b.Errors = append(b.Errors, err)
+
+			c.skipBlockItem()
+
+			continue
+		}
+
+		b.Items = append(b.Items, bi)
+
+		c.Score(d)
+	}
+}
+
+func (c *cssParser) skipBlockItem() {
+	for {
+		switch c.Peek().Type {
+		case TokenSemiColon:
+			c.Skip()
+
+			return
+		case TokenOpenBrace:
+			c.AcceptComponentValue()
+
+			return
+		case TokenCloseBrace, parser.TokenDone:
+			return
+		}
+
+		c.AcceptComponentValue()
+	}
+}
+
+// Declarations returns all of the Declarations in the Block.
+func (b *Block) Declarations() []*Declaration {
+	var ds []*Declaration
+
+	for _, bi := range b.Items {
+		if bi.Declaration != nil {
+			ds = append(ds, bi.Declaration)
+		}
+	}
+
+	return ds
+}
+
+// Rules returns all of the nested Rules in the Block.
+func (b *Block) Rules() []*Rule {
+	var rs []*Rule
+
+	for _, bi := range b.Items {
+		if bi.Rule != nil {
+			rs = append(rs, bi.Rule)
+		}
+	}
+
+	return rs
+}
+
+// BlockItem represents a single item in a Block.
+//
+// Only one of Declaration or Rule will be set.
+type BlockItem struct {
+	Declaration *Declaration
+	Rule        *Rule
+	Tokens      Tokens
+}
+
+func (b *BlockItem) parse(c *cssParser, kind BlockKind, nested bool) error {
+	if kind == BlockKindDeclarations && c.Peek().Type != TokenAtKeyword || kind == BlockKindMixed && isDeclaration(*c) {
+		d := c.NewGoal()
+		b.Declaration = new(Declaration)
+
+		if err := b.Declaration.parse(&d); err != nil {
+			return c.Error("BlockItem", err)
+		}
+
+		c.Score(d)
+	} else {
+		d := c.NewGoal()
+		b.Rule = new(Rule)
+
+		if err := b.Rule.parse(&d, nested); err != nil {
+			return c.Error("BlockItem", err)
+		}
+
+		c.Score(d)
+	}
+
+	b.Tokens = c.ToTokens()
 
-func (a *AtRule) parse(c *cssParser) error {
 	return nil
 }
 
-type QualifiedRule struct{}
+func isDeclaration(c cssParser) bool {
+	if !c.Accept(TokenIdent) {
+		return false
+	}
+
+	custom := strings.HasPrefix(c.GetLastToken().Data, "--")
+
+	c.AcceptRunWhitespace()
+
+	if !c.Accept(TokenColon) {
+		return false
+	} else if custom {
+		return true
+	}
+
+	for {
+		switch c.Peek().Type {
+		case TokenOpenBrace:
+			return false
+		case TokenSemiColon, TokenCloseBrace, parser.TokenDone:
+			return true
+		}
+
+		c.AcceptComponentValue()
+	}
+}
+
+// Declaration represents a property declaration, such as 'color: red'.
+//
+// The Value does not include surrounding whitespace or the '!important' flag.
+type Declaration struct {
+	Name      *Token
+	Value     Tokens
+	Important bool
+	Tokens    Tokens
+}
+
+func (d *Declaration) parse(c *cssParser) error {
+	if !c.Accept(TokenIdent) {
+		return c.Error("Declaration", ErrMissingName)
+	}
+
+	d.Name = c.GetLastToken()
+
+	c.AcceptRunWhitespace()
+
+	if !c.Accept(TokenColon) {
+		return c.Error("Declaration", ErrMissingColon)
+	}
+
+	c.AcceptRunWhitespace()
+
+	e := c.NewGoal()
+
+Loop:
+	for {
+		switch e.Peek().Type {
+		case TokenSemiColon, TokenCloseBrace, TokenCloseParen, parser.TokenDone:
+			break Loop
+		}
+
+		e.AcceptComponentValue()
+	}
+
+	d.Value = e.ToTokens().Trim()
+
+	if l := len(d.Value); l > 1 && d.Value[l-1].Type == TokenIdent && strings.EqualFold(d.Value[l-1].Data, "important") {
+		value := d.Value[:l-1].Trim()
+
+		if l := len(value); l > 0 && value[l-1].Type == TokenDelim && value[l-1].Data == "!" {
+			d.Value = value[:l-1].Trim()
+			d.Important = true
+		}
+	}
+
+	c.Score(e)
+
+	d.Tokens = c.ToTokens().Trim()
 
-func (q *QualifiedRule) parse(c *cssParser) error {
 	return nil
 }
+
+// Property returns the name of the declared property. Standard properties are
+// lowercased, custom properties are returned unchanged.
+func (d *Declaration) Property() string {
+	if strings.HasPrefix(d.Name.Data, "--") {
+		return d.Name.Data
+	}
+
+	return strings.ToLower(d.Name.Data)
+}
@@ -0,0 +1,280 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+// ruleError returns the first error recorded on a rule, or on the Block of a
+// rule, within the Sheet.
+func ruleError(s *Sheet) error {
+	var err error
+
+	s.walkRules(func(r *Rule) {
+		if err != nil {
+			return
+		} else if r.QualifiedRule != nil {
+			err = r.QualifiedRule.Err
+		} else if r.AtRule != nil {
+			err = r.AtRule.Err
+		}
+
+		if b := r.block(); err == nil && b != nil && len(b.Errors) > 0 {
+			err = b.Errors[0]
+		}
+	})
+
+	return err
+}
+
+func TestParseSheet(t *testing.T) {
+	for n, test := range [...]struct {
+		Input                     string
+		AtRules, QualifiedRules   int
+		Declarations, NestedRules int
+		Err                       bool
+	}{
+		{ // 1
+			Input: "",
+		},
+		{ // 2
+			Input:          "a { color: red }",
+			QualifiedRules: 1,
+			Declarations:   1,
+		},
+		{ // 3
+			Input:          "a { color: red; background: blue !important; }\nb{}",
+			QualifiedRules: 2,
+			Declarations:   2,
+		},
+		{ // 4
+			Input:   "@import 'a.css';\n@media screen { a { color: red } }",
+			AtRules: 2,
+		},
+		{ // 5
+			Input:          "a { color: red; &:hover { color: blue } b:focus { color: green } }",
+			QualifiedRules: 1,
+			Declarations:   1,
+			NestedRules:    2,
+		},
+		{ // 6
+			Input:          "a { --custom: { a: b }; }",
+			QualifiedRules: 1,
+			Declarations:   1,
+		},
+		{ // 7
+			Input: "a",
+			Err:   true,
+		},
+		{ // 8
+			Input: "a { color: red",
+			Err:   true,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if test.Err {
+			if err == nil {
+				t.Errorf("test %d: expecting error, got none", n+1)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		var ats, qrs, decls, nested int
+
+		for _, r := range s.Rules {
+			if r.AtRule != nil {
+				ats++
+			} else if r.QualifiedRule != nil {
+				qrs++
+				decls += len(r.QualifiedRule.Block.Declarations())
+				nested += len(r.QualifiedRule.Block.Rules())
+			}
+		}
+
+		if ats != test.AtRules {
+			t.Errorf("test %d: expecting %d at-rules, got %d", n+1, test.AtRules, ats)
+		} else if qrs != test.QualifiedRules {
+			t.Errorf("test %d: expecting %d qualified rules, got %d", n+1, test.QualifiedRules, qrs)
+		} else if decls != test.Declarations {
+			t.Errorf("test %d: expecting %d declarations, got %d", n+1, test.Declarations, decls)
+		} else if nested != test.NestedRules {
+			t.Errorf("test %d: expecting %d nested rules, got %d", n+1, test.NestedRules, nested)
+		}
+	}
+}
+
+func TestDeclaration(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser("a { COLOR : red ! important; --X: 1px 2px }"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	decls := s.Rules[0].QualifiedRule.Block.Declarations()
+
+	if len(decls) != 2 {
+		t.Fatalf("expecting 2 declarations, got %d", len(decls))
+	} else if p := decls[0].Property(); p != "color" {
+		t.Errorf("expecting property %q, got %q", "color", p)
+	} else if v := decls[0].Value.String(); v != "red" {
+		t.Errorf("expecting value %q, got %q", "red", v)
+	} else if !decls[0].Important {
+		t.Errorf("expecting important declaration")
+	} else if p := decls[1].Property(); p != "--X" {
+		t.Errorf("expecting property %q, got %q", "--X", p)
+	} else if v := decls[1].Value.String(); v != "1px 2px" {
+		t.Errorf("expecting value %q, got %q", "1px 2px", v)
+	} else if decls[1].Important {
+		t.Errorf("expecting non-important declaration")
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output string
+		Err    error
+	}{
+		{ // 1
+			Input:  "@supports foo {a{}} b{}",
+			Output: "@supports foo {\n\ta {}\n}\nb {}\n",
+			Err:    ErrInvalidSupportsCondition,
+		},
+		{ // 2
+			Input:  "a { 123 } b{}",
+			Output: "a {}\nb {}\n",
+			Err:    ErrMissingBlock,
+		},
+		{ // 3
+			Input:  "@page :foo {} b{}",
+			Output: "@page :foo {}\nb {}\n",
+			Err:    ErrInvalidPagePseudoClass,
+		},
+		{ // 4
+			Input:  "@namespace 1; b{}",
+			Output: "@namespace 1;\nb {}\n",
+			Err:    ErrMissingURL,
+		},
+		{ // 5
+			Input:  "@import 5; b{}",
+			Output: "@import 5;\nb {}\n",
+			Err:    ErrMissingURL,
+		},
+		{ // 6
+			Input:  "@keyframes 5 {} b{}",
+			Output: "@keyframes 5 {}\nb {}\n",
+			Err:    ErrInvalidKeyframesName,
+		},
+		{ // 7
+			Input:  "@layer 1 {} b{}",
+			Output: "@layer 1 {}\nb {}\n",
+			Err:    ErrInvalidLayerName,
+		},
+		{ // 8
+			Input:  "a { color: red; 1px solid; c {} } b{}",
+			Output: "a {\n\tcolor: red;\n\tc {}\n}\nb {}\n",
+			Err:    ErrMissingBlock,
+		},
+		{ // 9
+			Input:  "@namespace x \"y\" {} b{}",
+			Output: "@namespace x \"y\" {}\nb {}\n",
+			Err:    ErrUnexpectedBlock,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if err = ruleError(s); !errors.Is(err, test.Err) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+		} else if len(s.Rules) != 2 || s.Rules[1].QualifiedRule == nil {
+			t.Errorf("test %d: expecting invalid rule to be followed by a style rule, got %d rules", n+1, len(s.Rules))
+		} else if r := s.Rules[0].AtRule; r != nil && (r.Supports != nil || r.Page != nil || r.Namespace != nil || r.Import != nil || r.Keyframes != nil || r.Layer != nil) {
+			t.Errorf("test %d: expecting typed field of invalid at-rule to be nil", n+1)
+		} else if out := s.String(); out != test.Output {
+			t.Errorf("test %d: expecting output %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
+
+func TestBlockKind(t *testing.T) {
+	for n, test := range [...]struct {
+		Input               string
+		Declarations, Rules int
+		Err                 error
+	}{
+		{ // 1
+			Input: "@media screen { a:hover { color: red } }",
+			Rules: 1,
+		},
+		{ // 2
+			Input: "@media screen { color: red; }",
+			Err:   ErrMissingBlock,
+		},
+		{ // 3
+			Input:        "a { @media screen { color: red; b:c {} } }",
+			Declarations: 1,
+			Rules:        1,
+		},
+		{ // 4
+			Input: "@view-transition { navigation: auto; a {} }",
+			Err:   ErrMissingColon,
+		},
+		{ // 5
+			Input: "@starting-style { a { opacity: 0 } b { opacity: 0 } }",
+			Rules: 2,
+		},
+		{ // 6
+			Input:        "a { @starting-style { opacity: 0 } }",
+			Declarations: 1,
+		},
+		{ // 7
+			Input: "@namespace x \"y\" {}",
+			Err:   ErrUnexpectedBlock,
+		},
+		{ // 8
+			Input:        "@unknown foo { a: b; c {} }",
+			Declarations: 1,
+			Rules:        1,
+		},
+		{ // 9
+			Input:        "@position-try --a { top: anchor(bottom); @media print {} }",
+			Declarations: 1,
+			Rules:        1,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		b := s.Rules[0].block()
+
+		if r := b.Rules(); len(r) == 1 && r[0].AtRule != nil && s.Rules[0].QualifiedRule != nil {
+			b = r[0].AtRule.Block
+		}
+
+		if d := len(b.Declarations()); d != test.Declarations {
+			t.Errorf("test %d: expecting %d declarations, got %d", n+1, test.Declarations, d)
+		} else if r := len(b.Rules()); r != test.Rules {
+			t.Errorf("test %d: expecting %d rules, got %d", n+1, test.Rules, r)
+		}
+	}
+}
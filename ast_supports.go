@@ -0,0 +1,244 @@
+package css
+
+import "strings"
+
+// SupportsCondition represents the condition of an @supports rule.
+//
+// When Not is set, the condition is the negation of that SupportsInParens;
+// otherwise the condition is the combination of the InParens values, joined by
+// 'or' when Or is true and by 'and' otherwise.
+type SupportsCondition struct {
+	Not      *SupportsInParens
+	InParens []SupportsInParens
+	Or       bool
+	Tokens   Tokens
+}
+
+func (s *SupportsCondition) parse(c *cssParser) error {
+	if c.AcceptIdent("not") {
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+		s.Not = new(SupportsInParens)
+
+		if err := s.Not.parse(&d); err != nil {
+			return c.Error("SupportsCondition", err)
+		}
+
+		c.Score(d)
+	} else {
+		var operator string
+
+		for {
+			d := c.NewGoal()
+
+			var sip SupportsInParens
+
+			if err := sip.parse(&d); err != nil {
+				return c.Error("SupportsCondition", err)
+			}
+
+			s.InParens = append(s.InParens, sip)
+
+			c.Score(d)
+
+			d = c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if operator == "" {
+				if d.AcceptIdent("and") {
+					operator = "and"
+				} else if d.AcceptIdent("or") {
+					operator = "or"
+					s.Or = true
+				} else {
+					break
+				}
+			} else if !d.AcceptIdent(operator) {
+				if d.AcceptIdent("and") || d.AcceptIdent("or") {
+					return d.Error("SupportsCondition", ErrMixedOperators)
+				}
+
+				break
+			}
+
+			d.AcceptRunWhitespace()
+			c.Score(d)
+		}
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// Evaluate determines whether the condition holds, using the given
+// SupportedFeatures to test individual features.
+func (s *SupportsCondition) Evaluate(f *SupportedFeatures) bool {
+	if s.Not != nil {
+		return !s.Not.Evaluate(f)
+	}
+
+	for _, sip := range s.InParens {
+		if sip.Evaluate(f) == s.Or {
+			return s.Or
+		}
+	}
+
+	return !s.Or
+}
+
+// SupportsInParens represents a single test within a SupportsCondition.
+//
+// Only one of Condition, Declaration, Selector, FontTech, FontFormat, or
+// GeneralEnclosed will be set.
+type SupportsInParens struct {
+	Condition       *SupportsCondition
+	Declaration     *Declaration
+	Selector        Tokens
+	FontTech        *Token
+	FontFormat      *Token
+	GeneralEnclosed Tokens
+	Tokens          Tokens
+}
+
+func (s *SupportsInParens) parse(c *cssParser) error {
+	if c.Accept(TokenOpenParen) {
+		c.AcceptRunWhitespace()
+
+		if isDeclaration(*c) {
+			d := c.NewGoal()
+			s.Declaration = new(Declaration)
+
+			if err := s.Declaration.parse(&d); err != nil {
+				return c.Error("SupportsInParens", err)
+			}
+
+			c.Score(d)
+		} else {
+			d := c.NewGoal()
+			s.Condition = new(SupportsCondition)
+
+			if err := s.Condition.parse(&d); err == nil {
+				c.Score(d)
+			} else {
+				s.Condition = nil
+			}
+		}
+
+		if c.AcceptRunWhitespace(); !c.Accept(TokenCloseParen) {
+			if s.Declaration != nil {
+				return c.Error("SupportsInParens", ErrMissingCloseParen)
+			}
+
+			s.Condition = nil
+
+			return s.parseGeneralEnclosed(c)
+		}
+	} else if c.AcceptFunction("selector") {
+		s.Selector = acceptFunctionArguments(c)
+
+		if len(s.Selector) == 0 {
+			return c.Error("SupportsInParens", ErrMissingSelector)
+		}
+	} else if c.AcceptFunction("font-tech") || c.AcceptFunction("font-format") {
+		isTech := strings.EqualFold(c.GetLastToken().Data, "font-tech(")
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenIdent, TokenString) {
+			return c.Error("SupportsInParens", ErrInvalidFontFeature)
+		}
+
+		if isTech {
+			s.FontTech = c.GetLastToken()
+		} else {
+			s.FontFormat = c.GetLastToken()
+		}
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("SupportsInParens", ErrMissingCloseParen)
+		}
+	} else if tk := c.Peek(); tk.Type == TokenFunction {
+		return s.parseGeneralEnclosed(c)
+	} else {
+		return c.Error("SupportsInParens", ErrInvalidSupportsCondition)
+	}
+
+	s.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func (s *SupportsInParens) parseGeneralEnclosed(c *cssParser) error {
+	*c = (*c)[:0]
+
+	c.AcceptComponentValue()
+
+	s.GeneralEnclosed = c.ToTokens()
+	s.Tokens = s.GeneralEnclosed
+
+	return nil
+}
+
+func acceptFunctionArguments(c *cssParser) Tokens {
+	d := c.NewGoal()
+
+	for d.Peek().Type != TokenCloseParen {
+		if !d.AcceptComponentValue() {
+			break
+		}
+	}
+
+	args := d.ToTokens().Trim()
+
+	c.Score(d)
+	c.Accept(TokenCloseParen)
+
+	return args
+}
+
+// Evaluate determines whether the test holds, using the given
+// SupportedFeatures to test individual features.
+//
+// A GeneralEnclosed value always evaluates to false.
+func (s *SupportsInParens) Evaluate(f *SupportedFeatures) bool {
+	switch {
+	case s.Condition != nil:
+		return s.Condition.Evaluate(f)
+	case s.Declaration != nil:
+		return f != nil && f.Declaration != nil && f.Declaration(s.Declaration)
+	case s.Selector != nil:
+		return f != nil && f.Selector != nil && f.Selector(s.Selector)
+	case s.FontTech != nil:
+		return f != nil && f.FontTech != nil && f.FontTech(fontFeatureName(s.FontTech))
+	case s.FontFormat != nil:
+		return f != nil && f.FontFormat != nil && f.FontFormat(fontFeatureName(s.FontFormat))
+	}
+
+	return false
+}
+
+func fontFeatureName(tk *Token) string {
+	if tk.Type == TokenString {
+		if str, err := Unquote(tk.Data); err == nil {
+			return strings.ToLower(str)
+		}
+	}
+
+	return strings.ToLower(tk.Data)
+}
+
+// SupportedFeatures contains the callbacks used to determine whether a
+// particular feature is supported when evaluating a SupportsCondition.
+//
+// A nil callback is treated as the feature being unsupported.
+type SupportedFeatures struct {
+	Declaration func(*Declaration) bool
+	Selector    func(Tokens) bool
+	FontTech    func(string) bool
+	FontFormat  func(string) bool
+}
@@ -0,0 +1,102 @@
+package css
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestSupports(t *testing.T) {
+	features := &SupportedFeatures{
+		Declaration: func(d *Declaration) bool {
+			return d.Property() == "display" && d.Value.String() == "grid"
+		},
+		Selector: func(ts Tokens) bool {
+			return !strings.Contains(ts.String(), ":has")
+		},
+		FontTech: func(tech string) bool {
+			return tech == "color-colrv1"
+		},
+		FontFormat: func(format string) bool {
+			return format == "woff2"
+		},
+	}
+
+	for n, test := range [...]struct {
+		Input  string
+		Output bool
+		Err    error
+	}{
+		{ // 1
+			Input:  "(display: grid)",
+			Output: true,
+		},
+		{ // 2
+			Input:  "(display: flex)",
+			Output: false,
+		},
+		{ // 3
+			Input:  "not (display: flex)",
+			Output: true,
+		},
+		{ // 4
+			Input:  "(display: grid) and (not selector(:has(a)))",
+			Output: true,
+		},
+		{ // 5
+			Input:  "(display: grid) and selector(:has(a))",
+			Output: false,
+		},
+		{ // 6
+			Input:  "(display: flex) or selector(a > b)",
+			Output: true,
+		},
+		{ // 7
+			Input:  "font-tech(color-COLRv1) and font-format('woff2')",
+			Output: true,
+		},
+		{ // 8
+			Input:  "((display: grid) or (display: flex)) and font-format(woff)",
+			Output: false,
+		},
+		{ // 9
+			Input:  "unknown(a b c) or (a b c)",
+			Output: false,
+		},
+		{ // 10
+			Input:  "not unknown(a)",
+			Output: true,
+		},
+		{ // 11
+			Input: "(display: grid) and (display: flex) or (display: block)",
+			Err:   ErrMixedOperators,
+		},
+		{ // 12
+			Input: "display: grid",
+			Err:   ErrInvalidSupportsCondition,
+		},
+		{ // 13
+			Input: "(display: grid) (display: flex)",
+			Err:   ErrUnexpectedToken,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser("@supports " + test.Input + " {}"))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if cond := s.Rules[0].AtRule.Supports; cond == nil {
+			t.Errorf("test %d: expecting supports condition, got nil", n+1)
+		} else if out := cond.Evaluate(features); out != test.Output {
+			t.Errorf("test %d: expecting evaluation %v, got %v", n+1, test.Output, out)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package css
+
+import (
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// ViewTransition represents the descriptors of an @view-transition rule.
+//
+// The Navigation is either 'auto' or 'none', with an empty value meaning
+// unspecified.
+//
+// Invalid descriptors are ignored, with the reason for each recorded in
+// Errors.
+type ViewTransition struct {
+	Navigation string
+	Types      []string
+	Errors     []error
+}
+
+func (v *ViewTransition) parse(b *Block) {
+	for _, d := range b.Declarations() {
+		c := newCSSParserFromTokens(d.Value)
+		valid := *v
+
+		var err error
+
+		c.AcceptRunWhitespace()
+
+		switch d.Property() {
+		case "navigation":
+			v.Navigation, err = parseKeyword(&c, "auto", "none")
+		case "types":
+			v.Types, err = parseTransitionTypes(&c)
+		default:
+			continue
+		}
+
+		if err == nil && c.AcceptRunWhitespace() != parser.TokenDone {
+			err = c.Error("ViewTransition", ErrUnexpectedToken)
+		}
+
+		if err != nil {
+			*v = valid
+			v.Errors = append(v.Errors, err)
+		}
+	}
+}
+
+func parseTransitionTypes(c *cssParser) ([]string, error) {
+	if c.AcceptIdent("none") {
+		return nil, nil
+	}
+
+	var types []string
+
+	for c.Accept(TokenIdent) {
+		name := c.GetLastToken().Data
+
+		if isCSSWideKeyword(name) || strings.HasPrefix(strings.ToLower(name), "-ua-") {
+			return nil, c.Error("ViewTransition", ErrInvalidKeyword)
+		}
+
+		types = append(types, name)
+
+		c.AcceptRunWhitespace()
+	}
+
+	if len(types) == 0 {
+		return nil, c.Error("ViewTransition", ErrInvalidKeyword)
+	}
+
+	return types, nil
+}
@@ -0,0 +1,80 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestViewTransition(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output ViewTransition
+		Err    error
+	}{
+		{ // 1
+			Input:  "@view-transition { navigation: auto }",
+			Output: ViewTransition{Navigation: "auto"},
+		},
+		{ // 2
+			Input:  "@view-transition { navigation: NONE; types: slide forwards; }",
+			Output: ViewTransition{Navigation: "none", Types: []string{"slide", "forwards"}},
+		},
+		{ // 3
+			Input: "@view-transition { types: none }",
+		},
+		{ // 4
+			Input:  "@view-transition { navigation: manual }",
+			Output: ViewTransition{Errors: []error{ErrInvalidKeyword}},
+		},
+		{ // 5
+			Input:  "@view-transition { types: slide; types: -ua-slide }",
+			Output: ViewTransition{Types: []string{"slide"}, Errors: []error{ErrInvalidKeyword}},
+		},
+		{ // 6
+			Input: "@view-transition foo { navigation: auto }",
+			Err:   ErrUnexpectedPrelude,
+		},
+		{ // 7
+			Input: "@view-transition;",
+			Err:   ErrMissingBlock,
+		},
+		{ // 8
+			Input:  "@view-transition { navigation: auto extra; types: slide } a {}",
+			Output: ViewTransition{Types: []string{"slide"}, Errors: []error{ErrUnexpectedToken}},
+		},
+		{ // 9
+			Input:  "@view-transition { navigation: auto; garbage } a {}",
+			Output: ViewTransition{Navigation: "auto"},
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = s.Rules[0].AtRule.Err
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if vt := s.Rules[0].AtRule.ViewTransition; len(vt.Errors) != len(test.Output.Errors) {
+			t.Errorf("test %d: expecting %d errors, got %d", n+1, len(test.Output.Errors), len(vt.Errors))
+		} else {
+			for m, err := range test.Output.Errors {
+				if !errors.Is(vt.Errors[m], err) {
+					t.Errorf("test %d: expecting error %d to be %v, got %v", n+1, m+1, err, vt.Errors[m])
+				}
+			}
+
+			vt.Errors, test.Output.Errors = nil, nil
+
+			if !reflect.DeepEqual(*vt, test.Output) {
+				t.Errorf("test %d: expecting %v, got %v", n+1, test.Output, *vt)
+			}
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package css
+
+import (
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// Bundle reads the entry stylesheet from the given filesystem, recursively
+// inlining the contents of any local stylesheets it imports.
+//
+// Inlined rules are wrapped in @media, @supports and @layer rules as needed to
+// preserve the conditions of the @import, and relative url() references in the
+// inlined rules are rewritten to be relative to the entry stylesheet.
+//
+// Imports of external URLs are retained and moved to the top of the bundle.
+// As this must not change the cascade order, an external import that would
+// be moved above rules inlined from an earlier import returns
+// ErrHoistedImport; @layer statements that precede it are moved along with
+// it.
+//
+// A stylesheet that is imported unconditionally more than once is only
+// inlined at its last import, as that is the one that determines its place in
+// the cascade.
+//
+// As @namespace rules cannot follow other rules, those of the entry stylesheet
+// are moved above the inlined rules. As they only apply to the stylesheet that
+// declares them, an imported stylesheet that declares a namespace returns
+// ErrInlinedNamespace.
+func Bundle(fsys fs.FS, entry string) (*Sheet, error) {
+	b := bundler{
+		fsys:   fsys,
+		base:   path.Dir(entry),
+		counts: make(map[string]map[string]int),
+	}
+
+	s, err := b.readSheet(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	b.remaining = maps.Clone(b.countImports(entry, []string{entry}))
+
+	rules, err := b.inline(s, entry, []string{entry}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sheet{Rules: slices.Concat(b.header, b.namespaces, rules)}, nil
+}
+
+type bundler struct {
+	fsys       fs.FS
+	base       string
+	header     []Rule
+	namespaces []Rule
+	counts     map[string]map[string]int
+	remaining  map[string]int
+	emitted    bool
+}
+
+func (b *bundler) readSheet(name string) (*Sheet, error) {
+	data, err := fs.ReadFile(b.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSheet(parser.NewStringTokeniser(string(data)))
+}
+
+// countImports returns the number of times that each stylesheet is imported
+// unconditionally, directly or indirectly, by the given stylesheet.
+//
+// Stylesheets that cannot be read, and import cycles, are ignored here, and
+// are reported when inlining.
+func (b *bundler) countImports(file string, stack []string) map[string]int {
+	if counts, ok := b.counts[file]; ok {
+		return counts
+	}
+
+	counts := make(map[string]int)
+	b.counts[file] = counts
+
+	s, err := b.readSheet(file)
+	if err != nil {
+		return counts
+	}
+
+	for _, r := range s.Rules {
+		i := importRule(&r)
+		if i == nil || isExternalURL(i.URL) || isConditionalImport(i) {
+			continue
+		}
+
+		name := resolvePath(file, i.URL)
+
+		if slices.Contains(stack, name) {
+			continue
+		}
+
+		counts[name]++
+
+		for n, c := range b.countImports(name, append(stack, name)) {
+			counts[n] += c
+		}
+	}
+
+	return counts
+}
+
+func importRule(r *Rule) *ImportRule {
+	if r.AtRule == nil {
+		return nil
+	}
+
+	return r.AtRule.Import
+}
+
+func isConditionalImport(i *ImportRule) bool {
+	return i.Layer || i.Supports != nil || i.Media != nil
+}
+
+func (b *bundler) inline(s *Sheet, file string, stack []string, conditional bool) ([]Rule, error) {
+	var rules []Rule
+
+	for _, r := range s.Rules {
+		if r.AtRule != nil {
+			switch r.AtRule.Name() {
+			case "charset":
+				if len(stack) == 1 {
+					b.header = append(b.header, r)
+				}
+
+				continue
+			case "namespace":
+				if len(stack) > 1 {
+					return nil, bundleError(file, r.AtRule, ErrInlinedNamespace)
+				}
+
+				b.namespaces = append(b.namespaces, r)
+
+				continue
+			case "import":
+				if r.AtRule.Import == nil {
+					continue
+				} else if isExternalURL(r.AtRule.Import.URL) {
+					if conditional {
+						return nil, bundleError(file, r.AtRule, ErrExternalImport)
+					} else if b.emitted {
+						return nil, bundleError(file, r.AtRule, ErrHoistedImport)
+					}
+
+					b.header = append(b.header, r)
+
+					continue
+				}
+
+				imported, err := b.importRule(r.AtRule, file, stack, conditional)
+				if err != nil {
+					return nil, err
+				}
+
+				rules = append(rules, imported...)
+
+				continue
+			}
+		}
+
+		if !conditional && !b.emitted && r.allowedBeforeImport() {
+			b.header = append(b.header, r)
+
+			continue
+		}
+
+		if len(stack) > 1 {
+			b.rewriteURLs(&r, file)
+		}
+
+		b.emitted = true
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+func (b *bundler) importRule(a *AtRule, file string, stack []string, conditional bool) ([]Rule, error) {
+	i := a.Import
+	name := resolvePath(file, i.URL)
+
+	if slices.Contains(stack, name) {
+		return nil, bundleError(file, a, ErrImportCycle)
+	}
+
+	conditional = conditional || isConditionalImport(i)
+
+	if !conditional {
+		if b.remaining[name]--; b.remaining[name] > 0 {
+			for n, c := range b.countImports(name, append(stack, name)) {
+				b.remaining[n] -= c
+			}
+
+			return nil, nil
+		}
+	}
+
+	s, err := b.readSheet(name)
+	if err != nil {
+		return nil, bundleError(file, a, err)
+	}
+
+	rules, err := b.inline(s, name, append(stack, name), conditional)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Layer {
+		var prelude Tokens
+
+		if i.LayerName != "" {
+			prelude = Tokens{{Token: parser.Token{Type: TokenIdent, Data: i.LayerName}}}
+		}
+
+		rules = []Rule{wrapRules("layer", prelude, rules)}
+	}
+
+	if i.Supports != nil {
+		prelude := append(Tokens{{Token: parser.Token{Type: TokenOpenParen, Data: "("}}}, i.Supports.Tokens...)
+		prelude = append(prelude, Token{Token: parser.Token{Type: TokenCloseParen, Data: ")"}})
+		rules = []Rule{wrapRules("supports", prelude, rules)}
+		rules[0].AtRule.Supports = i.Supports
+	}
+
+	if i.Media != nil {
+		rules = []Rule{wrapRules("media", i.Media.Tokens, rules)}
+	}
+
+	return rules, nil
+}
+
+func wrapRules(name string, prelude Tokens, rules []Rule) Rule {
+	block := new(Block)
+
+	for n := range rules {
+		block.Items = append(block.Items, BlockItem{Rule: &rules[n]})
+	}
+
+	return Rule{
+		AtRule: &AtRule{
+			AtKeyword: &Token{Token: parser.Token{Type: TokenAtKeyword, Data: "@" + name}},
+			Prelude:   prelude,
+			Block:     block,
+		},
+	}
+}
+
+func bundleError(file string, a *AtRule, err error) error {
+	return Error{
+		Err:     err,
+		Parsing: file,
+		Token:   *a.AtKeyword,
+	}
+}
+
+func isExternalURL(url string) bool {
+	if strings.HasPrefix(url, "//") {
+		return true
+	}
+
+	for n, c := range url {
+		switch {
+		case c == ':':
+			return n > 0
+		case c == '/' || c == '?' || c == '#':
+			return false
+		}
+	}
+
+	return false
+}
+
+func resolvePath(file, url string) string {
+	if n := strings.IndexAny(url, "?#"); n >= 0 {
+		url = url[:n]
+	}
+
+	if strings.HasPrefix(url, "/") {
+		return strings.TrimPrefix(path.Clean(url), "/")
+	}
+
+	return path.Join(path.Dir(file), url)
+}
+
+func relativePath(from, to string) string {
+	fromParts := strings.Split(path.Clean(from), "/")
+	toParts := strings.Split(path.Clean(to), "/")
+
+	if from == "." {
+		fromParts = nil
+	}
+
+	for len(fromParts) > 0 && len(toParts) > 1 && fromParts[0] == toParts[0] {
+		fromParts = fromParts[1:]
+		toParts = toParts[1:]
+	}
+
+	return strings.Repeat("../", len(fromParts)) + strings.Join(toParts, "/")
+}
+
+func (b *bundler) rewriteURLs(r *Rule, file string) {
+	if path.Dir(file) == b.base {
+		return
+	}
+
+	if r.AtRule != nil {
+		b.rewriteTokenURLs(r.AtRule.Prelude, file)
+
+		if r.AtRule.Block != nil {
+			b.rewriteBlockURLs(r.AtRule.Block, file)
+		}
+	} else if r.QualifiedRule != nil {
+		b.rewriteBlockURLs(&r.QualifiedRule.Block, file)
+	}
+}
+
+func (b *bundler) rewriteBlockURLs(block *Block, file string) {
+	for _, bi := range block.Items {
+		if bi.Declaration != nil {
+			b.rewriteTokenURLs(bi.Declaration.Value, file)
+		} else if bi.Rule != nil {
+			b.rewriteURLs(bi.Rule, file)
+		}
+	}
+}
+
+func (b *bundler) rewriteTokenURLs(ts Tokens, file string) {
+	for n := range ts {
+		tk := &ts[n]
+
+		switch tk.Type {
+		case TokenURL:
+			if url, err := UnURL(tk.Data); err == nil {
+				if url, ok := b.rebaseURL(file, url); ok {
+					tk.Data = "url(" + quoteURL(url) + ")"
+				}
+			}
+		case TokenFunction:
+			if !strings.EqualFold(tk.Data, "url(") {
+				continue
+			}
+
+			for m := n + 1; m < len(ts); m++ {
+				if ts[m].Type == TokenString {
+					if url, err := Unquote(ts[m].Data); err == nil {
+						if url, ok := b.rebaseURL(file, url); ok {
+							ts[m].Data = quote(url)
+						}
+					}
+
+					break
+				} else if ts[m].Type != TokenWhitespace {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (b *bundler) rebaseURL(file, url string) (string, bool) {
+	if url == "" || isExternalURL(url) || strings.HasPrefix(url, "/") || strings.HasPrefix(url, "#") {
+		return "", false
+	}
+
+	suffix := ""
+
+	if n := strings.IndexAny(url, "?#"); n >= 0 {
+		url, suffix = url[:n], url[n:]
+	}
+
+	return relativePath(b.base, path.Join(path.Dir(file), url)) + suffix, true
+}
+
+func quoteURL(url string) string {
+	if strings.ContainsAny(url, noURL) {
+		return quote(url)
+	}
+
+	return url
+}
+
+func quote(str string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+
+	for _, c := range str {
+		switch c {
+		case '"', '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(c)
+		case '\n':
+			sb.WriteString("\\a ")
+		default:
+			sb.WriteRune(c)
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
@@ -0,0 +1,183 @@
+package css
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundle(t *testing.T) {
+	for n, test := range [...]struct {
+		Files  fstest.MapFS
+		Entry  string
+		Output string
+		Err    error
+	}{
+		{ // 1
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";\nb { color: blue }")},
+				"a.css":    {Data: []byte("a { color: red }")},
+			},
+			Entry:  "main.css",
+			Output: "a {\n\tcolor: red;\n}\nb {\n\tcolor: blue;\n}\n",
+		},
+		{ // 2
+			Files: fstest.MapFS{
+				"css/main.css":  {Data: []byte("@charset \"utf-8\";\n@import url(\"https://example.com/a.css\");\n@import url(lib/a.css) layer(base) supports(display:grid) screen and (min-width: 40em);")},
+				"css/lib/a.css": {Data: []byte("@charset \"utf-8\";\n@import \"../../b.css\";\na { background: url(img/a.png) }")},
+				"b.css":         {Data: []byte("b { background: url( \"../img/b.png\" ) url(/c.png) url(#d) url(data:image/png,) }")},
+			},
+			Entry:  "css/main.css",
+			Output: "@charset \"utf-8\";\n@import url(\"https://example.com/a.css\");\n@media screen and (min-width: 40em) {\n\t@supports (display:grid) {\n\t\t@layer base {\n\t\t\tb {\n\t\t\t\tbackground: url( \"../../img/b.png\" ) url(/c.png) url(#d) url(data:image/png,);\n\t\t\t}\n\t\t\ta {\n\t\t\t\tbackground: url(lib/img/a.png);\n\t\t\t}\n\t\t}\n\t}\n}\n",
+		},
+		{ // 3
+			Files: fstest.MapFS{
+				"main.css":  {Data: []byte("@import \"sub/a.css\" layer;")},
+				"sub/a.css": {Data: []byte("@import \"../img.css\";\na { background: url('x y.png') }")},
+				"img.css":   {Data: []byte("b { color: red }")},
+			},
+			Entry:  "main.css",
+			Output: "@layer {\n\tb {\n\t\tcolor: red;\n\t}\n\ta {\n\t\tbackground: url(\"sub/x y.png\");\n\t}\n}\n",
+		},
+		{ // 4
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";")},
+				"a.css":    {Data: []byte("@import \"main.css\";")},
+			},
+			Entry: "main.css",
+			Err:   ErrImportCycle,
+		},
+		{ // 5
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("\n  @import \"missing.css\";")},
+			},
+			Entry: "main.css",
+			Err:   fs.ErrNotExist,
+		},
+		{ // 6
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\" print;")},
+				"a.css":    {Data: []byte("@import \"http://example.com/b.css\";")},
+			},
+			Entry: "main.css",
+			Err:   ErrExternalImport,
+		},
+		{ // 7
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"b.css\";\n@import \"c.css\";")},
+				"b.css":    {Data: []byte("@import \"d.css\";\nd { color: blue }")},
+				"c.css":    {Data: []byte("@import \"d.css\";\nc { color: green }")},
+				"d.css":    {Data: []byte("d { color: red }")},
+			},
+			Entry:  "main.css",
+			Output: "d {\n\tcolor: blue;\n}\nd {\n\tcolor: red;\n}\nc {\n\tcolor: green;\n}\n",
+		},
+		{ // 8
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";\n@import \"b.css\" print;\n@import \"b.css\";")},
+				"a.css":    {Data: []byte("@import \"b.css\";")},
+				"b.css":    {Data: []byte("b { color: blue }")},
+			},
+			Entry:  "main.css",
+			Output: "@media print {\n\tb {\n\t\tcolor: blue;\n\t}\n}\nb {\n\tcolor: blue;\n}\n",
+		},
+		{ // 9
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";\nb { color: blue }")},
+				"a.css":    {Data: []byte("@import \"https://example.com/c.css\";\na { color: red }")},
+			},
+			Entry:  "main.css",
+			Output: "@import \"https://example.com/c.css\";\na {\n\tcolor: red;\n}\nb {\n\tcolor: blue;\n}\n",
+		},
+		{ // 10
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";\n@import \"b.css\";")},
+				"a.css":    {Data: []byte("a { color: red }")},
+				"b.css":    {Data: []byte("@import \"https://example.com/c.css\";")},
+			},
+			Entry: "main.css",
+			Err:   ErrHoistedImport,
+		},
+		{ // 11
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@layer a, b;\n@import \"https://example.com/c.css\";")},
+			},
+			Entry:  "main.css",
+			Output: "@layer a, b;\n@import \"https://example.com/c.css\";\n",
+		},
+		{ // 12
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import 5;\na { color: red }")},
+			},
+			Entry:  "main.css",
+			Output: "a {\n\tcolor: red;\n}\n",
+		},
+		{ // 13
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"b.css\";\n@import \"c.css\";\n@import \"e.css\";")},
+				"b.css":    {Data: []byte("@import \"d.css\";\nb { color: blue }")},
+				"c.css":    {Data: []byte("@import \"e.css\";\n@import \"d.css\";")},
+				"d.css":    {Data: []byte("@import \"e.css\";\nd { color: red }")},
+				"e.css":    {Data: []byte("e { color: green }")},
+			},
+			Entry:  "main.css",
+			Output: "b {\n\tcolor: blue;\n}\nd {\n\tcolor: red;\n}\ne {\n\tcolor: green;\n}\n",
+		},
+		{ // 14
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";\n@namespace svg \"http://www.w3.org/2000/svg\";\nsvg|a {}")},
+				"a.css":    {Data: []byte("@import \"https://example.com/b.css\";\na { color: red }")},
+			},
+			Entry:  "main.css",
+			Output: "@import \"https://example.com/b.css\";\n@namespace svg \"http://www.w3.org/2000/svg\";\na {\n\tcolor: red;\n}\nsvg|a {}\n",
+		},
+		{ // 15
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("@import \"a.css\";")},
+				"a.css":    {Data: []byte("@namespace svg \"http://www.w3.org/2000/svg\";\nsvg|a { color: red }")},
+			},
+			Entry: "main.css",
+			Err:   ErrInlinedNamespace,
+		},
+		{ // 16
+			Files: fstest.MapFS{
+				"main.css": {Data: []byte("a { color: red }\n@import \"b.css\";")},
+				"b.css":    {Data: []byte("b { color: blue }")},
+			},
+			Entry:  "main.css",
+			Output: "a {\n\tcolor: red;\n}\n",
+		},
+	} {
+		s, err := Bundle(test.Files, test.Entry)
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if out := s.String(); out != test.Output {
+			t.Errorf("test %d: expecting output:\n%s\ngot:\n%s", n+1, test.Output, out)
+		}
+	}
+}
+
+func TestBundleErrorPosition(t *testing.T) {
+	_, err := Bundle(fstest.MapFS{
+		"main.css": {Data: []byte("@charset \"utf-8\";\n\n  @import \"missing.css\";")},
+	}, "main.css")
+
+	var e Error
+
+	if err == nil {
+		t.Fatal("expecting error, got none")
+	} else if !errors.As(err, &e) {
+		t.Fatalf("expecting Error, got %T", err)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expecting error %v, got %v", fs.ErrNotExist, err)
+	} else if e.Parsing != "main.css" {
+		t.Errorf("expecting error in %q, got %q", "main.css", e.Parsing)
+	} else if e.Token.Line != 2 || e.Token.LinePos != 2 {
+		t.Errorf("expecting error at 2:2, got %d:%d", e.Token.Line, e.Token.LinePos)
+	}
+}
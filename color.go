@@ -0,0 +1,607 @@
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// ColorSpace represents the colour space of a Color.
+type ColorSpace uint8
+
+// Colour spaces.
+const (
+	ColorSpaceSRGB ColorSpace = iota
+	ColorSpaceSRGBLinear
+	ColorSpaceDisplayP3
+	ColorSpaceA98RGB
+	ColorSpaceProPhotoRGB
+	ColorSpaceRec2020
+	ColorSpaceXYZD50
+	ColorSpaceXYZD65
+	ColorSpaceLab
+	ColorSpaceLCH
+	ColorSpaceOKLab
+	ColorSpaceOKLCH
+	ColorSpaceHSL
+	ColorSpaceHWB
+)
+
+var colorSpaceNames = [...]string{
+	ColorSpaceSRGB:        "srgb",
+	ColorSpaceSRGBLinear:  "srgb-linear",
+	ColorSpaceDisplayP3:   "display-p3",
+	ColorSpaceA98RGB:      "a98-rgb",
+	ColorSpaceProPhotoRGB: "prophoto-rgb",
+	ColorSpaceRec2020:     "rec2020",
+	ColorSpaceXYZD50:      "xyz-d50",
+	ColorSpaceXYZD65:      "xyz-d65",
+	ColorSpaceLab:         "lab",
+	ColorSpaceLCH:         "lch",
+	ColorSpaceOKLab:       "oklab",
+	ColorSpaceOKLCH:       "oklch",
+	ColorSpaceHSL:         "hsl",
+	ColorSpaceHWB:         "hwb",
+}
+
+// String returns the CSS name of the ColorSpace.
+func (s ColorSpace) String() string {
+	if int(s) < len(colorSpaceNames) {
+		return colorSpaceNames[s]
+	}
+
+	return "unknown"
+}
+
+// ParseColorSpace returns the ColorSpace with the given CSS name, as used in
+// the color() function and color interpolation methods.
+func ParseColorSpace(name string) (ColorSpace, bool) {
+	name = strings.ToLower(name)
+
+	if name == "xyz" {
+		return ColorSpaceXYZD65, true
+	}
+
+	for s, n := range colorSpaceNames {
+		if n == name {
+			return ColorSpace(s), true
+		}
+	}
+
+	return 0, false
+}
+
+// Color represents a CSS colour value.
+//
+// The meaning of the Components depends on the Space:
+//
+//   - RGB spaces, such as sRGB and Display P3, use values in the range 0-1.
+//   - XYZ spaces use the raw X, Y, and Z values.
+//   - Lab and LCH use a Lightness of 0-100, and OKLab and OKLCH use 0-1.
+//   - HSL uses a Hue in degrees, and Saturation and Lightness of 0-100.
+//   - HWB uses a Hue in degrees, and Whiteness and Blackness of 0-100.
+//
+// A component of NaN represents a missing component, written as 'none'. The
+// Alpha is in the range 0-1.
+//
+// When CurrentColor is true, the Color represents the 'currentcolor' keyword,
+// and all other fields are ignored.
+type Color struct {
+	Space        ColorSpace
+	Components   [3]float64
+	Alpha        float64
+	CurrentColor bool
+}
+
+// ParseColor parses a colour value, such as 'red', '#ff0000',
+// 'rgb(255 0 0)', or 'color(display-p3 1 0 0 / 50%)'.
+//
+// The color-mix() function and relative colours, such as
+// 'rgb(from red r g b / 50%)', are resolved to concrete colours; any var()
+// references must be substituted beforehand. As 'currentcolor' cannot be
+// resolved statically, using it within either returns ErrUnresolvedColor.
+func ParseColor(value Tokens) (Color, error) {
+	var col Color
+
+	c := newCSSParserFromTokens(value)
+
+	if err := parseTrimmed(&c, col.parse); err != nil {
+		return Color{}, err
+	}
+
+	return col, nil
+}
+
+func (col *Color) parse(c *cssParser) error {
+	col.Alpha = 1
+
+	switch tk := c.Next(); tk.Type {
+	case TokenHash:
+		return col.parseHex(c, tk.Data[1:])
+	case TokenIdent:
+		name := strings.ToLower(tk.Data)
+
+		if rgb, ok := namedColors[name]; ok {
+			col.Components = [3]float64{float64(rgb[0]) / 255, float64(rgb[1]) / 255, float64(rgb[2]) / 255}
+		} else if name == "transparent" {
+			col.Alpha = 0
+		} else if name == "currentcolor" {
+			col.CurrentColor = true
+		} else {
+			return c.Error("Color", ErrInvalidColor)
+		}
+
+		return nil
+	case TokenFunction:
+		name := functionName(*tk)
+		args := acceptFunctionArguments(c)
+
+		if err := col.parseFunction(name, args); err != nil {
+			return c.Error("Color", err)
+		}
+
+		return nil
+	}
+
+	return c.Error("Color", ErrInvalidColor)
+}
+
+func (col *Color) parseHex(c *cssParser, hex string) error {
+	var digits [8]float64
+
+	if strings.Trim(hex, hexDigits) != "" {
+		return c.Error("Color", ErrInvalidColor)
+	}
+
+	for n, d := range hex {
+		v, _ := strconv.ParseUint(string(d), 16, 8)
+		digits[n] = float64(v)
+	}
+
+	switch len(hex) {
+	case 3, 4:
+		for n := range 4 {
+			digits[n] *= 17
+		}
+	case 6, 8:
+		for n := range 4 {
+			digits[n] = digits[2*n]*16 + digits[2*n+1]
+		}
+	default:
+		return c.Error("Color", ErrInvalidColor)
+	}
+
+	col.Components = [3]float64{digits[0] / 255, digits[1] / 255, digits[2] / 255}
+
+	if len(hex) == 4 || len(hex) == 8 {
+		col.Alpha = digits[3] / 255
+	}
+
+	return nil
+}
+
+type colorArguments struct {
+	values []Token
+	alpha  *Token
+	legacy bool
+}
+
+func parseColorArguments(args Tokens) (colorArguments, error) {
+	var (
+		ca    colorArguments
+		slash bool
+		comma bool
+	)
+
+	c := newCSSParserFromTokens(args)
+
+	for c.AcceptRunWhitespace() != parser.TokenDone {
+		tk := c.Next()
+
+		switch tk.Type {
+		case TokenComma:
+			if len(ca.values) == 0 || comma || slash || len(ca.values) > 1 && !ca.legacy {
+				return ca, ErrInvalidColor
+			}
+
+			comma = true
+			ca.legacy = true
+
+			continue
+		case TokenDelim:
+			if tk.Data != "/" || slash || ca.legacy || len(ca.values) == 0 {
+				return ca, ErrInvalidColor
+			}
+
+			slash = true
+
+			continue
+		case TokenNumber, TokenPercentage, TokenDimension, TokenIdent:
+		default:
+			return ca, ErrInvalidColor
+		}
+
+		if ca.legacy && !comma && len(ca.values) > 0 {
+			return ca, ErrInvalidColor
+		}
+
+		comma = false
+
+		if slash {
+			if ca.alpha != nil {
+				return ca, ErrInvalidColor
+			}
+
+			ca.alpha = tk
+		} else {
+			ca.values = append(ca.values, *tk)
+		}
+	}
+
+	if comma || slash && ca.alpha == nil {
+		return ca, ErrInvalidColor
+	}
+
+	if ca.legacy {
+		if len(ca.values) == 4 {
+			ca.alpha = &ca.values[3]
+			ca.values = ca.values[:3]
+		}
+
+		for _, v := range ca.values {
+			if v.Type == TokenIdent {
+				return ca, ErrInvalidColor
+			}
+		}
+
+		if ca.alpha != nil && ca.alpha.Type == TokenIdent {
+			return ca, ErrInvalidColor
+		}
+	}
+
+	return ca, nil
+}
+
+func (col *Color) parseFunction(name string, args Tokens) error {
+	if name == "color-mix" {
+		return col.parseMix(args)
+	}
+
+	origin, args, err := parseOriginColor(args)
+	if err != nil {
+		return err
+	}
+
+	var space string
+
+	if name == "color" {
+		c := newCSSParserFromTokens(args)
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenIdent) {
+			return ErrInvalidColor
+		}
+
+		space = c.GetLastToken().Data
+		args = args[len(c):]
+	}
+
+	if origin != nil {
+		if args, err = origin.substituteChannels(name, space, args); err != nil {
+			return err
+		}
+	}
+
+	ca, err := parseColorArguments(args)
+	if err != nil {
+		return err
+	} else if len(ca.values) != 3 || origin != nil && ca.legacy {
+		return ErrInvalidColor
+	}
+
+	switch name {
+	case "rgb", "rgba":
+		err = col.parseRGB(ca)
+	case "hsl", "hsla":
+		err = col.parsePolar(ca, ColorSpaceHSL, 0, 100, 100)
+	case "hwb":
+		err = col.parsePolar(ca, ColorSpaceHWB, 0, 100, 100)
+	case "lab":
+		err = col.parseRectangular(ca, ColorSpaceLab, 100, 125, 125)
+	case "lch":
+		err = col.parsePolar(ca, ColorSpaceLCH, 100, 150, 0)
+	case "oklab":
+		err = col.parseRectangular(ca, ColorSpaceOKLab, 1, 0.4, 0.4)
+	case "oklch":
+		err = col.parsePolar(ca, ColorSpaceOKLCH, 1, 0.4, 0)
+	case "color":
+		s, ok := ParseColorSpace(space)
+		if !ok || s == ColorSpaceLab || s == ColorSpaceLCH || s == ColorSpaceOKLab || s == ColorSpaceOKLCH || s == ColorSpaceHSL || s == ColorSpaceHWB {
+			return ErrInvalidColor
+		}
+
+		err = col.parseRectangular(ca, s, 1, 1, 1)
+	default:
+		return ErrInvalidColor
+	}
+
+	if err != nil {
+		return err
+	} else if origin != nil && ca.alpha == nil {
+		col.Alpha = origin.Alpha
+
+		return nil
+	}
+
+	return col.parseAlpha(ca)
+}
+
+func (col *Color) parseRGB(ca colorArguments) error {
+	col.Space = ColorSpaceSRGB
+
+	for n, v := range ca.values {
+		if ca.legacy && v.Type != ca.values[0].Type {
+			return ErrInvalidColor
+		}
+
+		value, err := colorComponent(v, 255)
+		if err != nil {
+			return err
+		}
+
+		col.Components[n] = clamp(value/255, 0, 1)
+	}
+
+	return nil
+}
+
+func (col *Color) parseRectangular(ca colorArguments, space ColorSpace, scales ...float64) error {
+	col.Space = space
+
+	for n, v := range ca.values {
+		value, err := colorComponent(v, scales[n])
+		if err != nil {
+			return err
+		}
+
+		col.Components[n] = value
+	}
+
+	if space == ColorSpaceLab {
+		col.Components[0] = clamp(col.Components[0], 0, 100)
+	} else if space == ColorSpaceOKLab {
+		col.Components[0] = clamp(col.Components[0], 0, 1)
+	}
+
+	return nil
+}
+
+func (col *Color) parsePolar(ca colorArguments, space ColorSpace, scales ...float64) error {
+	col.Space = space
+
+	if ca.legacy && space != ColorSpaceHSL {
+		return ErrInvalidColor
+	}
+
+	hue := hueComponentIndex(space)
+
+	for n, v := range ca.values {
+		var (
+			value float64
+			err   error
+		)
+
+		if n == hue {
+			value, err = hueComponent(v)
+		} else if ca.legacy && v.Type != TokenPercentage {
+			return ErrInvalidColor
+		} else {
+			value, err = colorComponent(v, scales[n])
+		}
+
+		if err != nil {
+			return err
+		}
+
+		col.Components[n] = value
+	}
+
+	switch space {
+	case ColorSpaceHSL, ColorSpaceHWB:
+		col.Components[1] = clamp(col.Components[1], 0, 100)
+		col.Components[2] = clamp(col.Components[2], 0, 100)
+	case ColorSpaceLCH:
+		col.Components[0] = clamp(col.Components[0], 0, 100)
+		col.Components[1] = max(col.Components[1], 0)
+	case ColorSpaceOKLCH:
+		col.Components[0] = clamp(col.Components[0], 0, 1)
+		col.Components[1] = max(col.Components[1], 0)
+	}
+
+	return nil
+}
+
+func (col *Color) parseAlpha(ca colorArguments) error {
+	if ca.alpha == nil {
+		return nil
+	}
+
+	alpha, err := colorComponent(*ca.alpha, 1)
+	if err != nil {
+		return err
+	}
+
+	col.Alpha = alpha
+
+	if !math.IsNaN(alpha) {
+		col.Alpha = clamp(alpha, 0, 1)
+	}
+
+	return nil
+}
+
+func colorComponent(tk Token, percentScale float64) (float64, error) {
+	switch tk.Type {
+	case TokenNumber:
+		v, err := strconv.ParseFloat(tk.Data, 64)
+		if err != nil {
+			return 0, ErrInvalidNumber
+		}
+
+		return v, nil
+	case TokenPercentage:
+		v, err := strconv.ParseFloat(strings.TrimSuffix(tk.Data, "%"), 64)
+		if err != nil {
+			return 0, ErrInvalidNumber
+		}
+
+		return v * percentScale / 100, nil
+	case TokenIdent:
+		if strings.EqualFold(tk.Data, "none") {
+			return math.NaN(), nil
+		}
+	}
+
+	return 0, ErrInvalidColor
+}
+
+func hueComponent(tk Token) (float64, error) {
+	switch tk.Type {
+	case TokenDimension:
+		return parseAngle(tk.Data)
+	case TokenNumber, TokenIdent:
+		return colorComponent(tk, 1)
+	}
+
+	return 0, ErrInvalidColor
+}
+
+func hueComponentIndex(space ColorSpace) int {
+	switch space {
+	case ColorSpaceHSL, ColorSpaceHWB:
+		return 0
+	case ColorSpaceLCH, ColorSpaceOKLCH:
+		return 2
+	}
+
+	return -1
+}
+
+func clamp(v, lower, upper float64) float64 {
+	return min(max(v, lower), upper)
+}
+
+// String returns a CSS representation of the Color.
+//
+// Colours in the sRGB, HSL, and HWB spaces are written using rgb(), with
+// components in the range 0-255 and missing components written as 0; all others are written using their own
+// function, or color() for the predefined RGB and XYZ spaces.
+func (col Color) String() string {
+	if col.CurrentColor {
+		return "currentcolor"
+	}
+
+	var sb strings.Builder
+
+	switch col.Space {
+	case ColorSpaceSRGB, ColorSpaceHSL, ColorSpaceHWB:
+		rgb := col.Convert(ColorSpaceSRGB)
+
+		if col.Alpha < 1 {
+			sb.WriteString("rgba(")
+		} else {
+			sb.WriteString("rgb(")
+		}
+
+		for n, v := range rgb.Components {
+			if n > 0 {
+				sb.WriteString(", ")
+			}
+
+			if math.IsNaN(v) {
+				v = 0
+			}
+
+			sb.WriteString(strconv.Itoa(int(math.Round(clamp(v, 0, 1) * 255))))
+		}
+
+		if col.Alpha < 1 {
+			sb.WriteString(", ")
+			sb.WriteString(formatColorNumber(col.Alpha))
+		}
+
+		sb.WriteString(")")
+
+		return sb.String()
+	case ColorSpaceLab, ColorSpaceLCH, ColorSpaceOKLab, ColorSpaceOKLCH:
+		sb.WriteString(col.Space.String())
+		sb.WriteString("(")
+	default:
+		sb.WriteString("color(")
+		sb.WriteString(col.Space.String())
+		sb.WriteString(" ")
+	}
+
+	for n, v := range col.Components {
+		if n > 0 {
+			sb.WriteString(" ")
+		}
+
+		sb.WriteString(formatColorNumber(v))
+	}
+
+	if col.Alpha < 1 || math.IsNaN(col.Alpha) {
+		sb.WriteString(" / ")
+		sb.WriteString(formatColorNumber(col.Alpha))
+	}
+
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+func formatColorNumber(v float64) string {
+	if math.IsNaN(v) {
+		return "none"
+	}
+
+	v = math.Round(v*1e6) / 1e6
+
+	if v == 0 {
+		v = 0
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Hex returns the Color, mapped into the sRGB gamut, as a hexadecimal colour,
+// such as '#ff0000', with an alpha component only when it is not opaque.
+func (col Color) Hex() string {
+	rgb := col.ToGamut(ColorSpaceSRGB)
+	values := []float64{rgb.Components[0], rgb.Components[1], rgb.Components[2]}
+
+	if a := rgb.Alpha; a < 1 && !math.IsNaN(a) {
+		values = append(values, a)
+	}
+
+	hex := []byte{'#'}
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			v = 0
+		}
+
+		hex = append(hex, hexPair(uint8(math.Round(clamp(v, 0, 1)*255)))...)
+	}
+
+	return string(hex)
+}
+
+func hexPair(b uint8) []byte {
+	const digits = "0123456789abcdef"
+
+	return []byte{digits[b>>4], digits[b&15]}
+}
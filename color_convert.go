@@ -0,0 +1,518 @@
+package css
+
+import "math"
+
+type colorMatrix [3][3]float64
+
+func (m colorMatrix) multiply(v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+func (m colorMatrix) inverse() colorMatrix {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+
+	return colorMatrix{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
+
+var (
+	linearSRGBToXYZ = colorMatrix{
+		{506752.0 / 1228815, 87881.0 / 245763, 12673.0 / 70218},
+		{87098.0 / 409605, 175762.0 / 245763, 12673.0 / 175545},
+		{7918.0 / 409605, 87881.0 / 737289, 1001167.0 / 1053270},
+	}
+	linearDisplayP3ToXYZ = colorMatrix{
+		{608311.0 / 1250200, 189793.0 / 714400, 198249.0 / 1000160},
+		{35783.0 / 156275, 247089.0 / 357200, 198249.0 / 2500400},
+		{0, 32229.0 / 714400, 5220557.0 / 5000800},
+	}
+	linearA98RGBToXYZ = colorMatrix{
+		{573536.0 / 994567, 263643.0 / 1420810, 187206.0 / 994567},
+		{591459.0 / 1989134, 6239551.0 / 9945670, 374412.0 / 4972835},
+		{53769.0 / 1989134, 351524.0 / 4972835, 4929758.0 / 4972835},
+	}
+	linearRec2020ToXYZ = colorMatrix{
+		{63426534.0 / 99577255, 20160776.0 / 139408157, 47086771.0 / 278816314},
+		{26158966.0 / 99577255, 472592308.0 / 697040785, 8267143.0 / 139408157},
+		{0, 19567812.0 / 697040785, 295819943.0 / 278816314},
+	}
+	linearProPhotoRGBToXYZD50 = colorMatrix{
+		{0.7977666449006423, 0.13518129740053308, 0.0313477341283922},
+		{0.2880748288194013, 0.711835234241873, 0.00008993693872564},
+		{0, 0, 0.8251046025104602},
+	}
+	xyzD50ToD65 = colorMatrix{
+		{0.955473421488075, -0.02309845494876471, 0.06325924320057072},
+		{-0.0283697093338637, 1.0099953980813041, 0.021041441191917323},
+		{0.012314014864481998, -0.020507649298898964, 1.330365926242124},
+	}
+	xyzToOKLMS = colorMatrix{
+		{0.819022437996703, 0.3619062600528904, -0.1288737815209879},
+		{0.0329836539323885, 0.9292868615863434, 0.0361446663506424},
+		{0.0481771893596242, 0.2642395317527308, 0.6335478284694309},
+	}
+	okLMSToOKLab = colorMatrix{
+		{0.210454268309314, 0.7936177747023054, -0.0040720430116193},
+		{1.9779985324311684, -2.42859224204858, 0.450593709617411},
+		{0.0259040424655478, 0.7827717124575296, -0.8086757549230774},
+	}
+
+	xyzToLinearSRGB           = linearSRGBToXYZ.inverse()
+	xyzToLinearDisplayP3      = linearDisplayP3ToXYZ.inverse()
+	xyzToLinearA98RGB         = linearA98RGBToXYZ.inverse()
+	xyzToLinearRec2020        = linearRec2020ToXYZ.inverse()
+	xyzD50ToLinearProPhotoRGB = linearProPhotoRGBToXYZD50.inverse()
+	xyzD65ToD50               = xyzD50ToD65.inverse()
+	okLMSToXYZ                = xyzToOKLMS.inverse()
+	okLabToOKLMS              = okLMSToOKLab.inverse()
+
+	whiteD50 = [3]float64{0.3457 / 0.3585, 1, (1 - 0.3457 - 0.3585) / 0.3585}
+)
+
+const (
+	labEpsilon = 216.0 / 24389
+	labKappa   = 24389.0 / 27
+
+	gamutEpsilon = 0.000001
+)
+
+func mapComponents(v [3]float64, fn func(float64) float64) [3]float64 {
+	return [3]float64{fn(v[0]), fn(v[1]), fn(v[2])}
+}
+
+func signedPow(v, e float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), e), v)
+}
+
+func srgbToLinear(v float64) float64 {
+	if math.Abs(v) <= 0.04045 {
+		return v / 12.92
+	}
+
+	return signedPow((math.Abs(v)+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) float64 {
+	if math.Abs(v) <= 0.0031308 {
+		return v * 12.92
+	}
+
+	return math.Copysign(1.055*math.Pow(math.Abs(v), 1/2.4)-0.055, v)
+}
+
+func a98RGBToLinear(v float64) float64 {
+	return signedPow(v, 563.0/256)
+}
+
+func linearToA98RGB(v float64) float64 {
+	return signedPow(v, 256.0/563)
+}
+
+func proPhotoRGBToLinear(v float64) float64 {
+	if math.Abs(v) <= 16.0/512 {
+		return v / 16
+	}
+
+	return signedPow(v, 1.8)
+}
+
+func linearToProPhotoRGB(v float64) float64 {
+	if math.Abs(v) >= 1.0/512 {
+		return signedPow(v, 1/1.8)
+	}
+
+	return v * 16
+}
+
+const (
+	rec2020Alpha = 1.09929682680944
+	rec2020Beta  = 0.018053968510807
+)
+
+func rec2020ToLinear(v float64) float64 {
+	if math.Abs(v) < rec2020Beta*4.5 {
+		return v / 4.5
+	}
+
+	return math.Copysign(math.Pow((math.Abs(v)+rec2020Alpha-1)/rec2020Alpha, 1/0.45), v)
+}
+
+func linearToRec2020(v float64) float64 {
+	if math.Abs(v) < rec2020Beta {
+		return v * 4.5
+	}
+
+	return math.Copysign(rec2020Alpha*math.Pow(math.Abs(v), 0.45)-(rec2020Alpha-1), v)
+}
+
+func hslToSRGB(v [3]float64) [3]float64 {
+	h, s, l := normaliseHue(v[0]), v[1]/100, v[2]/100
+
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * min(l, 1-l)
+
+		return l - a*max(-1, min(k-3, 9-k, 1))
+	}
+
+	return [3]float64{f(0), f(8), f(4)}
+}
+
+func srgbToHSL(v [3]float64) [3]float64 {
+	r, g, b := v[0], v[1], v[2]
+	high, low := max(r, g, b), min(r, g, b)
+	h, s, l := math.NaN(), 0.0, (low+high)/2
+
+	if d := high - low; d != 0 {
+		if l != 0 && l != 1 {
+			s = (high - l) / min(l, 1-l)
+		}
+
+		switch high {
+		case r:
+			h = (g-b)/d + 6*boolToFloat(g < b)
+		case g:
+			h = (b-r)/d + 2
+		default:
+			h = (r-g)/d + 4
+		}
+
+		h *= 60
+	}
+
+	if s < 0 {
+		h += 180
+		s = -s
+	}
+
+	if !math.IsNaN(h) {
+		h = normaliseHue(h)
+	}
+
+	return [3]float64{h, s * 100, l * 100}
+}
+
+func hwbToSRGB(v [3]float64) [3]float64 {
+	w, b := v[1]/100, v[2]/100
+
+	if w+b >= 1 {
+		gray := w / (w + b)
+
+		return [3]float64{gray, gray, gray}
+	}
+
+	rgb := hslToSRGB([3]float64{v[0], 100, 50})
+
+	return mapComponents(rgb, func(c float64) float64 { return c*(1-w-b) + w })
+}
+
+func srgbToHWB(v [3]float64) [3]float64 {
+	hsl := srgbToHSL(v)
+	w, b := min(v[0], v[1], v[2]), 1-max(v[0], v[1], v[2])
+
+	if w+b >= 1-gamutEpsilon {
+		hsl[0] = math.NaN()
+	}
+
+	return [3]float64{hsl[0], w * 100, b * 100}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func normaliseHue(h float64) float64 {
+	if math.IsNaN(h) {
+		return 0
+	}
+
+	h = math.Mod(h, 360)
+
+	if h < 0 {
+		h += 360
+	}
+
+	return h
+}
+
+func labToXYZD50(v [3]float64) [3]float64 {
+	f1 := (v[0] + 16) / 116
+	f0 := v[1]/500 + f1
+	f2 := f1 - v[2]/200
+
+	inverse := func(f float64) float64 {
+		if f3 := f * f * f; f3 > labEpsilon {
+			return f3
+		}
+
+		return (116*f - 16) / labKappa
+	}
+
+	y := v[0] / labKappa
+
+	if v[0] > labKappa*labEpsilon {
+		y = f1 * f1 * f1
+	}
+
+	return [3]float64{inverse(f0) * whiteD50[0], y * whiteD50[1], inverse(f2) * whiteD50[2]}
+}
+
+func xyzD50ToLab(v [3]float64) [3]float64 {
+	f := func(n int) float64 {
+		c := v[n] / whiteD50[n]
+
+		if c > labEpsilon {
+			return math.Cbrt(c)
+		}
+
+		return (labKappa*c + 16) / 116
+	}
+
+	f0, f1, f2 := f(0), f(1), f(2)
+
+	return [3]float64{116*f1 - 16, 500 * (f0 - f1), 200 * (f1 - f2)}
+}
+
+func okLabToXYZ(v [3]float64) [3]float64 {
+	return okLMSToXYZ.multiply(mapComponents(okLabToOKLMS.multiply(v), func(c float64) float64 { return c * c * c }))
+}
+
+func xyzToOKLab(v [3]float64) [3]float64 {
+	return okLMSToOKLab.multiply(mapComponents(xyzToOKLMS.multiply(v), math.Cbrt))
+}
+
+func polarToRectangular(v [3]float64) [3]float64 {
+	h := normaliseHue(v[2]) * math.Pi / 180
+
+	return [3]float64{v[0], v[1] * math.Cos(h), v[1] * math.Sin(h)}
+}
+
+func rectangularToPolar(v [3]float64, epsilon float64) [3]float64 {
+	c := math.Hypot(v[1], v[2])
+	h := math.NaN()
+
+	if c > epsilon {
+		h = normaliseHue(math.Atan2(v[2], v[1]) * 180 / math.Pi)
+	}
+
+	return [3]float64{v[0], c, h}
+}
+
+func toXYZD65(space ColorSpace, v [3]float64) [3]float64 {
+	switch space {
+	case ColorSpaceSRGB:
+		return linearSRGBToXYZ.multiply(mapComponents(v, srgbToLinear))
+	case ColorSpaceSRGBLinear:
+		return linearSRGBToXYZ.multiply(v)
+	case ColorSpaceDisplayP3:
+		return linearDisplayP3ToXYZ.multiply(mapComponents(v, srgbToLinear))
+	case ColorSpaceA98RGB:
+		return linearA98RGBToXYZ.multiply(mapComponents(v, a98RGBToLinear))
+	case ColorSpaceProPhotoRGB:
+		return xyzD50ToD65.multiply(linearProPhotoRGBToXYZD50.multiply(mapComponents(v, proPhotoRGBToLinear)))
+	case ColorSpaceRec2020:
+		return linearRec2020ToXYZ.multiply(mapComponents(v, rec2020ToLinear))
+	case ColorSpaceXYZD50:
+		return xyzD50ToD65.multiply(v)
+	case ColorSpaceLab:
+		return xyzD50ToD65.multiply(labToXYZD50(v))
+	case ColorSpaceLCH:
+		return xyzD50ToD65.multiply(labToXYZD50(polarToRectangular(v)))
+	case ColorSpaceOKLab:
+		return okLabToXYZ(v)
+	case ColorSpaceOKLCH:
+		return okLabToXYZ(polarToRectangular(v))
+	case ColorSpaceHSL:
+		return toXYZD65(ColorSpaceSRGB, hslToSRGB(v))
+	case ColorSpaceHWB:
+		return toXYZD65(ColorSpaceSRGB, hwbToSRGB(v))
+	}
+
+	return v
+}
+
+func fromXYZD65(space ColorSpace, v [3]float64) [3]float64 {
+	switch space {
+	case ColorSpaceSRGB:
+		return mapComponents(xyzToLinearSRGB.multiply(v), linearToSRGB)
+	case ColorSpaceSRGBLinear:
+		return xyzToLinearSRGB.multiply(v)
+	case ColorSpaceDisplayP3:
+		return mapComponents(xyzToLinearDisplayP3.multiply(v), linearToSRGB)
+	case ColorSpaceA98RGB:
+		return mapComponents(xyzToLinearA98RGB.multiply(v), linearToA98RGB)
+	case ColorSpaceProPhotoRGB:
+		return mapComponents(xyzD50ToLinearProPhotoRGB.multiply(xyzD65ToD50.multiply(v)), linearToProPhotoRGB)
+	case ColorSpaceRec2020:
+		return mapComponents(xyzToLinearRec2020.multiply(v), linearToRec2020)
+	case ColorSpaceXYZD50:
+		return xyzD65ToD50.multiply(v)
+	case ColorSpaceLab:
+		return xyzD50ToLab(xyzD65ToD50.multiply(v))
+	case ColorSpaceLCH:
+		return rectangularToPolar(xyzD50ToLab(xyzD65ToD50.multiply(v)), 0.0015)
+	case ColorSpaceOKLab:
+		return xyzToOKLab(v)
+	case ColorSpaceOKLCH:
+		return rectangularToPolar(xyzToOKLab(v), 0.000004)
+	case ColorSpaceHSL:
+		return srgbToHSL(fromXYZD65(ColorSpaceSRGB, v))
+	case ColorSpaceHWB:
+		return srgbToHWB(fromXYZD65(ColorSpaceSRGB, v))
+	}
+
+	return v
+}
+
+// Convert returns the Color converted to the given ColorSpace.
+//
+// Missing components are treated as zero. Hue components of achromatic
+// colours are set to NaN, as they are powerless. The resulting colour may be
+// outside the gamut of the target space; use ToGamut to map it into range.
+func (col Color) Convert(space ColorSpace) Color {
+	if col.CurrentColor || col.Space == space {
+		return col
+	}
+
+	components := mapComponents(col.Components, func(c float64) float64 {
+		if math.IsNaN(c) {
+			return 0
+		}
+
+		return c
+	})
+
+	return Color{
+		Space:      space,
+		Components: fromXYZD65(space, toXYZD65(col.Space, components)),
+		Alpha:      col.Alpha,
+	}
+}
+
+func gamutSpace(space ColorSpace) (ColorSpace, bool) {
+	switch space {
+	case ColorSpaceHSL, ColorSpaceHWB:
+		return ColorSpaceSRGB, true
+	case ColorSpaceSRGB, ColorSpaceSRGBLinear, ColorSpaceDisplayP3, ColorSpaceA98RGB, ColorSpaceProPhotoRGB, ColorSpaceRec2020:
+		return space, true
+	}
+
+	return space, false
+}
+
+// InGamut returns true if the Color can be represented in the given
+// ColorSpace without any components being out of range.
+//
+// The Lab, LCH, OKLab, OKLCH, and XYZ spaces are unbounded, so every colour is
+// within their gamut.
+func (col Color) InGamut(space ColorSpace) bool {
+	gamut, bounded := gamutSpace(space)
+	if !bounded || col.CurrentColor {
+		return true
+	}
+
+	for _, c := range col.Convert(gamut).Components {
+		if c < -gamutEpsilon || c > 1+gamutEpsilon {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (col Color) clip() Color {
+	col.Components = mapComponents(col.Components, func(c float64) float64 { return clamp(c, 0, 1) })
+
+	return col
+}
+
+func deltaEOK(a, b Color) float64 {
+	x, y := a.Convert(ColorSpaceOKLab).Components, b.Convert(ColorSpaceOKLab).Components
+
+	return math.Sqrt((x[0]-y[0])*(x[0]-y[0]) + (x[1]-y[1])*(x[1]-y[1]) + (x[2]-y[2])*(x[2]-y[2]))
+}
+
+// ToGamut returns the Color converted to the given ColorSpace and mapped into
+// its gamut, using the CSS Color 4 algorithm that reduces OKLCH chroma until
+// the clipped colour is no longer noticeably different.
+func (col Color) ToGamut(space ColorSpace) Color {
+	const (
+		jnd     = 0.02
+		epsilon = 0.0001
+	)
+
+	gamut, bounded := gamutSpace(space)
+	if !bounded || col.CurrentColor {
+		return col.Convert(space)
+	}
+
+	origin := col.Convert(ColorSpaceOKLCH)
+
+	if origin.Components[0] >= 1 {
+		return Color{Space: ColorSpaceOKLab, Components: [3]float64{1, 0, 0}, Alpha: col.Alpha}.Convert(space)
+	} else if origin.Components[0] <= 0 {
+		return Color{Space: ColorSpaceOKLab, Alpha: col.Alpha}.Convert(space)
+	} else if col.InGamut(gamut) {
+		return col.Convert(space)
+	}
+
+	current := origin
+	clipped := current.Convert(gamut).clip()
+
+	if deltaEOK(clipped, current) < jnd {
+		return clipped.Convert(space)
+	}
+
+	low, high, lowInGamut := 0.0, current.Components[1], true
+
+	for high-low > epsilon {
+		chroma := (low + high) / 2
+		current.Components[1] = chroma
+
+		if lowInGamut && current.InGamut(gamut) {
+			low = chroma
+
+			continue
+		}
+
+		clipped = current.Convert(gamut).clip()
+
+		if e := deltaEOK(clipped, current); e < jnd {
+			if jnd-e < epsilon {
+				break
+			}
+
+			lowInGamut = false
+			low = chroma
+		} else {
+			high = chroma
+		}
+	}
+
+	return clipped.Convert(space)
+}
@@ -0,0 +1,240 @@
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// HueInterpolation determines how hues are interpolated when mixing colours
+// in a polar colour space.
+type HueInterpolation uint8
+
+// Hue interpolation methods.
+const (
+	HueShorter HueInterpolation = iota
+	HueLonger
+	HueIncreasing
+	HueDecreasing
+)
+
+var hueInterpolationNames = [...]string{
+	HueShorter:    "shorter",
+	HueLonger:     "longer",
+	HueIncreasing: "increasing",
+	HueDecreasing: "decreasing",
+}
+
+// String returns the CSS name of the HueInterpolation method.
+func (h HueInterpolation) String() string {
+	if int(h) < len(hueInterpolationNames) {
+		return hueInterpolationNames[h]
+	}
+
+	return "unknown"
+}
+
+// Mix interpolates between the Color and another, in the given ColorSpace,
+// returning a Color in that space.
+//
+// The amount is the proportion of the other colour, in the range 0-1. Missing
+// components take the value from the other colour, and the colours are
+// interpolated with premultiplied alpha, per CSS Color 4.
+func (col Color) Mix(other Color, amount float64, space ColorSpace, hue HueInterpolation) Color {
+	a, b := col.Convert(space), other.Convert(space)
+	hueIndex := hueComponentIndex(space)
+
+	alphaA, alphaB := carryForward(a.Alpha, b.Alpha)
+	alpha := alphaA + (alphaB-alphaA)*amount
+	mixed := Color{Space: space, Alpha: alpha}
+
+	if math.IsNaN(alpha) {
+		alphaA, alphaB, alpha = 1, 1, 1
+	}
+
+	for n := range a.Components {
+		x, y := carryForward(a.Components[n], b.Components[n])
+
+		if n == hueIndex {
+			x, y = fixupHues(x, y, hue)
+			mixed.Components[n] = x + (y-x)*amount
+
+			if !math.IsNaN(mixed.Components[n]) {
+				mixed.Components[n] = normaliseHue(mixed.Components[n])
+			}
+
+			continue
+		}
+
+		v := x*alphaA + (y*alphaB-x*alphaA)*amount
+
+		if alpha != 0 {
+			v /= alpha
+		}
+
+		mixed.Components[n] = v
+	}
+
+	return mixed
+}
+
+func carryForward(a, b float64) (float64, float64) {
+	if math.IsNaN(a) {
+		return b, b
+	} else if math.IsNaN(b) {
+		return a, a
+	}
+
+	return a, b
+}
+
+func fixupHues(a, b float64, method HueInterpolation) (float64, float64) {
+	if math.IsNaN(a) {
+		return a, b
+	}
+
+	a, b = normaliseHue(a), normaliseHue(b)
+
+	switch d := b - a; method {
+	case HueShorter:
+		if d > 180 {
+			a += 360
+		} else if d < -180 {
+			b += 360
+		}
+	case HueLonger:
+		if 0 < d && d < 180 {
+			a += 360
+		} else if -180 < d && d <= 0 {
+			b += 360
+		}
+	case HueIncreasing:
+		if d < 0 {
+			b += 360
+		}
+	case HueDecreasing:
+		if d > 0 {
+			a += 360
+		}
+	}
+
+	return a, b
+}
+
+type mixComponent struct {
+	color      Color
+	percentage *float64
+}
+
+func (col *Color) parseMix(args Tokens) error {
+	parts := splitCommas(args)
+	space, hue := ColorSpaceOKLab, HueShorter
+
+	if method := componentValues(parts[0]); len(method) > 0 && method[0][0].Type == TokenIdent && strings.EqualFold(method[0][0].Data, "in") {
+		var err error
+
+		if space, hue, err = parseInterpolationMethod(method[1:]); err != nil {
+			return err
+		}
+
+		parts = parts[1:]
+	}
+
+	if len(parts) != 2 {
+		return ErrInvalidColor
+	}
+
+	var components [2]mixComponent
+
+	for n, part := range parts {
+		if err := components[n].parse(part); err != nil {
+			return err
+		}
+	}
+
+	p1, p2 := components[0].percentage, components[1].percentage
+
+	switch {
+	case p1 == nil && p2 == nil:
+		p1, p2 = new(float64), new(float64)
+		*p1, *p2 = 50, 50
+	case p1 == nil:
+		p1 = new(float64)
+		*p1 = 100 - *p2
+	case p2 == nil:
+		p2 = new(float64)
+		*p2 = 100 - *p1
+	}
+
+	sum := *p1 + *p2
+	if sum == 0 {
+		return ErrInvalidColor
+	}
+
+	*col = components[0].color.Mix(components[1].color, *p2/sum, space, hue)
+
+	if sum < 100 && !math.IsNaN(col.Alpha) {
+		col.Alpha *= sum / 100
+	}
+
+	return nil
+}
+
+func parseInterpolationMethod(method []Tokens) (ColorSpace, HueInterpolation, error) {
+	if len(method) == 0 || method[0][0].Type != TokenIdent {
+		return 0, 0, ErrInvalidColor
+	}
+
+	space, ok := ParseColorSpace(method[0][0].Data)
+	if !ok {
+		return 0, 0, ErrInvalidColor
+	} else if len(method) == 1 {
+		return space, HueShorter, nil
+	} else if len(method) != 3 || hueComponentIndex(space) < 0 || method[1][0].Type != TokenIdent || method[2][0].Type != TokenIdent || !strings.EqualFold(method[2][0].Data, "hue") {
+		return 0, 0, ErrInvalidColor
+	}
+
+	for h, name := range hueInterpolationNames {
+		if strings.EqualFold(method[1][0].Data, name) {
+			return space, HueInterpolation(h), nil
+		}
+	}
+
+	return 0, 0, ErrInvalidColor
+}
+
+func (m *mixComponent) parse(part Tokens) error {
+	values := componentValues(part)
+
+	if len(values) == 0 || len(values) > 2 {
+		return ErrInvalidColor
+	}
+
+	if len(values) == 2 {
+		if values[0][0].Type == TokenPercentage {
+			values[0], values[1] = values[1], values[0]
+		}
+
+		if values[1][0].Type != TokenPercentage {
+			return ErrInvalidColor
+		}
+
+		p, err := strconv.ParseFloat(strings.TrimSuffix(values[1][0].Data, "%"), 64)
+		if err != nil || p < 0 || p > 100 {
+			return ErrInvalidColor
+		}
+
+		m.percentage = &p
+	}
+
+	col, err := ParseColor(values[0])
+	if err != nil {
+		return err
+	} else if col.CurrentColor {
+		return ErrUnresolvedColor
+	}
+
+	m.color = col
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package css
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestColorMix(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Space  ColorSpace
+		Output string
+		Err    error
+	}{
+		{ // 1
+			Input:  "color-mix(in srgb, red, blue)",
+			Output: "rgb(128, 0, 128)",
+		},
+		{ // 2
+			Input:  "color-mix(in srgb, red 25%, blue)",
+			Output: "rgb(64, 0, 191)",
+		},
+		{ // 3
+			Input:  "color-mix(in srgb, 25% red, blue 25%)",
+			Output: "rgba(128, 0, 128, 0.5)",
+		},
+		{ // 4
+			Input:  "color-mix(in srgb, red 60%, blue 60%)",
+			Output: "rgb(128, 0, 128)",
+		},
+		{ // 5
+			Input:  "color-mix(in srgb, rgb(255 0 0 / 0.5), blue)",
+			Output: "rgba(85, 0, 170, 0.75)",
+		},
+		{ // 6
+			Input:  "color-mix(in hsl, red, blue)",
+			Space:  ColorSpaceHSL,
+			Output: "rgb(255, 0, 255)",
+		},
+		{ // 7
+			Input:  "color-mix(in hsl longer hue, red, blue)",
+			Space:  ColorSpaceHSL,
+			Output: "rgb(0, 255, 0)",
+		},
+		{ // 8
+			Input:  "color-mix(in hsl increasing hue, hsl(300 100% 50%), hsl(60 100% 50%))",
+			Space:  ColorSpaceHSL,
+			Output: "rgb(255, 0, 0)",
+		},
+		{ // 9
+			Input:  "color-mix(in hsl decreasing hue, hsl(300 100% 50%), hsl(60 100% 50%))",
+			Space:  ColorSpaceHSL,
+			Output: "rgb(0, 255, 255)",
+		},
+		{ // 10
+			Input:  "color-mix(in oklch, oklch(0.6 0.2 30), white)",
+			Space:  ColorSpaceOKLCH,
+			Output: "oklch(0.8 0.1 30)",
+		},
+		{ // 11
+			Input:  "color-mix(in lab, lab(40 20 none), lab(60 none 10))",
+			Space:  ColorSpaceLab,
+			Output: "lab(50 20 10)",
+		},
+		{ // 12
+			Input:  "color-mix(red, red)",
+			Space:  ColorSpaceOKLab,
+			Output: "oklab(0.627955 0.224863 0.125846)",
+		},
+		{ // 13
+			Input: "color-mix(in srgb, currentcolor, red)",
+			Err:   ErrUnresolvedColor,
+		},
+		{ // 14
+			Input: "color-mix(in srgb, red 0%, blue 0%)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 15
+			Input: "color-mix(in srgb longer hue, red, blue)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 16
+			Input: "color-mix(in srgb, red 150%, blue)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 17
+			Input: "color-mix(in srgb, red, blue, green)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 18
+			Input: "color-mix(in unknown, red, blue)",
+			Err:   ErrInvalidColor,
+		},
+	} {
+		col, err := ParseColor(tokenise(t, test.Input))
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if col.Space != test.Space {
+			t.Errorf("test %d: expecting space %s, got %s", n+1, test.Space, col.Space)
+		} else if str := col.String(); str != test.Output {
+			t.Errorf("test %d: expecting %q, got %q", n+1, test.Output, str)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+var relativeChannelNames = [...][3]string{
+	ColorSpaceSRGB:        {"r", "g", "b"},
+	ColorSpaceSRGBLinear:  {"r", "g", "b"},
+	ColorSpaceDisplayP3:   {"r", "g", "b"},
+	ColorSpaceA98RGB:      {"r", "g", "b"},
+	ColorSpaceProPhotoRGB: {"r", "g", "b"},
+	ColorSpaceRec2020:     {"r", "g", "b"},
+	ColorSpaceXYZD50:      {"x", "y", "z"},
+	ColorSpaceXYZD65:      {"x", "y", "z"},
+	ColorSpaceLab:         {"l", "a", "b"},
+	ColorSpaceLCH:         {"l", "c", "h"},
+	ColorSpaceOKLab:       {"l", "a", "b"},
+	ColorSpaceOKLCH:       {"l", "c", "h"},
+	ColorSpaceHSL:         {"h", "s", "l"},
+	ColorSpaceHWB:         {"h", "w", "b"},
+}
+
+var colorFunctionSpaces = map[string]ColorSpace{
+	"rgb":   ColorSpaceSRGB,
+	"rgba":  ColorSpaceSRGB,
+	"hsl":   ColorSpaceHSL,
+	"hsla":  ColorSpaceHSL,
+	"hwb":   ColorSpaceHWB,
+	"lab":   ColorSpaceLab,
+	"lch":   ColorSpaceLCH,
+	"oklab": ColorSpaceOKLab,
+	"oklch": ColorSpaceOKLCH,
+}
+
+func parseOriginColor(args Tokens) (*Color, Tokens, error) {
+	c := newCSSParserFromTokens(args)
+
+	c.AcceptRunWhitespace()
+
+	if !c.AcceptIdent("from") {
+		return nil, args, nil
+	}
+
+	c.AcceptRunWhitespace()
+
+	d := c.NewGoal()
+
+	if !d.AcceptComponentValue() {
+		return nil, nil, ErrInvalidColor
+	}
+
+	origin, err := ParseColor(d.ToTokens())
+	if err != nil {
+		return nil, nil, err
+	} else if origin.CurrentColor {
+		return nil, nil, ErrUnresolvedColor
+	}
+
+	return &origin, args[len(c)+len(d):], nil
+}
+
+// substituteChannels replaces the channel keywords in the arguments of a
+// relative colour function with the values of the origin colour, converted to
+// the colour space of the function, evaluating any calc() expressions that
+// reference them.
+func (col *Color) substituteChannels(name, spaceName string, args Tokens) (Tokens, error) {
+	space, ok := colorFunctionSpaces[name]
+	if name == "color" {
+		space, ok = ParseColorSpace(spaceName)
+	}
+
+	if !ok {
+		return nil, ErrInvalidColor
+	}
+
+	converted := col.Convert(space)
+	channels := map[string]float64{"alpha": col.Alpha}
+
+	for n, c := range converted.Components {
+		if math.IsNaN(c) {
+			c = 0
+		}
+
+		if space == ColorSpaceSRGB && name != "color" {
+			c *= 255
+		}
+
+		channels[relativeChannelNames[space][n]] = c
+	}
+
+	if math.IsNaN(col.Alpha) {
+		channels["alpha"] = 0
+	}
+
+	return substituteChannelTokens(args, channels)
+}
+
+func substituteChannelTokens(args Tokens, channels map[string]float64) (Tokens, error) {
+	var substituted Tokens
+
+	c := newCSSParserFromTokens(args)
+
+	for {
+		d := c.NewGoal()
+
+		if !d.AcceptComponentValue() {
+			return substituted, nil
+		}
+
+		value := d.ToTokens()
+
+		c.Score(d)
+
+		switch tk := value[0]; tk.Type {
+		case TokenIdent:
+			if v, ok := channels[strings.ToLower(tk.Data)]; ok {
+				substituted = append(substituted, quantityToken(tk, Quantity{Value: v}))
+
+				continue
+			}
+		case TokenFunction:
+			if isMathFunction(value) {
+				if value[len(value)-1].Type != TokenCloseParen {
+					return nil, ErrInvalidColor
+				}
+
+				inner, err := substituteChannelTokens(value[1:len(value)-1], channels)
+				if err != nil {
+					return nil, err
+				}
+
+				m, err := ParseMath(append(append(Tokens{tk}, inner...), value[len(value)-1]))
+				if err != nil {
+					return nil, err
+				}
+
+				q, err := m.Evaluate(nil)
+				if err != nil {
+					return nil, err
+				}
+
+				// NaN is censored to zero, as for any top-level calculation.
+				if math.IsNaN(q.Value) {
+					q.Value = 0
+				}
+
+				substituted = append(substituted, quantityToken(tk, q))
+
+				continue
+			}
+		case TokenOpenParen:
+			if value[len(value)-1].Type != TokenCloseParen {
+				return nil, ErrInvalidColor
+			}
+
+			inner, err := substituteChannelTokens(value[1:len(value)-1], channels)
+			if err != nil {
+				return nil, err
+			}
+
+			substituted = append(append(append(substituted, tk), inner...), value[len(value)-1])
+
+			continue
+		}
+
+		substituted = append(substituted, value...)
+	}
+}
+
+func quantityToken(pos Token, q Quantity) Token {
+	pos.Token = parser.Token{Type: TokenNumber, Data: strconv.FormatFloat(q.Value, 'f', -1, 64) + q.Unit}
+
+	switch q.Category() {
+	case UnitNumber:
+	case UnitPercentage:
+		pos.Type = TokenPercentage
+	default:
+		pos.Type = TokenDimension
+	}
+
+	return pos
+}
+
+func splitCommas(ts Tokens) []Tokens {
+	var (
+		parts []Tokens
+		part  Tokens
+	)
+
+	for _, value := range componentValues(ts) {
+		if value[0].Type == TokenComma {
+			parts = append(parts, part)
+			part = nil
+		} else {
+			part = append(part, value...)
+			part = append(part, Token{Token: parser.Token{Type: TokenWhitespace, Data: " "}})
+		}
+	}
+
+	return append(parts, part)
+}
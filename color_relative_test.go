@@ -0,0 +1,98 @@
+package css
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRelativeColor(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output string
+		Err    error
+	}{
+		{ // 1
+			Input:  "rgb(from red r g b / 50%)",
+			Output: "rgba(255, 0, 0, 0.5)",
+		},
+		{ // 2
+			Input:  "rgb(from #336699 b g r)",
+			Output: "rgb(153, 102, 51)",
+		},
+		{ // 3
+			Input:  "rgb(from rgb(0 0 0 / 0.3) r 255 b)",
+			Output: "rgba(0, 255, 0, 0.3)",
+		},
+		{ // 4
+			Input:  "rgb(from red calc(r / 2) g calc((r + 255) / 2 - 1))",
+			Output: "rgb(128, 0, 254)",
+		},
+		{ // 5
+			Input:  "rgb(from red r max(g, 51) clamp(20, b, 255) / calc(alpha * 0.5))",
+			Output: "rgba(255, 51, 20, 0.5)",
+		},
+		{ // 6
+			Input:  "hsl(from red calc(h + 120) s l)",
+			Output: "rgb(0, 255, 0)",
+		},
+		{ // 7
+			Input:  "hwb(from white h w calc(b + 50))",
+			Output: "rgb(170, 170, 170)",
+		},
+		{ // 8
+			Input:  "oklch(from oklch(0.5 0.1 120) calc(l + 0.2) c h)",
+			Output: "oklch(0.7 0.1 120)",
+		},
+		{ // 9
+			Input:  "lab(from lab(50 10 -10) l b a)",
+			Output: "lab(50 -10 10)",
+		},
+		{ // 10
+			Input:  "color(from color(display-p3 0.5 0.25 0) display-p3 g r b)",
+			Output: "color(display-p3 0.25 0.5 0)",
+		},
+		{ // 11
+			Input:  "color(from white xyz-d65 x y z)",
+			Output: "color(xyz-d65 0.950456 1 1.089058)",
+		},
+		{ // 12
+			Input:  "rgb(from color-mix(in srgb, red, blue) r g b)",
+			Output: "rgb(128, 0, 128)",
+		},
+		{ // 13
+			Input: "rgb(from currentcolor r g b)",
+			Err:   ErrUnresolvedColor,
+		},
+		{ // 14
+			Input: "rgb(from red r, g, b)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 15
+			Input: "rgb(from red x g b)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 16
+			Input: "rgb(from red calc(r + 1px) g b)",
+			Err:   ErrInvalidMathType,
+		},
+		{ // 17
+			Input: "rgb(from r g b)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 18
+			Input:  "rgb(from red calc(g / 0) calc(r / 0) b)",
+			Output: "rgb(0, 255, 0)",
+		},
+	} {
+		col, err := ParseColor(tokenise(t, test.Input))
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if str := col.String(); str != test.Output {
+			t.Errorf("test %d: expecting %q, got %q", n+1, test.Output, str)
+		}
+	}
+}
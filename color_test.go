@@ -0,0 +1,398 @@
+package css
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func closeComponents(a, b [3]float64, epsilon float64) bool {
+	for n := range a {
+		if math.IsNaN(a[n]) != math.IsNaN(b[n]) || math.Abs(a[n]-b[n]) > epsilon {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestParseColor(t *testing.T) {
+	nan := math.NaN()
+
+	for n, test := range [...]struct {
+		Input        string
+		Space        ColorSpace
+		Components   [3]float64
+		Alpha        float64
+		CurrentColor bool
+		Err          error
+	}{
+		{ // 1
+			Input:      "red",
+			Components: [3]float64{1, 0, 0},
+			Alpha:      1,
+		},
+		{ // 2
+			Input:      "RebeccaPurple",
+			Components: [3]float64{0.4, 0.2, 0.6},
+			Alpha:      1,
+		},
+		{ // 3
+			Input: "transparent",
+		},
+		{ // 4
+			Input:        "currentColor",
+			Alpha:        1,
+			CurrentColor: true,
+		},
+		{ // 5
+			Input:      "#f00",
+			Components: [3]float64{1, 0, 0},
+			Alpha:      1,
+		},
+		{ // 6
+			Input:      "#0f08",
+			Components: [3]float64{0, 1, 0},
+			Alpha:      0x88 / 255.0,
+		},
+		{ // 7
+			Input:      "#336699",
+			Components: [3]float64{0.2, 0.4, 0.6},
+			Alpha:      1,
+		},
+		{ // 8
+			Input:      "#33669980",
+			Components: [3]float64{0.2, 0.4, 0.6},
+			Alpha:      0x80 / 255.0,
+		},
+		{ // 9
+			Input:      "rgb(255, 0, 51)",
+			Components: [3]float64{1, 0, 0.2},
+			Alpha:      1,
+		},
+		{ // 10
+			Input:      "rgba(100%, 0%, 20%, 0.5)",
+			Components: [3]float64{1, 0, 0.2},
+			Alpha:      0.5,
+		},
+		{ // 11
+			Input:      "rgb(255 0 51 / 50%)",
+			Components: [3]float64{1, 0, 0.2},
+			Alpha:      0.5,
+		},
+		{ // 12
+			Input:      "rgb(300 none -10)",
+			Components: [3]float64{1, nan, 0},
+			Alpha:      1,
+		},
+		{ // 13
+			Input:      "hsl(120, 100%, 50%)",
+			Space:      ColorSpaceHSL,
+			Components: [3]float64{120, 100, 50},
+			Alpha:      1,
+		},
+		{ // 14
+			Input:      "hsl(0.5turn 100 25 / 0.25)",
+			Space:      ColorSpaceHSL,
+			Components: [3]float64{180, 100, 25},
+			Alpha:      0.25,
+		},
+		{ // 15
+			Input:      "hwb(90deg 10% 20%)",
+			Space:      ColorSpaceHWB,
+			Components: [3]float64{90, 10, 20},
+			Alpha:      1,
+		},
+		{ // 16
+			Input:      "lab(50% 100% -50%)",
+			Space:      ColorSpaceLab,
+			Components: [3]float64{50, 125, -62.5},
+			Alpha:      1,
+		},
+		{ // 17
+			Input:      "lch(50 100% 270deg)",
+			Space:      ColorSpaceLCH,
+			Components: [3]float64{50, 150, 270},
+			Alpha:      1,
+		},
+		{ // 18
+			Input:      "oklab(75% 0.1 -50%)",
+			Space:      ColorSpaceOKLab,
+			Components: [3]float64{0.75, 0.1, -0.2},
+			Alpha:      1,
+		},
+		{ // 19
+			Input:      "oklch(0.7 0.15 none / none)",
+			Space:      ColorSpaceOKLCH,
+			Components: [3]float64{0.7, 0.15, nan},
+			Alpha:      nan,
+		},
+		{ // 20
+			Input:      "color(display-p3 1 0.5 0 / 0.5)",
+			Space:      ColorSpaceDisplayP3,
+			Components: [3]float64{1, 0.5, 0},
+			Alpha:      0.5,
+		},
+		{ // 21
+			Input:      "color(xyz 0.5 50% 0.25)",
+			Space:      ColorSpaceXYZD65,
+			Components: [3]float64{0.5, 0.5, 0.25},
+			Alpha:      1,
+		},
+		{ // 22
+			Input: "reddish",
+			Err:   ErrInvalidColor,
+		},
+		{ // 23
+			Input: "#12345",
+			Err:   ErrInvalidColor,
+		},
+		{ // 24
+			Input: "rgb(255, 0 0)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 25
+			Input: "rgb(255, 0%, 0)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 26
+			Input: "rgb(255, none, 0)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 27
+			Input: "hsl(120 100% 50% 1)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 28
+			Input: "hwb(120, 10%, 20%)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 29
+			Input: "color(lab 50 0 0)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 30
+			Input: "rgb(1 2 3 /)",
+			Err:   ErrInvalidColor,
+		},
+		{ // 31
+			Input: "red blue",
+			Err:   ErrUnexpectedToken,
+		},
+	} {
+		col, err := ParseColor(tokenise(t, test.Input))
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if col.CurrentColor != test.CurrentColor {
+			t.Errorf("test %d: expecting currentcolor %v, got %v", n+1, test.CurrentColor, col.CurrentColor)
+		} else if col.Space != test.Space {
+			t.Errorf("test %d: expecting space %s, got %s", n+1, test.Space, col.Space)
+		} else if !closeComponents(col.Components, test.Components, 1e-9) {
+			t.Errorf("test %d: expecting components %v, got %v", n+1, test.Components, col.Components)
+		} else if !closeComponents([3]float64{col.Alpha}, [3]float64{test.Alpha}, 1e-9) {
+			t.Errorf("test %d: expecting alpha %v, got %v", n+1, test.Alpha, col.Alpha)
+		}
+	}
+}
+
+func TestColorConvert(t *testing.T) {
+	nan := math.NaN()
+
+	for n, test := range [...]struct {
+		Input      string
+		Space      ColorSpace
+		Components [3]float64
+		Epsilon    float64
+	}{
+		{ // 1
+			Input:      "red",
+			Space:      ColorSpaceOKLCH,
+			Components: [3]float64{0.627955, 0.257683, 29.2339},
+			Epsilon:    0.0001,
+		},
+		{ // 2
+			Input:      "red",
+			Space:      ColorSpaceLab,
+			Components: [3]float64{54.2905, 80.8049, 69.891},
+			Epsilon:    0.01,
+		},
+		{ // 3
+			Input:      "white",
+			Space:      ColorSpaceXYZD65,
+			Components: [3]float64{0.950456, 1, 1.089058},
+			Epsilon:    0.0001,
+		},
+		{ // 4
+			Input:      "white",
+			Space:      ColorSpaceOKLCH,
+			Components: [3]float64{1, 0, nan},
+			Epsilon:    0.0001,
+		},
+		{ // 5
+			Input:      "gray",
+			Space:      ColorSpaceHSL,
+			Components: [3]float64{nan, 0, 50.196},
+			Epsilon:    0.001,
+		},
+		{ // 6
+			Input:      "hsl(120 100% 25%)",
+			Space:      ColorSpaceSRGB,
+			Components: [3]float64{0, 0.5, 0},
+			Epsilon:    1e-9,
+		},
+		{ // 7
+			Input:      "hwb(0 20% 20%)",
+			Space:      ColorSpaceSRGB,
+			Components: [3]float64{0.8, 0.2, 0.2},
+			Epsilon:    1e-9,
+		},
+		{ // 8
+			Input:      "hwb(0 60% 60%)",
+			Space:      ColorSpaceSRGB,
+			Components: [3]float64{0.5, 0.5, 0.5},
+			Epsilon:    1e-9,
+		},
+		{ // 9
+			Input:      "#00ffff",
+			Space:      ColorSpaceHWB,
+			Components: [3]float64{180, 0, 0},
+			Epsilon:    1e-9,
+		},
+		{ // 10
+			Input:      "color(display-p3 1 0 0)",
+			Space:      ColorSpaceSRGB,
+			Components: [3]float64{1.093, -0.2267, -0.1501},
+			Epsilon:    0.001,
+		},
+		{ // 11
+			Input:      "color(rec2020 0.5 0.5 0.5)",
+			Space:      ColorSpaceA98RGB,
+			Components: [3]float64{0.5417, 0.5417, 0.5417},
+			Epsilon:    0.001,
+		},
+		{ // 12
+			Input:      "lch(54.2905 106.8372 40.8526)",
+			Space:      ColorSpaceSRGB,
+			Components: [3]float64{1, 0, 0},
+			Epsilon:    0.001,
+		},
+	} {
+		col, err := ParseColor(tokenise(t, test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		if got := col.Convert(test.Space); got.Space != test.Space {
+			t.Errorf("test %d: expecting space %s, got %s", n+1, test.Space, got.Space)
+		} else if !closeComponents(got.Components, test.Components, test.Epsilon) {
+			t.Errorf("test %d: expecting components %v, got %v", n+1, test.Components, got.Components)
+		}
+	}
+}
+
+func TestColorRoundTrip(t *testing.T) {
+	spaces := []ColorSpace{
+		ColorSpaceSRGB, ColorSpaceSRGBLinear, ColorSpaceDisplayP3, ColorSpaceA98RGB,
+		ColorSpaceProPhotoRGB, ColorSpaceRec2020, ColorSpaceXYZD50, ColorSpaceXYZD65,
+		ColorSpaceLab, ColorSpaceLCH, ColorSpaceOKLab, ColorSpaceOKLCH, ColorSpaceHSL, ColorSpaceHWB,
+	}
+	col := Color{Components: [3]float64{0.2, 0.6, 0.4}, Alpha: 1}
+
+	for _, space := range spaces {
+		if got := col.Convert(space).Convert(ColorSpaceSRGB); !closeComponents(got.Components, col.Components, 1e-6) {
+			t.Errorf("%s: expecting components %v, got %v", space, col.Components, got.Components)
+		}
+	}
+}
+
+func TestColorGamut(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		InSRGB bool
+		InP3   bool
+		Hex    string
+		String string
+	}{
+		{ // 1
+			Input:  "rgb(10 20 30)",
+			InSRGB: true,
+			InP3:   true,
+			Hex:    "#0a141e",
+			String: "rgb(10, 20, 30)",
+		},
+		{ // 2
+			Input:  "hsl(0 100% 50% / 0.5)",
+			InSRGB: true,
+			InP3:   true,
+			Hex:    "#ff000080",
+			String: "rgba(255, 0, 0, 0.5)",
+		},
+		{ // 3
+			Input:  "color(display-p3 1 0 0)",
+			InP3:   true,
+			Hex:    "#ff0b0c",
+			String: "color(display-p3 1 0 0)",
+		},
+		{ // 4
+			Input:  "oklch(0.7 0.4 150)",
+			Hex:    "#00c248",
+			String: "oklch(0.7 0.4 150)",
+		},
+		{ // 5
+			Input:  "lab(120 0 0)",
+			InSRGB: true,
+			InP3:   true,
+			Hex:    "#ffffff",
+			String: "lab(100 0 0)",
+		},
+		{ // 6
+			Input:  "oklab(0 0.1 0.1 / none)",
+			InSRGB: false,
+			InP3:   false,
+			Hex:    "#000000",
+			String: "oklab(0 0.1 0.1 / none)",
+		},
+		{ // 7
+			Input:  "rgb(none 128 255)",
+			InSRGB: true,
+			InP3:   true,
+			Hex:    "#0080ff",
+			String: "rgb(0, 128, 255)",
+		},
+		{ // 8
+			Input:  "hsl(none 100% 50%)",
+			InSRGB: true,
+			InP3:   true,
+			Hex:    "#ff0000",
+			String: "rgb(255, 0, 0)",
+		},
+		{ // 9
+			Input:  "lch(50 none 0)",
+			InSRGB: true,
+			InP3:   true,
+			Hex:    "#777777",
+			String: "lch(50 none 0)",
+		},
+	} {
+		col, err := ParseColor(tokenise(t, test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if in := col.InGamut(ColorSpaceSRGB); in != test.InSRGB {
+			t.Errorf("test %d: expecting sRGB gamut %v, got %v", n+1, test.InSRGB, in)
+		} else if in := col.InGamut(ColorSpaceDisplayP3); in != test.InP3 {
+			t.Errorf("test %d: expecting Display P3 gamut %v, got %v", n+1, test.InP3, in)
+		} else if hex := col.Hex(); hex != test.Hex {
+			t.Errorf("test %d: expecting hex %q, got %q", n+1, test.Hex, hex)
+		} else if str := col.String(); str != test.String {
+			t.Errorf("test %d: expecting string %q, got %q", n+1, test.String, str)
+		} else if mapped := col.ToGamut(ColorSpaceSRGB); !mapped.InGamut(ColorSpaceSRGB) {
+			t.Errorf("test %d: expecting mapped colour to be in gamut, got %v", n+1, mapped.Components)
+		}
+	}
+}
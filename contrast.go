@@ -0,0 +1,190 @@
+package css
+
+import "math"
+
+// WCAG 2.x minimum contrast ratios.
+const (
+	ContrastAALarge  = 3
+	ContrastAA       = 4.5
+	ContrastAAALarge = 4.5
+	ContrastAAA      = 7
+)
+
+// RelativeLuminance returns the WCAG 2.x relative luminance of the Color,
+// after mapping it into the sRGB gamut. The alpha component is ignored.
+func (col Color) RelativeLuminance() float64 {
+	rgb := col.ToGamut(ColorSpaceSRGB).Components
+
+	return 0.2126*srgbToLinear(rgb[0]) + 0.7152*srgbToLinear(rgb[1]) + 0.0722*srgbToLinear(rgb[2])
+}
+
+// composite returns the Color, in sRGB, drawn over the given opaque
+// background.
+func (col Color) composite(background Color) Color {
+	fg := col.ToGamut(ColorSpaceSRGB)
+	bg := background.ToGamut(ColorSpaceSRGB)
+	alpha := fg.Alpha
+
+	if math.IsNaN(alpha) {
+		alpha = 1
+	}
+
+	for n, c := range fg.Components {
+		if math.IsNaN(c) {
+			c = 0
+		}
+
+		fg.Components[n] = c*alpha + bg.Components[n]*(1-alpha)
+	}
+
+	fg.Alpha = 1
+
+	return fg
+}
+
+var opaqueWhite = Color{Components: [3]float64{1, 1, 1}, Alpha: 1}
+
+func opaquePair(foreground, background Color) (Color, Color) {
+	background = background.composite(opaqueWhite)
+
+	return foreground.composite(background), background
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio, in the range 1-21,
+// between a foreground and background Color.
+//
+// A translucent foreground is composited over the background, and a
+// translucent background is composited over white.
+func ContrastRatio(foreground, background Color) float64 {
+	fg, bg := opaquePair(foreground, background)
+	l1, l2 := fg.RelativeLuminance(), bg.RelativeLuminance()
+
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// APCAContrast returns the APCA (SAPC 0.0.98G) lightness contrast, Lc, of text
+// in the given Color against a background Color.
+//
+// The result is positive for dark text on a light background, and negative
+// for light text on a dark background; its magnitude ranges from 0 to roughly
+// 108. Translucent colours are composited as with ContrastRatio.
+func APCAContrast(text, background Color) float64 {
+	const (
+		blackThreshold = 0.022
+		blackClamp     = 1.414
+		deltaYMin      = 0.0005
+		scale          = 1.14
+		lowClip        = 0.1
+		lowOffset      = 0.027
+	)
+
+	fg, bg := opaquePair(text, background)
+	yText, yBackground := apcaLuminance(fg), apcaLuminance(bg)
+
+	if yText < blackThreshold {
+		yText += math.Pow(blackThreshold-yText, blackClamp)
+	}
+
+	if yBackground < blackThreshold {
+		yBackground += math.Pow(blackThreshold-yBackground, blackClamp)
+	}
+
+	if math.Abs(yBackground-yText) < deltaYMin {
+		return 0
+	}
+
+	if yBackground > yText {
+		if sapc := (math.Pow(yBackground, 0.56) - math.Pow(yText, 0.57)) * scale; sapc >= lowClip {
+			return (sapc - lowOffset) * 100
+		}
+	} else if sapc := (math.Pow(yBackground, 0.65) - math.Pow(yText, 0.62)) * scale; sapc <= -lowClip {
+		return (sapc + lowOffset) * 100
+	}
+
+	return 0
+}
+
+func apcaLuminance(col Color) float64 {
+	rgb := col.Components
+
+	return 0.2126729*math.Pow(rgb[0], 2.4) + 0.7151522*math.Pow(rgb[1], 2.4) + 0.072175*math.Pow(rgb[2], 2.4)
+}
+
+// ContrastIssue describes a QualifiedRule whose foreground and background
+// colours have insufficient contrast.
+type ContrastIssue struct {
+	Rule       *QualifiedRule
+	Color      *Declaration
+	Background *Declaration
+	Ratio      float64
+	APCA       float64
+}
+
+// CheckContrast walks the Sheet and returns a ContrastIssue for every
+// QualifiedRule that sets both a foreground colour and a background colour
+// whose WCAG 2.x contrast ratio is below the given minimum.
+//
+// The foreground is taken from the 'color' property, and the background from
+// either 'background-color', or a 'background' shorthand consisting of a
+// single colour. Where a property is declared more than once the winning
+// declaration, respecting '!important', is used. Values that cannot be
+// resolved statically, such as those containing var() or 'currentcolor', are
+// skipped.
+func (s *Sheet) CheckContrast(minRatio float64) []ContrastIssue {
+	var issues []ContrastIssue
+
+	s.walkRules(func(r *Rule) {
+		if r.QualifiedRule == nil {
+			return
+		}
+
+		var fg, bg *Declaration
+
+		for _, d := range r.QualifiedRule.Block.Declarations() {
+			switch d.Property() {
+			case "color":
+				fg = winningDeclaration(fg, d)
+			case "background-color", "background":
+				bg = winningDeclaration(bg, d)
+			}
+		}
+
+		if fg == nil || bg == nil {
+			return
+		}
+
+		foreground, err := ParseColor(fg.Value.Trim())
+		if err != nil || foreground.CurrentColor {
+			return
+		}
+
+		background, err := ParseColor(bg.Value.Trim())
+		if err != nil || background.CurrentColor {
+			return
+		}
+
+		if ratio := ContrastRatio(foreground, background); ratio < minRatio {
+			issues = append(issues, ContrastIssue{
+				Rule:       r.QualifiedRule,
+				Color:      fg,
+				Background: bg,
+				Ratio:      ratio,
+				APCA:       APCAContrast(foreground, background),
+			})
+		}
+	})
+
+	return issues
+}
+
+func winningDeclaration(current, d *Declaration) *Declaration {
+	if current == nil || d.Important || !current.Important {
+		return d
+	}
+
+	return current
+}
@@ -0,0 +1,131 @@
+package css
+
+import (
+	"math"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestContrast(t *testing.T) {
+	for n, test := range [...]struct {
+		Foreground, Background string
+		Ratio, APCA            float64
+	}{
+		{ // 1
+			Foreground: "black",
+			Background: "white",
+			Ratio:      21,
+			APCA:       106.04,
+		},
+		{ // 2
+			Foreground: "white",
+			Background: "black",
+			Ratio:      21,
+			APCA:       -107.88,
+		},
+		{ // 3
+			Foreground: "#767676",
+			Background: "#fff",
+			Ratio:      4.54,
+			APCA:       71.57,
+		},
+		{ // 4
+			Foreground: "#888",
+			Background: "#fff",
+			Ratio:      3.54,
+			APCA:       63.06,
+		},
+		{ // 5
+			Foreground: "red",
+			Background: "red",
+			Ratio:      1,
+			APCA:       0,
+		},
+		{ // 6
+			Foreground: "rgb(0 0 0 / 0.5)",
+			Background: "white",
+			Ratio:      3.98,
+			APCA:       67.13,
+		},
+		{ // 7
+			Foreground: "black",
+			Background: "transparent",
+			Ratio:      21,
+			APCA:       106.04,
+		},
+	} {
+		fg, err := ParseColor(tokenise(t, test.Foreground))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		bg, err := ParseColor(tokenise(t, test.Background))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		if ratio := ContrastRatio(fg, bg); math.Abs(ratio-test.Ratio) > 0.01 {
+			t.Errorf("test %d: expecting ratio %v, got %v", n+1, test.Ratio, ratio)
+		} else if apca := APCAContrast(fg, bg); math.Abs(apca-test.APCA) > 0.01 {
+			t.Errorf("test %d: expecting APCA %v, got %v", n+1, test.APCA, apca)
+		}
+	}
+}
+
+func TestCheckContrast(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(`
+.a { color: #777; background-color: white }
+.b { color: black; background: #fff }
+.c { color: #777; background-color: var(--bg) }
+.d { color: #eee !important; background-color: black; color: #222 }
+.e { color: currentcolor; background-color: white }
+@media screen {
+	.f { background: yellow; color: white }
+}
+.g {
+	color: black;
+	background-color: white;
+
+	.h { color: #aaa; background-color: #fff }
+}
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	issues := s.CheckContrast(ContrastAA)
+
+	if len(issues) != 3 {
+		t.Fatalf("expecting 3 issues, got %d", len(issues))
+	}
+
+	for n, expected := range [...]struct {
+		Selector   string
+		Color      string
+		Background string
+		Line       uint64
+	}{
+		{".a", "#777", "white", 1},
+		{".f", "white", "yellow", 7},
+		{".h", "#aaa", "#fff", 13},
+	} {
+		issue := issues[n]
+
+		if sel := issue.Rule.Prelude.Trim().String(); sel != expected.Selector {
+			t.Errorf("issue %d: expecting selector %q, got %q", n+1, expected.Selector, sel)
+		} else if col := issue.Color.Value.String(); col != expected.Color {
+			t.Errorf("issue %d: expecting color %q, got %q", n+1, expected.Color, col)
+		} else if bg := issue.Background.Value.String(); bg != expected.Background {
+			t.Errorf("issue %d: expecting background %q, got %q", n+1, expected.Background, bg)
+		} else if line := issue.Color.Name.Line; line != expected.Line {
+			t.Errorf("issue %d: expecting line %d, got %d", n+1, expected.Line, line)
+		} else if issue.Ratio >= ContrastAA {
+			t.Errorf("issue %d: expecting ratio below %v, got %v", n+1, ContrastAA, issue.Ratio)
+		}
+	}
+}
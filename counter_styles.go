@@ -0,0 +1,431 @@
+package css
+
+import "math"
+
+// predefinedCounterStyleDefinitions contains the unresolved predefined counter
+// styles.
+var predefinedCounterStyleDefinitions = map[string]*CounterStyle{
+	"decimal": {
+		Name:    "decimal",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+	},
+	"decimal-leading-zero": {
+		Name:    "decimal-leading-zero",
+		System:  CounterSystemExtends,
+		Extends: "decimal",
+		Pad:     &CounterPad{2, "0"},
+	},
+	"arabic-indic": {
+		Name:    "arabic-indic",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"٠", "١", "٢", "٣", "٤", "٥", "٦", "٧", "٨", "٩"},
+	},
+	"armenian": {
+		Name:   "armenian",
+		System: CounterSystemAdditive,
+		AdditiveSymbols: []AdditiveSymbol{
+			{9000, "Ք"},
+			{8000, "Փ"},
+			{7000, "Ւ"},
+			{6000, "Ց"},
+			{5000, "Ր"},
+			{4000, "Տ"},
+			{3000, "Վ"},
+			{2000, "Ս"},
+			{1000, "Ռ"},
+			{900, "Ջ"},
+			{800, "Պ"},
+			{700, "Չ"},
+			{600, "Ո"},
+			{500, "Շ"},
+			{400, "Ն"},
+			{300, "Յ"},
+			{200, "Մ"},
+			{100, "Ճ"},
+			{90, "Ղ"},
+			{80, "Ձ"},
+			{70, "Հ"},
+			{60, "Կ"},
+			{50, "Ծ"},
+			{40, "Խ"},
+			{30, "Լ"},
+			{20, "Ի"},
+			{10, "Ժ"},
+			{9, "Թ"},
+			{8, "Ը"},
+			{7, "Է"},
+			{6, "Զ"},
+			{5, "Ե"},
+			{4, "Դ"},
+			{3, "Գ"},
+			{2, "Բ"},
+			{1, "Ա"},
+		},
+		Range: []CounterRange{{1, 9999}},
+	},
+	"upper-armenian": {
+		Name:    "upper-armenian",
+		System:  CounterSystemExtends,
+		Extends: "armenian",
+	},
+	"lower-armenian": {
+		Name:   "lower-armenian",
+		System: CounterSystemAdditive,
+		AdditiveSymbols: []AdditiveSymbol{
+			{9000, "ք"},
+			{8000, "փ"},
+			{7000, "ւ"},
+			{6000, "ց"},
+			{5000, "ր"},
+			{4000, "տ"},
+			{3000, "վ"},
+			{2000, "ս"},
+			{1000, "ռ"},
+			{900, "ջ"},
+			{800, "պ"},
+			{700, "չ"},
+			{600, "ո"},
+			{500, "շ"},
+			{400, "ն"},
+			{300, "յ"},
+			{200, "մ"},
+			{100, "ճ"},
+			{90, "ղ"},
+			{80, "ձ"},
+			{70, "հ"},
+			{60, "կ"},
+			{50, "ծ"},
+			{40, "խ"},
+			{30, "լ"},
+			{20, "ի"},
+			{10, "ժ"},
+			{9, "թ"},
+			{8, "ը"},
+			{7, "է"},
+			{6, "զ"},
+			{5, "ե"},
+			{4, "դ"},
+			{3, "գ"},
+			{2, "բ"},
+			{1, "ա"},
+		},
+		Range: []CounterRange{{1, 9999}},
+	},
+	"bengali": {
+		Name:    "bengali",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"০", "১", "২", "৩", "৪", "৫", "৬", "৭", "৮", "৯"},
+	},
+	"cambodian": {
+		Name:    "cambodian",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"០", "១", "២", "៣", "៤", "៥", "៦", "៧", "៨", "៩"},
+	},
+	"khmer": {
+		Name:    "khmer",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"០", "១", "២", "៣", "៤", "៥", "៦", "៧", "៨", "៩"},
+	},
+	"cjk-decimal": {
+		Name:    "cjk-decimal",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"},
+		Range:   []CounterRange{{0, math.MaxInt}},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"devanagari": {
+		Name:    "devanagari",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"०", "१", "२", "३", "४", "५", "६", "७", "८", "९"},
+	},
+	"georgian": {
+		Name:   "georgian",
+		System: CounterSystemAdditive,
+		AdditiveSymbols: []AdditiveSymbol{
+			{10000, "ჵ"},
+			{9000, "ჰ"},
+			{8000, "ჯ"},
+			{7000, "ჴ"},
+			{6000, "ხ"},
+			{5000, "ჭ"},
+			{4000, "წ"},
+			{3000, "ძ"},
+			{2000, "ც"},
+			{1000, "ჩ"},
+			{900, "შ"},
+			{800, "ყ"},
+			{700, "ღ"},
+			{600, "ქ"},
+			{500, "ფ"},
+			{400, "ჳ"},
+			{300, "ტ"},
+			{200, "ს"},
+			{100, "რ"},
+			{90, "ჟ"},
+			{80, "პ"},
+			{70, "ო"},
+			{60, "ჲ"},
+			{50, "ნ"},
+			{40, "მ"},
+			{30, "ლ"},
+			{20, "კ"},
+			{10, "ი"},
+			{9, "თ"},
+			{8, "ჱ"},
+			{7, "ზ"},
+			{6, "ვ"},
+			{5, "ე"},
+			{4, "დ"},
+			{3, "გ"},
+			{2, "ბ"},
+			{1, "ა"},
+		},
+		Range: []CounterRange{{1, 19999}},
+	},
+	"gujarati": {
+		Name:    "gujarati",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"૦", "૧", "૨", "૩", "૪", "૫", "૬", "૭", "૮", "૯"},
+	},
+	"gurmukhi": {
+		Name:    "gurmukhi",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"੦", "੧", "੨", "੩", "੪", "੫", "੬", "੭", "੮", "੯"},
+	},
+	"hebrew": {
+		Name:   "hebrew",
+		System: CounterSystemAdditive,
+		AdditiveSymbols: []AdditiveSymbol{
+			{10000, "י׳"},
+			{9000, "ט׳"},
+			{8000, "ח׳"},
+			{7000, "ז׳"},
+			{6000, "ו׳"},
+			{5000, "ה׳"},
+			{4000, "ד׳"},
+			{3000, "ג׳"},
+			{2000, "ב׳"},
+			{1000, "א׳"},
+			{400, "ת"},
+			{300, "ש"},
+			{200, "ר"},
+			{100, "ק"},
+			{90, "צ"},
+			{80, "פ"},
+			{70, "ע"},
+			{60, "ס"},
+			{50, "נ"},
+			{40, "מ"},
+			{30, "ל"},
+			{20, "כ"},
+			{19, "יט"},
+			{18, "יח"},
+			{17, "יז"},
+			{16, "טז"},
+			{15, "טו"},
+			{10, "י"},
+			{9, "ט"},
+			{8, "ח"},
+			{7, "ז"},
+			{6, "ו"},
+			{5, "ה"},
+			{4, "ד"},
+			{3, "ג"},
+			{2, "ב"},
+			{1, "א"},
+		},
+		Range: []CounterRange{{1, 10999}},
+	},
+	"kannada": {
+		Name:    "kannada",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"೦", "೧", "೨", "೩", "೪", "೫", "೬", "೭", "೮", "೯"},
+	},
+	"lao": {
+		Name:    "lao",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"໐", "໑", "໒", "໓", "໔", "໕", "໖", "໗", "໘", "໙"},
+	},
+	"malayalam": {
+		Name:    "malayalam",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"൦", "൧", "൨", "൩", "൪", "൫", "൬", "൭", "൮", "൯"},
+	},
+	"mongolian": {
+		Name:    "mongolian",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"᠐", "᠑", "᠒", "᠓", "᠔", "᠕", "᠖", "᠗", "᠘", "᠙"},
+	},
+	"myanmar": {
+		Name:    "myanmar",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"၀", "၁", "၂", "၃", "၄", "၅", "၆", "၇", "၈", "၉"},
+	},
+	"oriya": {
+		Name:    "oriya",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"୦", "୧", "୨", "୩", "୪", "୫", "୬", "୭", "୮", "୯"},
+	},
+	"persian": {
+		Name:    "persian",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"۰", "۱", "۲", "۳", "۴", "۵", "۶", "۷", "۸", "۹"},
+	},
+	"lower-roman": {
+		Name:   "lower-roman",
+		System: CounterSystemAdditive,
+		AdditiveSymbols: []AdditiveSymbol{
+			{1000, "m"},
+			{900, "cm"},
+			{500, "d"},
+			{400, "cd"},
+			{100, "c"},
+			{90, "xc"},
+			{50, "l"},
+			{40, "xl"},
+			{10, "x"},
+			{9, "ix"},
+			{5, "v"},
+			{4, "iv"},
+			{1, "i"},
+		},
+		Range: []CounterRange{{1, 3999}},
+	},
+	"upper-roman": {
+		Name:   "upper-roman",
+		System: CounterSystemAdditive,
+		AdditiveSymbols: []AdditiveSymbol{
+			{1000, "M"},
+			{900, "CM"},
+			{500, "D"},
+			{400, "CD"},
+			{100, "C"},
+			{90, "XC"},
+			{50, "L"},
+			{40, "XL"},
+			{10, "X"},
+			{9, "IX"},
+			{5, "V"},
+			{4, "IV"},
+			{1, "I"},
+		},
+		Range: []CounterRange{{1, 3999}},
+	},
+	"tamil": {
+		Name:    "tamil",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"௦", "௧", "௨", "௩", "௪", "௫", "௬", "௭", "௮", "௯"},
+	},
+	"telugu": {
+		Name:    "telugu",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"౦", "౧", "౨", "౩", "౪", "౫", "౬", "౭", "౮", "౯"},
+	},
+	"thai": {
+		Name:    "thai",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"๐", "๑", "๒", "๓", "๔", "๕", "๖", "๗", "๘", "๙"},
+	},
+	"tibetan": {
+		Name:    "tibetan",
+		System:  CounterSystemNumeric,
+		Symbols: []string{"༠", "༡", "༢", "༣", "༤", "༥", "༦", "༧", "༨", "༩"},
+	},
+	"lower-alpha": {
+		Name:    "lower-alpha",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z"},
+	},
+	"lower-latin": {
+		Name:    "lower-latin",
+		System:  CounterSystemExtends,
+		Extends: "lower-alpha",
+	},
+	"upper-alpha": {
+		Name:    "upper-alpha",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"},
+	},
+	"upper-latin": {
+		Name:    "upper-latin",
+		System:  CounterSystemExtends,
+		Extends: "upper-alpha",
+	},
+	"lower-greek": {
+		Name:    "lower-greek",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"α", "β", "γ", "δ", "ε", "ζ", "η", "θ", "ι", "κ", "λ", "μ", "ν", "ξ", "ο", "π", "ρ", "σ", "τ", "υ", "φ", "χ", "ψ", "ω"},
+	},
+	"hiragana": {
+		Name:    "hiragana",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"あ", "い", "う", "え", "お", "か", "き", "く", "け", "こ", "さ", "し", "す", "せ", "そ", "た", "ち", "つ", "て", "と", "な", "に", "ぬ", "ね", "の", "は", "ひ", "ふ", "へ", "ほ", "ま", "み", "む", "め", "も", "や", "ゆ", "よ", "ら", "り", "る", "れ", "ろ", "わ", "ゐ", "ゑ", "を", "ん"},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"hiragana-iroha": {
+		Name:    "hiragana-iroha",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"い", "ろ", "は", "に", "ほ", "へ", "と", "ち", "り", "ぬ", "る", "を", "わ", "か", "よ", "た", "れ", "そ", "つ", "ね", "な", "ら", "む", "う", "ゐ", "の", "お", "く", "や", "ま", "け", "ふ", "こ", "え", "て", "あ", "さ", "き", "ゆ", "め", "み", "し", "ゑ", "ひ", "も", "せ", "す"},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"katakana": {
+		Name:    "katakana",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"ア", "イ", "ウ", "エ", "オ", "カ", "キ", "ク", "ケ", "コ", "サ", "シ", "ス", "セ", "ソ", "タ", "チ", "ツ", "テ", "ト", "ナ", "ニ", "ヌ", "ネ", "ノ", "ハ", "ヒ", "フ", "ヘ", "ホ", "マ", "ミ", "ム", "メ", "モ", "ヤ", "ユ", "ヨ", "ラ", "リ", "ル", "レ", "ロ", "ワ", "ヰ", "ヱ", "ヲ", "ン"},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"katakana-iroha": {
+		Name:    "katakana-iroha",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"イ", "ロ", "ハ", "ニ", "ホ", "ヘ", "ト", "チ", "リ", "ヌ", "ル", "ヲ", "ワ", "カ", "ヨ", "タ", "レ", "ソ", "ツ", "ネ", "ナ", "ラ", "ム", "ウ", "ヰ", "ノ", "オ", "ク", "ヤ", "マ", "ケ", "フ", "コ", "エ", "テ", "ア", "サ", "キ", "ユ", "メ", "ミ", "シ", "ヱ", "ヒ", "モ", "セ", "ス"},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"cjk-earthly-branch": {
+		Name:    "cjk-earthly-branch",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"cjk-heavenly-stem": {
+		Name:    "cjk-heavenly-stem",
+		System:  CounterSystemAlphabetic,
+		Symbols: []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"},
+		Suffix:  &ideographicCommaSuffix,
+	},
+	"disc": {
+		Name:    "disc",
+		System:  CounterSystemCyclic,
+		Symbols: []string{"•"},
+		Suffix:  &spaceSuffix,
+	},
+	"circle": {
+		Name:    "circle",
+		System:  CounterSystemCyclic,
+		Symbols: []string{"◦"},
+		Suffix:  &spaceSuffix,
+	},
+	"square": {
+		Name:    "square",
+		System:  CounterSystemCyclic,
+		Symbols: []string{"▪"},
+		Suffix:  &spaceSuffix,
+	},
+	"disclosure-open": {
+		Name:    "disclosure-open",
+		System:  CounterSystemCyclic,
+		Symbols: []string{"▾"},
+		Suffix:  &spaceSuffix,
+	},
+	"disclosure-closed": {
+		Name:    "disclosure-closed",
+		System:  CounterSystemCyclic,
+		Symbols: []string{"▸"},
+		Suffix:  &spaceSuffix,
+	},
+}
+
+var (
+	ideographicCommaSuffix = "、"
+	spaceSuffix            = " "
+)
@@ -0,0 +1,162 @@
+package css
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"vimagination.zapto.org/parser"
+)
+
+type encoding uint8
+
+const (
+	encodingUnknown encoding = iota
+	encodingUTF8
+	encodingUTF16LE
+	encodingUTF16BE
+	encodingWindows1252
+)
+
+// ParseSheetBytes parses undecoded CSS input into a Sheet.
+//
+// The encoding of the input is determined, in order, by a byte order mark, an
+// '@charset' rule at the very start of the input, and the fallback encoding
+// label, with UTF-8 used when none of those apply. The supported encodings are
+// UTF-8, UTF-16LE, UTF-16BE, and windows-1252 (which is used for ISO-8859-1
+// and ASCII).
+//
+// An empty fallback is ignored, while a fallback that does not name a
+// supported encoding results in an error.
+func ParseSheetBytes(data []byte, fallback string) (*Sheet, error) {
+	text, err := decodeSheet(data, fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSheet(parser.NewStringTokeniser(text))
+}
+
+func decodeSheet(data []byte, fallback string) (string, error) {
+	enc, data := determineEncoding(data)
+
+	if enc == encodingUnknown && fallback != "" {
+		if enc = encodingForLabel(fallback); enc == encodingUnknown {
+			return "", ErrUnsupportedEncoding
+		}
+	}
+
+	switch enc {
+	case encodingUTF16LE, encodingUTF16BE:
+		return decodeUTF16(data, enc == encodingUTF16BE), nil
+	case encodingWindows1252:
+		return decodeWindows1252(data), nil
+	}
+
+	return decodeUTF8(data), nil
+}
+
+var charsetPrefix = []byte("@charset \"")
+
+func determineEncoding(data []byte) (encoding, []byte) {
+	if bytes.HasPrefix(data, []byte{0xef, 0xbb, 0xbf}) {
+		return encodingUTF8, data[3:]
+	} else if bytes.HasPrefix(data, []byte{0xfe, 0xff}) {
+		return encodingUTF16BE, data[2:]
+	} else if bytes.HasPrefix(data, []byte{0xff, 0xfe}) {
+		return encodingUTF16LE, data[2:]
+	}
+
+	if bytes.HasPrefix(data, charsetPrefix) {
+		prefix := data[len(charsetPrefix):min(len(data), 1024)]
+
+		if end := bytes.IndexByte(prefix, '"'); end >= 0 && end+1 < len(prefix) && prefix[end+1] == ';' {
+			switch enc := encodingForLabel(string(prefix[:end])); enc {
+			case encodingUnknown:
+			case encodingUTF16LE, encodingUTF16BE:
+				return encodingUTF8, data
+			default:
+				return enc, data
+			}
+		}
+	}
+
+	return encodingUnknown, data
+}
+
+func encodingForLabel(label string) encoding {
+	switch strings.ToLower(strings.Trim(label, "\t\n\f\r ")) {
+	case "unicode-1-1-utf-8", "unicode11utf8", "unicode20utf8", "utf-8", "utf8", "x-unicode20utf8":
+		return encodingUTF8
+	case "unicodefffe", "utf-16be":
+		return encodingUTF16BE
+	case "csunicode", "iso-10646-ucs-2", "ucs-2", "unicode", "unicodefeff", "utf-16", "utf-16le":
+		return encodingUTF16LE
+	case "ansi_x3.4-1968", "ascii", "cp1252", "cp819", "csisolatin1", "ibm819", "iso-8859-1", "iso-ir-100", "iso8859-1", "iso88591", "iso_8859-1", "iso_8859-1:1987", "l1", "latin1", "us-ascii", "windows-1252", "x-cp1252":
+		return encodingWindows1252
+	}
+
+	return encodingUnknown
+}
+
+func decodeUTF8(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	var sb strings.Builder
+
+	for len(data) > 0 {
+		r, s := utf8.DecodeRune(data)
+
+		sb.WriteRune(r)
+
+		data = data[s:]
+	}
+
+	return sb.String()
+}
+
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(data)/2)
+
+	for n := 0; n+1 < len(data); n += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[n])<<8|uint16(data[n+1]))
+		} else {
+			units = append(units, uint16(data[n+1])<<8|uint16(data[n]))
+		}
+	}
+
+	str := string(utf16.Decode(units))
+
+	if len(data)%2 == 1 {
+		str += string(utf8.RuneError)
+	}
+
+	return str
+}
+
+var windows1252 = [32]rune{
+	0x20ac, 0x0081, 0x201a, 0x0192, 0x201e, 0x2026, 0x2020, 0x2021,
+	0x02c6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008d, 0x017d, 0x008f,
+	0x0090, 0x2018, 0x2019, 0x201c, 0x201d, 0x2022, 0x2013, 0x2014,
+	0x02dc, 0x2122, 0x0161, 0x203a, 0x0153, 0x009d, 0x017e, 0x0178,
+}
+
+func decodeWindows1252(data []byte) string {
+	var sb strings.Builder
+
+	sb.Grow(len(data))
+
+	for _, b := range data {
+		if b >= 0x80 && b < 0xa0 {
+			sb.WriteRune(windows1252[b-0x80])
+		} else {
+			sb.WriteRune(rune(b))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package css
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf16"
+)
+
+func utf16Bytes(str string, bigEndian bool) []byte {
+	var b []byte
+
+	for _, u := range utf16.Encode([]rune(str)) {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+
+	return b
+}
+
+func TestDecodeSheet(t *testing.T) {
+	for n, test := range [...]struct {
+		Input    []byte
+		Fallback string
+		Output   string
+		Err      error
+	}{
+		{ // 1
+			Input:  []byte("a{content:\"é\"}"),
+			Output: "a{content:\"é\"}",
+		},
+		{ // 2
+			Input:  []byte("\xef\xbb\xbfa{}"),
+			Output: "a{}",
+		},
+		{ // 3
+			Input:  append([]byte{0xff, 0xfe}, utf16Bytes("a{content:\"€\"}", false)...),
+			Output: "a{content:\"€\"}",
+		},
+		{ // 4
+			Input:  append([]byte{0xfe, 0xff}, utf16Bytes("a{content:\"𝄞\"}", true)...),
+			Output: "a{content:\"𝄞\"}",
+		},
+		{ // 5
+			Input:  []byte("@charset \"ISO-8859-1\";a{content:\"\xe9\x80\"}"),
+			Output: "@charset \"ISO-8859-1\";a{content:\"é€\"}",
+		},
+		{ // 6
+			Input:    []byte("@charset \"utf-16\";a{content:\"\xc3\xa9\"}"),
+			Fallback: "latin1",
+			Output:   "@charset \"utf-16\";a{content:\"é\"}",
+		},
+		{ // 7
+			Input:    []byte("@charset \"x-unknown\";a{content:\"\xe9\"}"),
+			Fallback: "windows-1252",
+			Output:   "@charset \"x-unknown\";a{content:\"é\"}",
+		},
+		{ // 8
+			Input:    []byte("@charset 'latin1';a{content:\"\xe9\"}"),
+			Fallback: "",
+			Output:   "@charset 'latin1';a{content:\"�\"}",
+		},
+		{ // 9
+			Input:    []byte("\xef\xbb\xbf@charset \"latin1\";\xc3\xa9"),
+			Fallback: "latin1",
+			Output:   "@charset \"latin1\";é",
+		},
+		{ // 10
+			Input:    []byte("a{}"),
+			Fallback: "shift_jis",
+			Err:      ErrUnsupportedEncoding,
+		},
+		{ // 11
+			Input:  append([]byte{0xff, 0xfe}, 'a', 0, 'b'),
+			Output: "a�",
+		},
+	} {
+		out, err := decodeSheet(test.Input, test.Fallback)
+		if !errors.Is(err, test.Err) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+		} else if out != test.Output {
+			t.Errorf("test %d: expecting output %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
+
+func TestParseSheetBytes(t *testing.T) {
+	s, err := ParseSheetBytes(append([]byte{0xff, 0xfe}, utf16Bytes("@charset \"utf-16\";\na { color: red }", false)...), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(s.Rules) != 2 || s.Rules[0].AtRule == nil || s.Rules[1].QualifiedRule == nil {
+		t.Errorf("expecting @charset rule followed by style rule, got %v", s.Rules)
+	} else if d := s.Rules[1].QualifiedRule.Block.Declarations(); len(d) != 1 || d[0].Value.Trim().String() != "red" {
+		t.Errorf("expecting 'color: red' declaration, got %v", d)
+	}
+}
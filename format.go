@@ -0,0 +1,132 @@
+package css
+
+import (
+	"io"
+	"strings"
+)
+
+type writer struct {
+	io.Writer
+	count int64
+	err   error
+	depth int
+}
+
+func (w *writer) WriteString(str string) {
+	if w.err != nil {
+		return
+	}
+
+	n, err := io.WriteString(w.Writer, str)
+	w.count += int64(n)
+	w.err = err
+}
+
+func (w *writer) newLine() {
+	w.WriteString("\n")
+	w.WriteString(strings.Repeat("\t", w.depth))
+}
+
+// WriteTo writes the Sheet, as CSS, to the given io.Writer.
+//
+// The output is produced from the parsed structure rather than the original
+// Tokens, so any modifications made to the Sheet will be reflected in it.
+func (s *Sheet) WriteTo(w io.Writer) (int64, error) {
+	sw := writer{Writer: w}
+
+	for n, r := range s.Rules {
+		if n > 0 {
+			sw.newLine()
+		}
+
+		r.writeTo(&sw)
+	}
+
+	if len(s.Rules) > 0 {
+		sw.newLine()
+	}
+
+	return sw.count, sw.err
+}
+
+// String returns the Sheet formatted as CSS.
+func (s *Sheet) String() string {
+	var sb strings.Builder
+
+	s.WriteTo(&sb)
+
+	return sb.String()
+}
+
+func (r *Rule) writeTo(w *writer) {
+	if r.CommentDelimiter != nil {
+		w.WriteString(r.CommentDelimiter.Data)
+	} else if r.AtRule != nil {
+		r.AtRule.writeTo(w)
+	} else if r.QualifiedRule != nil {
+		r.QualifiedRule.writeTo(w)
+	}
+}
+
+func (a *AtRule) writeTo(w *writer) {
+	w.WriteString(a.AtKeyword.Data)
+
+	if prelude := a.Prelude.Trim(); len(prelude) > 0 {
+		w.WriteString(" ")
+		w.WriteString(prelude.String())
+	}
+
+	if a.Block != nil {
+		w.WriteString(" ")
+		a.Block.writeTo(w)
+	} else {
+		w.WriteString(";")
+	}
+}
+
+func (q *QualifiedRule) writeTo(w *writer) {
+	if prelude := q.Prelude.Trim(); len(prelude) > 0 {
+		w.WriteString(prelude.String())
+		w.WriteString(" ")
+	}
+
+	q.Block.writeTo(w)
+}
+
+func (b *Block) writeTo(w *writer) {
+	w.WriteString("{")
+
+	if len(b.Items) == 0 {
+		w.WriteString("}")
+
+		return
+	}
+
+	w.depth++
+
+	for _, bi := range b.Items {
+		w.newLine()
+
+		if bi.Declaration != nil {
+			bi.Declaration.writeTo(w)
+			w.WriteString(";")
+		} else if bi.Rule != nil {
+			bi.Rule.writeTo(w)
+		}
+	}
+
+	w.depth--
+
+	w.newLine()
+	w.WriteString("}")
+}
+
+func (d *Declaration) writeTo(w *writer) {
+	w.WriteString(d.Name.Data)
+	w.WriteString(": ")
+	w.WriteString(d.Value.String())
+
+	if d.Important {
+		w.WriteString(" !important")
+	}
+}
@@ -0,0 +1,37 @@
+package css
+
+import (
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestFormat(t *testing.T) {
+	for n, test := range [...]struct {
+		Input, Output string
+	}{
+		{ // 1
+			Input:  "",
+			Output: "",
+		},
+		{ // 2
+			Input:  "a{color:red}",
+			Output: "a {\n\tcolor: red;\n}\n",
+		},
+		{ // 3
+			Input:  "@import 'a.css' screen ;\n\n@media  screen{a,b{color:red!important;&:hover{color:blue}}}",
+			Output: "@import 'a.css' screen;\n@media screen {\n\ta,b {\n\t\tcolor: red !important;\n\t\t&:hover {\n\t\t\tcolor: blue;\n\t\t}\n\t}\n}\n",
+		},
+		{ // 4
+			Input:  "<!-- a {} -->",
+			Output: "<!--\na {}\n-->\n",
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if out := s.String(); out != test.Output {
+			t.Errorf("test %d: expecting output %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"slices"
+	"strings"
+)
+
+type property struct {
+	Name          string   `json:"name"`
+	Syntax        string   `json:"syntax"`
+	Initial       string   `json:"initial"`
+	Inherited     bool     `json:"inherited"`
+	AppliesTo     string   `json:"appliesTo"`
+	Percentages   string   `json:"percentages"`
+	AnimationType string   `json:"animationType"`
+	Longhands     []string `json:"longhands"`
+	Shorthands    []string `json:"-"`
+}
+
+type data struct {
+	Types      map[string]string `json:"types"`
+	Properties []*property       `json:"properties"`
+}
+
+func main() {
+	if err := run("properties.json", "properties_table.go"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output string) error {
+	f, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+
+	var d data
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+
+	err = dec.Decode(&d)
+	f.Close()
+
+	if err != nil {
+		return fmt.Errorf("error decoding %s: %w", input, err)
+	}
+
+	if err := link(d.Properties); err != nil {
+		return err
+	}
+
+	src, err := format.Source(generate(&d))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, src, 0o644)
+}
+
+// link fills in the shorthands of each longhand, and marks a shorthand as
+// inherited when all of its longhands are.
+func link(properties []*property) error {
+	byName := make(map[string]*property, len(properties))
+
+	for _, p := range properties {
+		if _, ok := byName[p.Name]; ok {
+			return fmt.Errorf("duplicate property: %s", p.Name)
+		}
+
+		byName[p.Name] = p
+	}
+
+	for _, p := range properties {
+		if len(p.Longhands) == 0 {
+			continue
+		}
+
+		p.Inherited = true
+
+		for _, name := range p.Longhands {
+			l, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("unknown longhand of %s: %s", p.Name, name)
+			} else if len(l.Longhands) > 0 {
+				return fmt.Errorf("longhand of %s is a shorthand: %s", p.Name, name)
+			}
+
+			l.Shorthands = append(l.Shorthands, p.Name)
+			p.Inherited = p.Inherited && l.Inherited
+		}
+	}
+
+	for _, p := range properties {
+		slices.Sort(p.Shorthands)
+	}
+
+	return nil
+}
+
+func generate(d *data) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by gen_properties.go from properties.json; DO NOT EDIT.\n\npackage css\n\n")
+	buf.WriteString("// propertyTypeSource contains the definitions of the data types used by the\n// property grammars.\n")
+	buf.WriteString("var propertyTypeSource = map[string]string{\n")
+
+	for _, name := range sortedKeys(d.Types) {
+		fmt.Fprintf(&buf, "%q: %q,\n", name, d.Types[name])
+	}
+
+	buf.WriteString("}\n\n// propertyDefinitions contains the standard CSS properties, keyed by name.\n")
+	buf.WriteString("var propertyDefinitions = map[string]*PropertyDefinition{\n")
+
+	slices.SortFunc(d.Properties, func(a, b *property) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, p := range d.Properties {
+		fmt.Fprintf(&buf, "%q: {\n", p.Name)
+		fmt.Fprintf(&buf, "Name: %q,\n", p.Name)
+		fmt.Fprintf(&buf, "Syntax: %q,\n", p.Syntax)
+		fmt.Fprintf(&buf, "Initial: %q,\n", p.Initial)
+		fmt.Fprintf(&buf, "Inherited: %t,\n", p.Inherited)
+		fmt.Fprintf(&buf, "AppliesTo: %q,\n", p.AppliesTo)
+		fmt.Fprintf(&buf, "Percentages: %q,\n", p.Percentages)
+		fmt.Fprintf(&buf, "AnimationType: %q,\n", p.AnimationType)
+		writeList(&buf, "Longhands", p.Longhands)
+		writeList(&buf, "Shorthands", p.Shorthands)
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Bytes()
+}
+
+func writeList(buf *bytes.Buffer, field string, list []string) {
+	if len(list) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "%s: []string{", field)
+
+	for n, item := range list {
+		if n > 0 {
+			buf.WriteString(", ")
+		}
+
+		fmt.Fprintf(buf, "%q", item)
+	}
+
+	buf.WriteString("},\n")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
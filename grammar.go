@@ -0,0 +1,536 @@
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// GrammarCombinator determines how the Terms of a Grammar group are combined.
+type GrammarCombinator uint8
+
+// Grammar combinators, in order of precedence.
+const (
+	GrammarJuxtapose GrammarCombinator = iota // 'a b', all in order
+	GrammarAll                                // 'a && b', all in any order
+	GrammarAny                                // 'a || b', one or more in any order
+	GrammarOne                                // 'a | b', exactly one
+)
+
+var grammarCombinatorNames = [...]string{
+	GrammarJuxtapose: " ",
+	GrammarAll:       " && ",
+	GrammarAny:       " || ",
+	GrammarOne:       " | ",
+}
+
+// GrammarMultiplier specifies how many times a Grammar may be repeated.
+//
+// Max is -1 when the number of repetitions is unbounded, and Comma is set for
+// comma-separated lists ('#').
+type GrammarMultiplier struct {
+	Min, Max int
+	Comma    bool
+}
+
+// GrammarRange restricts the numeric values accepted by a data type, such as
+// '<length [0,∞]>'. Dimensions are compared in the canonical unit of their
+// category.
+type GrammarRange struct {
+	Min, Max float64
+}
+
+// Grammar represents a parsed CSS Value Definition Syntax, such as
+// '[ <length> | <percentage> ]{1,4}'.
+//
+// Exactly one of Keyword, Literal, Type, Property, or Function will be set,
+// unless the Grammar is a group, in which case its Terms are combined
+// according to the Combinator. The arguments of a Function are held in its
+// single Term, with no Term for a Function that takes no arguments.
+//
+// A Type names a data type, such as 'length' for '<length>', or a functional
+// notation, such as 'rgb()' for '<rgb()>'. A Property names a property whose
+// grammar is referenced, such as 'width' for "<'width'>". Types and Properties
+// that are not known do not match any value.
+//
+// Required is set for groups that must not be empty ('!').
+type Grammar struct {
+	Keyword    string
+	Literal    string
+	Type       string
+	Range      *GrammarRange
+	Property   string
+	Function   string
+	Combinator GrammarCombinator
+	Terms      []*Grammar
+	Multiplier *GrammarMultiplier
+	Required   bool
+}
+
+// ParseGrammar parses a Value Definition Syntax string into a Grammar.
+func ParseGrammar(str string) (*Grammar, error) {
+	c, err := newCSSParser(CreateTokeniser(parser.NewStringTokeniser(str), false))
+	if err != nil {
+		return nil, err
+	}
+
+	var g Grammar
+
+	if err := parseTrimmed(&c, g.parse); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (g *Grammar) parse(c *cssParser) error {
+	return g.parseCombination(c, GrammarOne)
+}
+
+func (g *Grammar) parseCombination(c *cssParser, combinator GrammarCombinator) error {
+	var terms []*Grammar
+
+	for {
+		var (
+			t   = new(Grammar)
+			err error
+		)
+
+		if combinator == GrammarJuxtapose {
+			err = t.parseTerm(c)
+		} else {
+			err = t.parseCombination(c, combinator-1)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		terms = append(terms, t)
+
+		d := c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if combinator == GrammarJuxtapose {
+			if !isGrammarTermStart(d.Peek()) {
+				break
+			}
+		} else if !acceptGrammarCombinator(&d, combinator) {
+			break
+		} else {
+			d.AcceptRunWhitespace()
+		}
+
+		c.Score(d)
+	}
+
+	if len(terms) == 1 {
+		*g = *terms[0]
+	} else {
+		g.Combinator = combinator
+		g.Terms = terms
+	}
+
+	return nil
+}
+
+func isGrammarTermStart(tk parser.Token) bool {
+	switch tk.Type {
+	case TokenIdent, TokenFunction, TokenString, TokenComma, TokenOpenBracket:
+		return true
+	case TokenDelim:
+		return tk.Data == "<" || tk.Data == "/"
+	}
+
+	return false
+}
+
+func acceptGrammarCombinator(c *cssParser, combinator GrammarCombinator) bool {
+	switch combinator {
+	case GrammarAll:
+		return c.AcceptToken(parser.Token{Type: TokenDelim, Data: "&"}) && c.AcceptToken(parser.Token{Type: TokenDelim, Data: "&"})
+	case GrammarAny:
+		return c.AcceptToken(parser.Token{Type: TokenDelim, Data: "|"}) && c.AcceptToken(parser.Token{Type: TokenDelim, Data: "|"})
+	}
+
+	return c.AcceptToken(parser.Token{Type: TokenDelim, Data: "|"}) && c.Peek() != parser.Token{Type: TokenDelim, Data: "|"}
+}
+
+func (g *Grammar) parseTerm(c *cssParser) error {
+	switch tk := c.Next(); tk.Type {
+	case TokenIdent:
+		g.Keyword = tk.Data
+	case TokenComma:
+		g.Literal = ","
+	case TokenString:
+		str, err := Unquote(tk.Data)
+		if err != nil {
+			return c.Error("Grammar", err)
+		}
+
+		g.Literal = str
+	case TokenDelim:
+		switch tk.Data {
+		case "/":
+			g.Literal = "/"
+		case "<":
+			if err := g.parseType(c); err != nil {
+				return err
+			}
+		default:
+			return c.Error("Grammar", ErrInvalidGrammar)
+		}
+	case TokenOpenBracket:
+		c.AcceptRunWhitespace()
+
+		if err := g.parse(c); err != nil {
+			return err
+		}
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseBracket) {
+			return c.Error("Grammar", ErrInvalidGrammar)
+		}
+	case TokenFunction:
+		g.Function = functionName(*tk)
+
+		if c.AcceptRunWhitespace(); !c.Accept(TokenCloseParen) {
+			var args Grammar
+
+			if err := args.parse(c); err != nil {
+				return err
+			}
+
+			c.AcceptRunWhitespace()
+
+			if !c.Accept(TokenCloseParen) {
+				return c.Error("Grammar", ErrMissingCloseParen)
+			}
+
+			g.Terms = []*Grammar{&args}
+		}
+	default:
+		return c.Error("Grammar", ErrInvalidGrammar)
+	}
+
+	return g.parseMultipliers(c)
+}
+
+func (g *Grammar) parseType(c *cssParser) error {
+	switch tk := c.Next(); tk.Type {
+	case TokenString:
+		name, err := Unquote(tk.Data)
+		if err != nil {
+			return c.Error("Grammar", err)
+		}
+
+		g.Property = strings.ToLower(name)
+	case TokenIdent:
+		g.Type = strings.ToLower(tk.Data)
+
+		d := c.NewGoal()
+
+		d.AcceptRunWhitespace()
+
+		if d.Accept(TokenOpenBracket) {
+			g.Range = new(GrammarRange)
+
+			if err := g.Range.parse(&d); err != nil {
+				return err
+			}
+
+			c.Score(d)
+		}
+	case TokenFunction:
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("Grammar", ErrInvalidGrammar)
+		}
+
+		g.Type = functionName(*tk) + "()"
+	default:
+		return c.Error("Grammar", ErrInvalidGrammar)
+	}
+
+	if !c.AcceptToken(parser.Token{Type: TokenDelim, Data: ">"}) {
+		return c.Error("Grammar", ErrInvalidGrammar)
+	}
+
+	return nil
+}
+
+func (r *GrammarRange) parse(c *cssParser) error {
+	var err error
+
+	c.AcceptRunWhitespace()
+
+	if r.Min, err = parseRangeBound(c); err != nil {
+		return err
+	}
+
+	c.AcceptRunWhitespace()
+
+	if !c.Accept(TokenComma) {
+		return c.Error("GrammarRange", ErrInvalidGrammar)
+	}
+
+	c.AcceptRunWhitespace()
+
+	if r.Max, err = parseRangeBound(c); err != nil {
+		return err
+	}
+
+	c.AcceptRunWhitespace()
+
+	if !c.Accept(TokenCloseBracket) || r.Min > r.Max {
+		return c.Error("GrammarRange", ErrInvalidGrammar)
+	}
+
+	return nil
+}
+
+func parseRangeBound(c *cssParser) (float64, error) {
+	tk := c.Next()
+
+	switch tk.Type {
+	case TokenNumber, TokenPercentage, TokenDimension:
+		q, err := ParseQuantity(*tk)
+		if err != nil {
+			return 0, c.Error("GrammarRange", err)
+		}
+
+		if canonical, ok := q.Canonical(); ok {
+			q = canonical
+		}
+
+		return q.Value, nil
+	case TokenDelim:
+		infinity := parser.Token{Type: TokenDelim, Data: "∞"}
+
+		switch {
+		case tk.Token == infinity, tk.Data == "+" && c.AcceptToken(infinity):
+			return math.Inf(1), nil
+		case tk.Data == "-" && c.AcceptToken(infinity):
+			return math.Inf(-1), nil
+		}
+	}
+
+	return 0, c.Error("GrammarRange", ErrInvalidGrammar)
+}
+
+func (g *Grammar) parseMultipliers(c *cssParser) error {
+	for {
+		var (
+			m        *GrammarMultiplier
+			required bool
+		)
+
+		tk := c.Peek()
+
+		switch {
+		case tk.Type == TokenOpenBrace:
+			c.Skip()
+
+			m = new(GrammarMultiplier)
+
+			if err := m.parseBounds(c); err != nil {
+				return err
+			}
+		case tk.Type != TokenDelim:
+			return nil
+		case tk.Data == "*":
+			m = &GrammarMultiplier{Min: 0, Max: -1}
+		case tk.Data == "+":
+			m = &GrammarMultiplier{Min: 1, Max: -1}
+		case tk.Data == "?":
+			m = &GrammarMultiplier{Min: 0, Max: 1}
+		case tk.Data == "#":
+			m = &GrammarMultiplier{Min: 1, Max: -1, Comma: true}
+		case tk.Data == "!":
+			required = true
+		default:
+			return nil
+		}
+
+		if tk.Type == TokenDelim {
+			c.Skip()
+
+			if tk.Data == "#" && c.Accept(TokenOpenBrace) {
+				if err := m.parseBounds(c); err != nil {
+					return err
+				}
+			}
+		}
+
+		if g.Multiplier != nil || g.Required {
+			inner := *g
+			*g = Grammar{Terms: []*Grammar{&inner}}
+		}
+
+		g.Multiplier = m
+		g.Required = required
+	}
+}
+
+func (m *GrammarMultiplier) parseBounds(c *cssParser) error {
+	var err error
+
+	if m.Min, err = parseMultiplierBound(c); err != nil {
+		return err
+	}
+
+	m.Max = m.Min
+
+	if c.Accept(TokenComma) {
+		if c.Peek().Type == TokenCloseBrace {
+			m.Max = -1
+		} else if m.Max, err = parseMultiplierBound(c); err != nil {
+			return err
+		} else if m.Max < m.Min {
+			return c.Error("GrammarMultiplier", ErrInvalidGrammar)
+		}
+	}
+
+	if !c.Accept(TokenCloseBrace) {
+		return c.Error("GrammarMultiplier", ErrInvalidGrammar)
+	}
+
+	return nil
+}
+
+func parseMultiplierBound(c *cssParser) (int, error) {
+	if !c.Accept(TokenNumber) {
+		return 0, c.Error("GrammarMultiplier", ErrInvalidGrammar)
+	}
+
+	n, err := strconv.ParseUint(c.GetLastToken().Data, 10, 16)
+	if err != nil {
+		return 0, c.Error("GrammarMultiplier", ErrInvalidGrammar)
+	}
+
+	return int(n), nil
+}
+
+// String returns the Grammar in Value Definition Syntax.
+func (g *Grammar) String() string {
+	var sb strings.Builder
+
+	g.writeTo(&sb)
+
+	return sb.String()
+}
+
+func (g *Grammar) isGroup() bool {
+	return g.Keyword == "" && g.Literal == "" && g.Type == "" && g.Property == "" && g.Function == ""
+}
+
+func (g *Grammar) writeTo(sb *strings.Builder) {
+	switch {
+	case g.Keyword != "":
+		sb.WriteString(g.Keyword)
+	case g.Literal == "," || g.Literal == "/":
+		sb.WriteString(g.Literal)
+	case g.Literal != "":
+		sb.WriteString(quote(g.Literal))
+	case g.Type != "":
+		sb.WriteString("<")
+		sb.WriteString(g.Type)
+
+		if g.Range != nil {
+			sb.WriteString(" [")
+			sb.WriteString(formatRangeBound(g.Range.Min))
+			sb.WriteString(",")
+			sb.WriteString(formatRangeBound(g.Range.Max))
+			sb.WriteString("]")
+		}
+
+		sb.WriteString(">")
+	case g.Property != "":
+		sb.WriteString("<'")
+		sb.WriteString(g.Property)
+		sb.WriteString("'>")
+	case g.Function != "":
+		sb.WriteString(g.Function)
+		sb.WriteString("(")
+
+		if len(g.Terms) > 0 {
+			g.Terms[0].writeTo(sb)
+		}
+
+		sb.WriteString(")")
+	default:
+		if g.Multiplier != nil || g.Required {
+			sb.WriteString("[ ")
+		}
+
+		for n, t := range g.Terms {
+			if n > 0 {
+				sb.WriteString(grammarCombinatorNames[g.Combinator])
+			}
+
+			if t.isGroup() && t.Multiplier == nil && !t.Required && len(t.Terms) > 1 && t.Combinator >= g.Combinator {
+				sb.WriteString("[ ")
+				t.writeTo(sb)
+				sb.WriteString(" ]")
+			} else {
+				t.writeTo(sb)
+			}
+		}
+
+		if g.Multiplier != nil || g.Required {
+			sb.WriteString(" ]")
+		}
+	}
+
+	g.writeMultiplier(sb)
+}
+
+func (g *Grammar) writeMultiplier(sb *strings.Builder) {
+	if m := g.Multiplier; m != nil {
+		switch *m {
+		case GrammarMultiplier{Min: 0, Max: -1}:
+			sb.WriteString("*")
+		case GrammarMultiplier{Min: 1, Max: -1}:
+			sb.WriteString("+")
+		case GrammarMultiplier{Min: 0, Max: 1}:
+			sb.WriteString("?")
+		case GrammarMultiplier{Min: 1, Max: -1, Comma: true}:
+			sb.WriteString("#")
+		default:
+			if m.Comma {
+				sb.WriteString("#")
+			}
+
+			sb.WriteString("{")
+			sb.WriteString(strconv.Itoa(m.Min))
+
+			if m.Max != m.Min {
+				sb.WriteString(",")
+
+				if m.Max >= 0 {
+					sb.WriteString(strconv.Itoa(m.Max))
+				}
+			}
+
+			sb.WriteString("}")
+		}
+	}
+
+	if g.Required {
+		sb.WriteString("!")
+	}
+}
+
+func formatRangeBound(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "∞"
+	case math.IsInf(v, -1):
+		return "-∞"
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,562 @@
+package css
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// GrammarMatch records the component values matched by a Grammar.
+//
+// Terms holds the matches of the sub-terms of the Grammar: for a group, the
+// matched terms in the order they appear in the value; for a Function, the
+// match of its arguments; and for a Type or Property defined by another
+// Grammar, the match of that definition. When the Grammar has a Multiplier,
+// Terms instead holds a GrammarMatch for each repetition.
+type GrammarMatch struct {
+	Grammar *Grammar
+	Values  []Tokens
+	Terms   []*GrammarMatch
+}
+
+// Match checks the component values of the given value against the Grammar,
+// returning the GrammarMatch describing which terms matched which values.
+//
+// When a value can be matched in more than one way, repetitions are matched
+// greedily.
+func (g *Grammar) Match(value Tokens) (*GrammarMatch, bool) {
+	m := grammarMatcher{
+		values: componentValues(value),
+		memo:   make(map[grammarMemoKey][]grammarResult),
+	}
+
+	for _, r := range m.match(g, 0) {
+		if r.end == len(m.values) {
+			return r.match, true
+		}
+	}
+
+	return nil, false
+}
+
+var grammarDataTypes = map[string]func(Tokens) bool{
+	"angle":              isAngle,
+	"color":              isColor,
+	"custom-ident":       isCustomIdent,
+	"dashed-ident":       isDashedIdent,
+	"dimension":          isDimension,
+	"flex":               isFlex,
+	"frequency":          isFrequency,
+	"hex-color":          isHexColor,
+	"ident":              isIdentValue,
+	"image":              isImage,
+	"integer":            isInteger,
+	"length":             isLength,
+	"length-percentage":  isLengthPercentage,
+	"line-names":         isLineNames,
+	"number":             isNumber,
+	"percentage":         isPercentage,
+	"resolution":         isResolution,
+	"string":             isString,
+	"time":               isTime,
+	"transform-function": isTransformFunction,
+	"url":                isURL,
+}
+
+// grammarTypeSource contains the definitions of data types that are composed
+// of other types.
+//
+// The data types used only by property grammars are generated, along with the
+// properties, in propertyTypeSource.
+var grammarTypeSource = map[string]string{
+	"alpha-value":          "<number> | <percentage>",
+	"angle-percentage":     "<angle> | <percentage>",
+	"frequency-percentage": "<frequency> | <percentage>",
+	"time-percentage":      "<time> | <percentage>",
+	"number-percentage":    "<number> | <percentage>",
+	"transform-list":       "<transform-function>+",
+	"line-style":           "none | hidden | dotted | dashed | solid | double | groove | ridge | inset | outset",
+	"line-width":           "<length [0,∞]> | thin | medium | thick",
+	"visual-box":           "content-box | padding-box | border-box",
+	"layout-box":           "<visual-box> | margin-box",
+	"paint-box":            "<visual-box> | fill-box | stroke-box",
+	"coord-box":            "<paint-box> | view-box",
+	"position":             "[ left | center | right | top | bottom | <length-percentage> ] | [ left | center | right | <length-percentage> ] [ top | center | bottom | <length-percentage> ] | [ center | [ left | right ] <length-percentage>? ] && [ center | [ top | bottom ] <length-percentage>? ]",
+	"bg-position":          "<position>",
+}
+
+var grammarTypes = sync.OnceValue(func() map[string]*Grammar {
+	types := make(map[string]*Grammar, len(grammarTypeSource)+len(propertyTypeSource))
+
+	for _, source := range [...]map[string]string{grammarTypeSource, propertyTypeSource} {
+		for name, src := range source {
+			g, err := ParseGrammar(src)
+			if err != nil {
+				panic(err)
+			}
+
+			types[name] = g
+		}
+	}
+
+	return types
+})
+
+// lookupPropertyGrammar returns the Grammar of the named property.
+func lookupPropertyGrammar(name string) (*Grammar, bool) {
+	p, ok := LookupProperty(name)
+	if !ok {
+		return nil, false
+	}
+
+	return p.Grammar(), true
+}
+
+type grammarResult struct {
+	end   int
+	match *GrammarMatch
+}
+
+type grammarMemoKey struct {
+	grammar *Grammar
+	pos     int
+}
+
+type grammarMatcher struct {
+	values []Tokens
+	memo   map[grammarMemoKey][]grammarResult
+}
+
+// match returns every way that the Grammar can match the values starting at
+// the given position, longest first.
+func (m *grammarMatcher) match(g *Grammar, pos int) []grammarResult {
+	key := grammarMemoKey{g, pos}
+
+	if results, ok := m.memo[key]; ok {
+		return results
+	}
+
+	var results []grammarResult
+
+	if g.Multiplier == nil {
+		results = m.matchOnce(g, pos)
+	} else {
+		results = m.matchRepeated(g, pos)
+	}
+
+	if g.Required {
+		results = slices.DeleteFunc(slices.Clone(results), func(r grammarResult) bool {
+			return r.end == pos
+		})
+	}
+
+	m.memo[key] = results
+
+	return results
+}
+
+func (m *grammarMatcher) result(g *Grammar, pos, end int, terms ...*GrammarMatch) grammarResult {
+	return grammarResult{
+		end: end,
+		match: &GrammarMatch{
+			Grammar: g,
+			Values:  m.values[pos:end:end],
+			Terms:   terms,
+		},
+	}
+}
+
+// matchRepeated keeps a single repetition for each end position at each
+// count, preferring the first found, and a single result for each end
+// position, preferring the most repetitions, so that the work done is
+// polynomial in the number of values.
+func (m *grammarMatcher) matchRepeated(g *Grammar, pos int) []grammarResult {
+	type repetition struct {
+		end   int
+		terms []*GrammarMatch
+	}
+
+	var (
+		results []grammarResult
+		ends    = make(map[int]int)
+		reps    = []repetition{{end: pos}}
+		mult    = g.Multiplier
+	)
+
+	for count := 0; len(reps) > 0; count++ {
+		if count >= mult.Min {
+			for _, r := range reps {
+				result := m.result(g, pos, r.end, r.terms...)
+
+				if n, ok := ends[r.end]; ok {
+					results[n] = result
+				} else {
+					ends[r.end] = len(results)
+					results = append(results, result)
+				}
+			}
+		}
+
+		if count == mult.Max {
+			break
+		}
+
+		var (
+			next []repetition
+			seen = make(map[int]bool)
+		)
+
+		for _, r := range reps {
+			start := r.end
+
+			if mult.Comma && count > 0 {
+				if start == len(m.values) || m.values[start][0].Type != TokenComma {
+					continue
+				}
+
+				start++
+			}
+
+			for _, o := range m.matchOnce(g, start) {
+				if (o.end > start || count < mult.Min) && !seen[o.end] {
+					seen[o.end] = true
+					next = append(next, repetition{end: o.end, terms: append(slices.Clip(r.terms), o.match)})
+				}
+			}
+		}
+
+		reps = next
+	}
+
+	slices.SortStableFunc(results, func(a, b grammarResult) int {
+		return b.end - a.end
+	})
+
+	return results
+}
+
+func (m *grammarMatcher) matchOnce(g *Grammar, pos int) []grammarResult {
+	switch {
+	case g.Keyword != "":
+		if pos < len(m.values) && m.values[pos][0].Type == TokenIdent && strings.EqualFold(m.values[pos][0].Data, g.Keyword) {
+			return []grammarResult{m.result(g, pos, pos+1)}
+		}
+	case g.Literal != "":
+		if pos < len(m.values) && isGrammarLiteral(m.values[pos], g.Literal) {
+			return []grammarResult{m.result(g, pos, pos+1)}
+		}
+	case g.Type != "":
+		return m.matchType(g, pos)
+	case g.Property != "":
+		if def, ok := lookupPropertyGrammar(g.Property); ok {
+			return m.matchDefinition(g, def, pos)
+		}
+	case g.Function != "":
+		return m.matchFunction(g, pos)
+	case g.Combinator == GrammarJuxtapose:
+		return m.matchSequence(g, pos)
+	case g.Combinator == GrammarOne:
+		var results []grammarResult
+
+		for _, t := range g.Terms {
+			for _, r := range m.match(t, pos) {
+				results = append(results, m.result(g, pos, r.end, r.match))
+			}
+		}
+
+		return results
+	default:
+		return m.matchSet(g, pos)
+	}
+
+	return nil
+}
+
+func isGrammarLiteral(v Tokens, literal string) bool {
+	if len(v) != 1 {
+		return false
+	} else if literal == "," {
+		return v[0].Type == TokenComma
+	}
+
+	return v[0].Type != TokenString && v[0].Data == literal
+}
+
+func (m *grammarMatcher) matchType(g *Grammar, pos int) []grammarResult {
+	switch g.Type {
+	case "declaration-value", "any-value":
+		var results []grammarResult
+
+		for end := len(m.values); end > pos; end-- {
+			results = append(results, m.result(g, pos, end))
+		}
+
+		return results
+	}
+
+	if pos == len(m.values) {
+		if def, ok := grammarTypes()[g.Type]; ok {
+			return m.matchDefinition(g, def, pos)
+		}
+
+		return nil
+	}
+
+	v := m.values[pos]
+
+	if name, ok := strings.CutSuffix(g.Type, "()"); ok {
+		if functionName(v[0]) == name {
+			return []grammarResult{m.result(g, pos, pos+1)}
+		}
+	} else if fn, ok := grammarDataTypes[g.Type]; ok {
+		if fn(v) && g.Range.contains(v) {
+			return []grammarResult{m.result(g, pos, pos+1)}
+		}
+	} else if def, ok := grammarTypes()[g.Type]; ok {
+		return m.matchDefinition(g, def, pos)
+	}
+
+	return nil
+}
+
+func (r *GrammarRange) contains(v Tokens) bool {
+	if r == nil || len(v) != 1 {
+		return true
+	}
+
+	q, err := ParseQuantity(v[0])
+	if err != nil {
+		return true
+	}
+
+	if c, ok := q.Canonical(); ok {
+		q = c
+	}
+
+	return r.Min <= q.Value && q.Value <= r.Max
+}
+
+func (m *grammarMatcher) matchDefinition(g, def *Grammar, pos int) []grammarResult {
+	var results []grammarResult
+
+	for _, r := range m.match(def, pos) {
+		results = append(results, m.result(g, pos, r.end, r.match))
+	}
+
+	return results
+}
+
+func (m *grammarMatcher) matchFunction(g *Grammar, pos int) []grammarResult {
+	if pos == len(m.values) {
+		return nil
+	}
+
+	v := m.values[pos]
+	last := len(v) - 1
+
+	if functionName(v[0]) != g.Function || v[last].Type != TokenCloseParen {
+		return nil
+	}
+
+	args := componentValues(v[1:last])
+
+	if len(g.Terms) == 0 {
+		if len(args) == 0 {
+			return []grammarResult{m.result(g, pos, pos+1)}
+		}
+
+		return nil
+	}
+
+	inner := grammarMatcher{
+		values: args,
+		memo:   make(map[grammarMemoKey][]grammarResult),
+	}
+
+	for _, r := range inner.match(g.Terms[0], 0) {
+		if r.end == len(args) {
+			return []grammarResult{m.result(g, pos, pos+1, r.match)}
+		}
+	}
+
+	return nil
+}
+
+// matchSequence matches the Terms of a juxtaposed group in order.
+//
+// Per the Value Definition Syntax, a comma in the grammar must be omitted
+// when everything before it since the start of the group or the previous
+// comma is absent, or when everything after it is absent. A comma at the
+// start or end of a group separates it from terms outside of the group, and
+// so is always required.
+func (m *grammarMatcher) matchSequence(g *Grammar, pos int) []grammarResult {
+	type sequence struct {
+		end      int
+		terms    []*GrammarMatch
+		empty    bool // nothing matched since the start or the last comma
+		trailing bool // a comma was omitted, so nothing more may match
+		dangling bool // a comma was matched, so something more must match
+	}
+
+	seqs := []sequence{{end: pos, empty: true}}
+
+	for n, t := range g.Terms {
+		var next []sequence
+
+		for _, s := range seqs {
+			if t.Literal == "," && t.Multiplier == nil {
+				if s.trailing || s.empty && n > 0 {
+					next = append(next, s)
+
+					continue
+				} else if n < len(g.Terms)-1 {
+					next = append(next, sequence{end: s.end, terms: s.terms, empty: true, trailing: true})
+				}
+			}
+
+			for _, r := range m.match(t, s.end) {
+				if s.trailing && r.end != s.end {
+					continue
+				}
+
+				next = append(next, sequence{
+					end:      r.end,
+					terms:    append(slices.Clip(s.terms), r.match),
+					empty:    s.empty && r.end == s.end || t.Literal == ",",
+					trailing: s.trailing,
+					dangling: s.dangling && r.end == s.end || t.Literal == "," && n < len(g.Terms)-1,
+				})
+			}
+		}
+
+		seqs = next
+	}
+
+	var results []grammarResult
+
+	for _, s := range seqs {
+		if !s.dangling {
+			results = append(results, m.result(g, pos, s.end, s.terms...))
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b grammarResult) int {
+		return b.end - a.end
+	})
+
+	return results
+}
+
+// matchSet matches the Terms of a group combined with '&&' or '||', which may
+// appear in any order.
+func (m *grammarMatcher) matchSet(g *Grammar, pos int) []grammarResult {
+	var (
+		results []grammarResult
+		walk    func(end int, used []bool, terms []*GrammarMatch)
+	)
+
+	walk = func(end int, used []bool, terms []*GrammarMatch) {
+		for n, t := range g.Terms {
+			if used[n] {
+				continue
+			}
+
+			for _, r := range m.match(t, end) {
+				if r.end == end {
+					continue
+				}
+
+				now := slices.Clone(used)
+				now[n] = true
+
+				walk(r.end, now, append(slices.Clip(terms), r.match))
+			}
+		}
+
+		if g.Combinator == GrammarAll {
+			for n, t := range g.Terms {
+				if used[n] {
+					continue
+				}
+
+				r, ok := m.matchEmpty(t, end)
+				if !ok {
+					return
+				}
+
+				terms = append(slices.Clip(terms), r.match)
+			}
+		} else if len(terms) == 0 {
+			return
+		}
+
+		results = append(results, m.result(g, pos, end, terms...))
+	}
+
+	walk(pos, make([]bool, len(g.Terms)), nil)
+
+	slices.SortStableFunc(results, func(a, b grammarResult) int {
+		return b.end - a.end
+	})
+
+	return results
+}
+
+func (m *grammarMatcher) matchEmpty(g *Grammar, pos int) (grammarResult, bool) {
+	for _, r := range m.match(g, pos) {
+		if r.end == pos {
+			return r, true
+		}
+	}
+
+	return grammarResult{}, false
+}
+
+func isDashedIdent(v Tokens) bool {
+	return v[0].Type == TokenIdent && strings.HasPrefix(v[0].Data, "--")
+}
+
+func isIdentValue(v Tokens) bool {
+	return v[0].Type == TokenIdent
+}
+
+func isLineNames(v Tokens) bool {
+	if v[0].Type != TokenOpenBracket {
+		return false
+	}
+
+	for _, tk := range v[1 : len(v)-1] {
+		switch tk.Type {
+		case TokenWhitespace, TokenComment:
+		case TokenIdent:
+			if !isCustomIdent(Tokens{tk}) || strings.EqualFold(tk.Data, "span") || strings.EqualFold(tk.Data, "auto") {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDimension(v Tokens) bool {
+	return v[0].Type == TokenDimension || isMathOfType(v, UnitPercentage, UnitLength, UnitAngle, UnitTime, UnitFrequency, UnitResolution, UnitFlex)
+}
+
+func isFlex(v Tokens) bool {
+	return dimensionUnit(v[0]) == "fr" || isMathOfType(v, UnitPercentage, UnitFlex)
+}
+
+func isFrequency(v Tokens) bool {
+	switch dimensionUnit(v[0]) {
+	case "hz", "khz":
+		return true
+	}
+
+	return isMathOfType(v, UnitPercentage, UnitFrequency)
+}
+
+func isHexColor(v Tokens) bool {
+	return v[0].Type == TokenHash && isColor(v)
+}
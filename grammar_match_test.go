@@ -0,0 +1,291 @@
+package css
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGrammarMatch(t *testing.T) {
+	for n, test := range [...]struct {
+		Grammar, Input string
+		Match          bool
+	}{
+		{ // 1
+			Grammar: "auto",
+			Input:   "AUTO",
+			Match:   true,
+		},
+		{ // 2
+			Grammar: "auto",
+			Input:   "none",
+		},
+		{ // 3
+			Grammar: "[ <length> | <percentage> ]{1,4}",
+			Input:   "1px 10% 0 2em",
+			Match:   true,
+		},
+		{ // 4
+			Grammar: "[ <length> | <percentage> ]{1,4}",
+			Input:   "1px 10% 0 2em 3px",
+		},
+		{ // 5
+			Grammar: "[ <length> | <percentage> ]{1,4}",
+			Input:   "",
+		},
+		{ // 6
+			Grammar: "<color>? && <line-style>",
+			Input:   "solid red",
+			Match:   true,
+		},
+		{ // 7
+			Grammar: "<color>? && <line-style>",
+			Input:   "dashed",
+			Match:   true,
+		},
+		{ // 8
+			Grammar: "<color>? && <line-style>",
+			Input:   "red",
+		},
+		{ // 9
+			Grammar: "<line-width> || <line-style> || <color>",
+			Input:   "#f00 thin dotted",
+			Match:   true,
+		},
+		{ // 10
+			Grammar: "<line-width> || <line-style> || <color>",
+			Input:   "thin thin",
+		},
+		{ // 11
+			Grammar: "<length>#",
+			Input:   "1px, 2px,3px",
+			Match:   true,
+		},
+		{ // 12
+			Grammar: "<length>#",
+			Input:   "1px, 2px,",
+		},
+		{ // 13
+			Grammar: "<length>#",
+			Input:   "1px 2px",
+		},
+		{ // 14
+			Grammar: "<length>#{2}",
+			Input:   "1px",
+		},
+		{ // 15
+			Grammar: "<length [0,∞]>",
+			Input:   "-1px",
+		},
+		{ // 16
+			Grammar: "<length [0,∞]>",
+			Input:   "calc(-1px)",
+			Match:   true,
+		},
+		{ // 17
+			Grammar: "<integer [1,10]>",
+			Input:   "10",
+			Match:   true,
+		},
+		{ // 18
+			Grammar: "<integer [1,10]>",
+			Input:   "11",
+		},
+		{ // 19
+			Grammar: "<length> / <number>",
+			Input:   "1px/2",
+			Match:   true,
+		},
+		{ // 20
+			Grammar: "rgb( <number>{3} [ / <alpha-value> ]? )",
+			Input:   "rgb(1 2 3 / 50%)",
+			Match:   true,
+		},
+		{ // 21
+			Grammar: "rgb( <number>{3} [ / <alpha-value> ]? )",
+			Input:   "rgb(1 2)",
+		},
+		{ // 22
+			Grammar: "<rgb()>",
+			Input:   "RGB(1 2 3)",
+			Match:   true,
+		},
+		{ // 23
+			Grammar: "a? , b? , c",
+			Input:   "c",
+			Match:   true,
+		},
+		{ // 24
+			Grammar: "a? , b? , c",
+			Input:   "a, c",
+			Match:   true,
+		},
+		{ // 25
+			Grammar: "a? , b? , c",
+			Input:   "a c",
+		},
+		{ // 26
+			Grammar: "a , b?",
+			Input:   "a",
+			Match:   true,
+		},
+		{ // 27
+			Grammar: "a , b?",
+			Input:   "a,",
+		},
+		{ // 28
+			Grammar: "[ a? b? ]!",
+			Input:   "",
+		},
+		{ // 29
+			Grammar: "[ a? b? ]! c",
+			Input:   "b c",
+			Match:   true,
+		},
+		{ // 30
+			Grammar: "<position>",
+			Input:   "left 10px top",
+			Match:   true,
+		},
+		{ // 31
+			Grammar: "<position>",
+			Input:   "top left",
+			Match:   true,
+		},
+		{ // 32
+			Grammar: "<transform-list> | none",
+			Input:   "rotate(45deg) scale(2)",
+			Match:   true,
+		},
+		{ // 33
+			Grammar: "<declaration-value>",
+			Input:   "a , (b) [c]",
+			Match:   true,
+		},
+		{ // 34
+			Grammar: "<'width'>",
+			Input:   "auto",
+			Match:   true,
+		},
+		{ // 35
+			Grammar: "<unknown-type>",
+			Input:   "a",
+		},
+		{ // 36
+			Grammar: "<custom-ident>+",
+			Input:   "a b inherit",
+		},
+		{ // 37
+			Grammar: "a* b",
+			Input:   "a a a b",
+			Match:   true,
+		},
+		{ // 38
+			Grammar: "[ a | a b ] b",
+			Input:   "a b",
+			Match:   true,
+		},
+		{ // 39
+			Grammar: "foo()",
+			Input:   "foo( )",
+			Match:   true,
+		},
+		{ // 40
+			Grammar: "<dashed-ident> | <hex-color>",
+			Input:   "#abc",
+			Match:   true,
+		},
+		{ // 41
+			Grammar: "<number> [ , <number> ]*",
+			Input:   "1 2",
+		},
+		{ // 42
+			Grammar: "<number> [ , <number> ]*",
+			Input:   "1, 2, 3",
+			Match:   true,
+		},
+		{ // 43
+			Grammar: "[ <url> , ]* auto",
+			Input:   "url(a) auto",
+		},
+		{ // 44
+			Grammar: "[ <url> , ]* auto",
+			Input:   "url(a), url(b), auto",
+			Match:   true,
+		},
+		{ // 45
+			Grammar: "<'not-a-property'>",
+			Input:   "auto",
+		},
+		{ // 46
+			Grammar: "<'MARGIN-TOP'>{1,4}",
+			Input:   "0 auto 1px",
+			Match:   true,
+		},
+		{ // 47
+			Grammar: "<'padding-top'>",
+			Input:   "-1px",
+		},
+		{ // 48
+			Grammar: "<'transition'>",
+			Input:   strings.Repeat("opacity 1s ease-in 0s, ", 49) + "opacity 1s ease-in 0s",
+			Match:   true,
+		},
+		{ // 49
+			Grammar: "<'box-shadow'>",
+			Input:   strings.Repeat("1px 2px 3px red, ", 49) + "inset 1px 2px",
+			Match:   true,
+		},
+		{ // 50
+			Grammar: "[ a? a? ]* b",
+			Input:   strings.Repeat("a ", 50) + "b",
+			Match:   true,
+		},
+		{ // 51
+			Grammar: "[ a? a? ]* b",
+			Input:   strings.Repeat("a ", 50),
+		},
+	} {
+		g, err := ParseGrammar(test.Grammar)
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		if _, ok := g.Match(tokenise(t, test.Input)); ok != test.Match {
+			t.Errorf("test %d: expecting match %v, got %v", n+1, test.Match, ok)
+		}
+	}
+}
+
+func TestGrammarMatchTerms(t *testing.T) {
+	g, err := ParseGrammar("<length>{1,2} && [ <color> | none ]")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	m, ok := g.Match(tokenise(t, "red 1px 2px"))
+	if !ok {
+		t.Fatal("expecting match")
+	}
+
+	if len(m.Terms) != 2 {
+		t.Fatalf("expecting 2 terms, got %d", len(m.Terms))
+	}
+
+	colour, lengths := m.Terms[0], m.Terms[1]
+
+	if colour.Grammar != g.Terms[1] {
+		t.Errorf("expecting first term to match %s, got %s", g.Terms[1], colour.Grammar)
+	} else if len(colour.Terms) != 1 || colour.Terms[0].Grammar.Type != "color" || Tokens(colour.Values[0]).String() != "red" {
+		t.Errorf("expecting colour to match <color>")
+	}
+
+	if lengths.Grammar != g.Terms[0] {
+		t.Errorf("expecting second term to match %s, got %s", g.Terms[0], lengths.Grammar)
+	} else if len(lengths.Terms) != 2 {
+		t.Errorf("expecting 2 repetitions, got %d", len(lengths.Terms))
+	} else if v := lengths.Terms[1].Values[0].String(); v != "2px" {
+		t.Errorf("expecting second repetition to be 2px, got %s", v)
+	}
+}
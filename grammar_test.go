@@ -0,0 +1,150 @@
+package css
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseGrammar(t *testing.T) {
+	for n, test := range [...]struct {
+		Input  string
+		Output string
+		Err    error
+	}{
+		{ // 1
+			Input:  "auto",
+			Output: "auto",
+		},
+		{ // 2
+			Input:  "<length>",
+			Output: "<length>",
+		},
+		{ // 3
+			Input:  "[ <length> | <percentage> ]{1,4}",
+			Output: "[ <length> | <percentage> ]{1,4}",
+		},
+		{ // 4
+			Input:  "<color>? && <line-style>",
+			Output: "<color>? && <line-style>",
+		},
+		{ // 5
+			Input:  "a b | c || d && e f",
+			Output: "a b | c || d && e f",
+		},
+		{ // 6
+			Input:  "[ a | b ] [ c || d ]",
+			Output: "[ a | b ] [ c || d ]",
+		},
+		{ // 7
+			Input:  "[a|b]",
+			Output: "a | b",
+		},
+		{ // 8
+			Input:  "<length>* <number>+ <angle>? <time># <integer>#{2,3} <flex>{2} <string>{1,}",
+			Output: "<length>* <number>+ <angle>? <time># <integer>#{2,3} <flex>{2} <string>+",
+		},
+		{ // 9
+			Input:  "[ a? b? ]!",
+			Output: "[ a? b? ]!",
+		},
+		{ // 10
+			Input:  "<length [0,∞]> | <integer [-∞,10]>",
+			Output: "<length [0,∞]> | <integer [-∞,10]>",
+		},
+		{ // 11
+			Input:  "<'margin-top'>{1,4}",
+			Output: "<'margin-top'>{1,4}",
+		},
+		{ // 12
+			Input:  "rgb( <number>#{3} , <alpha-value>? )",
+			Output: "rgb(<number>#{3} , <alpha-value>?)",
+		},
+		{ // 13
+			Input:  "<rgb()> | <hsl()>",
+			Output: "<rgb()> | <hsl()>",
+		},
+		{ // 14
+			Input:  "<length> / <number> '['",
+			Output: "<length> / <number> \"[\"",
+		},
+		{ // 15
+			Input:  "<shadow>#?",
+			Output: "[ <shadow># ]?",
+		},
+		{ // 16
+			Input:  "foo()",
+			Output: "foo()",
+		},
+		{ // 17
+			Input: "a &",
+			Err:   ErrUnexpectedToken,
+		},
+		{ // 18
+			Input: "[ ]",
+			Err:   ErrInvalidGrammar,
+		},
+		{ // 19
+			Input: "<length",
+			Err:   ErrInvalidGrammar,
+		},
+		{ // 20
+			Input: "a{4,2}",
+			Err:   ErrInvalidGrammar,
+		},
+		{ // 21
+			Input: "<length [10,0]>",
+			Err:   ErrInvalidGrammar,
+		},
+		{ // 22
+			Input: "| a",
+			Err:   ErrInvalidGrammar,
+		},
+	} {
+		g, err := ParseGrammar(test.Input)
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if out := g.String(); out != test.Output {
+			t.Errorf("test %d: expecting %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
+
+func TestParseGrammarStructure(t *testing.T) {
+	g, err := ParseGrammar("none | <length [0,∞]>{1,2}# && foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &Grammar{
+		Combinator: GrammarOne,
+		Terms: []*Grammar{
+			{Keyword: "none"},
+			{
+				Combinator: GrammarAll,
+				Terms: []*Grammar{
+					{
+						Terms: []*Grammar{
+							{
+								Type:       "length",
+								Range:      &GrammarRange{Min: 0, Max: math.Inf(1)},
+								Multiplier: &GrammarMultiplier{Min: 1, Max: 2},
+							},
+						},
+						Multiplier: &GrammarMultiplier{Min: 1, Max: -1, Comma: true},
+					},
+					{Keyword: "foo"},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(g, expected) {
+		t.Errorf("expecting %s, got %s", expected, g)
+	}
+}
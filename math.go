@@ -0,0 +1,775 @@
+package css
+
+import (
+	"math"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// MathNode is a node in the calculation tree produced by parsing a CSS math
+// function, such as calc() or clamp().
+//
+// Exactly one of Value, Sum, Product, Negate, Invert, Function, or Raw will be
+// set. Subtraction is represented as a Sum containing a Negate node, and
+// division as a Product containing an Invert node. The constants e, pi,
+// infinity, -infinity, and NaN are parsed into Values.
+//
+// Raw holds an unresolved substitution function, such as var(), which is left
+// untouched by simplification and prevents evaluation.
+type MathNode struct {
+	Value    *Quantity
+	Sum      []*MathNode
+	Product  []*MathNode
+	Negate   *MathNode
+	Invert   *MathNode
+	Function *MathFunction
+	Raw      Tokens
+	Tokens   Tokens
+}
+
+// MathFunction represents a math function and its arguments.
+//
+// A nil argument represents 'none' in the first or last argument of clamp().
+// Rounding holds the rounding strategy given to round(), if any.
+type MathFunction struct {
+	Name     string
+	Rounding string
+	Args     []*MathNode
+}
+
+// ParseMath parses a single math function, such as 'calc(100% - 2em)', into a
+// calculation tree.
+func ParseMath(value Tokens) (*MathNode, error) {
+	var n MathNode
+
+	c := newCSSParserFromTokens(value)
+
+	if err := parseTrimmed(&c, n.parse); err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+func (n *MathNode) parse(c *cssParser) error {
+	if !isMathFunction(Tokens{Token{Token: c.Peek()}}) {
+		return c.Error("MathNode", ErrInvalidMath)
+	}
+
+	n.Function = new(MathFunction)
+
+	if err := n.Function.parse(c); err != nil {
+		return c.Error("MathNode", err)
+	}
+
+	n.Tokens = c.ToTokens()
+
+	return nil
+}
+
+var mathArgumentCounts = map[string][2]int{
+	"calc":  {1, 1},
+	"min":   {1, -1},
+	"max":   {1, -1},
+	"clamp": {3, 3},
+	"round": {1, 2},
+	"mod":   {2, 2},
+	"rem":   {2, 2},
+	"sin":   {1, 1},
+	"cos":   {1, 1},
+	"tan":   {1, 1},
+	"asin":  {1, 1},
+	"acos":  {1, 1},
+	"atan":  {1, 1},
+	"atan2": {2, 2},
+	"pow":   {2, 2},
+	"sqrt":  {1, 1},
+	"hypot": {1, -1},
+	"log":   {1, 2},
+	"exp":   {1, 1},
+	"abs":   {1, 1},
+	"sign":  {1, 1},
+}
+
+var roundingStrategies = [...]string{"nearest", "up", "down", "to-zero"}
+
+func (f *MathFunction) parse(c *cssParser) error {
+	f.Name = functionName(*c.Next())
+
+	counts, ok := mathArgumentCounts[f.Name]
+	if !ok {
+		return c.Error("MathFunction", ErrInvalidMath)
+	}
+
+	c.AcceptRunWhitespace()
+
+	if f.Name == "round" {
+		for _, strategy := range roundingStrategies {
+			if c.AcceptIdent(strategy) {
+				f.Rounding = strategy
+
+				c.AcceptRunWhitespace()
+
+				if !c.Accept(TokenComma) {
+					return c.Error("MathFunction", ErrInvalidMath)
+				}
+
+				c.AcceptRunWhitespace()
+
+				break
+			}
+		}
+	}
+
+	for {
+		if f.Name == "clamp" && len(f.Args) != 1 && c.AcceptIdent("none") {
+			f.Args = append(f.Args, nil)
+		} else {
+			var arg MathNode
+
+			d := c.NewGoal()
+
+			if err := arg.parseSum(&d); err != nil {
+				return c.Error("MathFunction", err)
+			}
+
+			c.Score(d)
+
+			f.Args = append(f.Args, &arg)
+		}
+
+		c.AcceptRunWhitespace()
+
+		if c.Accept(TokenCloseParen) {
+			break
+		} else if !c.Accept(TokenComma) {
+			return c.Error("MathFunction", ErrInvalidMath)
+		}
+
+		c.AcceptRunWhitespace()
+	}
+
+	if len(f.Args) < counts[0] || counts[1] >= 0 && len(f.Args) > counts[1] {
+		return c.Error("MathFunction", ErrInvalidMath)
+	}
+
+	return nil
+}
+
+func (n *MathNode) parseSum(c *cssParser) error {
+	var (
+		terms  []*MathNode
+		negate bool
+	)
+
+	for {
+		var term MathNode
+
+		d := c.NewGoal()
+
+		if err := term.parseProduct(&d); err != nil {
+			return err
+		}
+
+		c.Score(d)
+
+		if negate {
+			terms = append(terms, &MathNode{Negate: &term, Tokens: term.Tokens})
+		} else {
+			terms = append(terms, &term)
+		}
+
+		d = c.NewGoal()
+
+		if d.AcceptRunWhitespace(); len(d) == 0 {
+			break
+		} else if d.AcceptToken(parser.Token{Type: TokenDelim, Data: "-"}) {
+			negate = true
+		} else if d.AcceptToken(parser.Token{Type: TokenDelim, Data: "+"}) {
+			negate = false
+		} else {
+			break
+		}
+
+		if !d.Accept(TokenWhitespace, TokenComment) {
+			return d.Error("MathNode", ErrInvalidMath)
+		}
+
+		d.AcceptRunWhitespace()
+		c.Score(d)
+	}
+
+	if len(terms) == 1 {
+		*n = *terms[0]
+	} else {
+		n.Sum = terms
+	}
+
+	n.Tokens = c.ToTokens()
+
+	return nil
+}
+
+func (n *MathNode) parseProduct(c *cssParser) error {
+	var factors []*MathNode
+
+	for {
+		var (
+			factor MathNode
+			invert bool
+		)
+
+		if len(factors) > 0 {
+			d := c.NewGoal()
+
+			d.AcceptRunWhitespace()
+
+			if d.AcceptToken(parser.Token{Type: TokenDelim, Data: "/"}) {
+				invert = true
+			} else if !d.AcceptToken(parser.Token{Type: TokenDelim, Data: "*"}) {
+				break
+			}
+
+			d.AcceptRunWhitespace()
+			c.Score(d)
+		}
+
+		d := c.NewGoal()
+
+		if err := factor.parseValue(&d); err != nil {
+			return err
+		}
+
+		c.Score(d)
+
+		if invert {
+			factors = append(factors, &MathNode{Invert: &factor, Tokens: factor.Tokens})
+		} else {
+			factors = append(factors, &factor)
+		}
+	}
+
+	if len(factors) == 1 {
+		*n = *factors[0]
+	} else {
+		n.Product = factors
+	}
+
+	n.Tokens = c.ToTokens()
+
+	return nil
+}
+
+var mathConstants = map[string]float64{
+	"e":         math.E,
+	"pi":        math.Pi,
+	"infinity":  math.Inf(1),
+	"-infinity": math.Inf(-1),
+	"nan":       math.NaN(),
+}
+
+func (n *MathNode) parseValue(c *cssParser) error {
+	switch tk := c.Peek(); tk.Type {
+	case TokenNumber, TokenPercentage, TokenDimension:
+		q, err := ParseQuantity(*c.Next())
+		if err != nil {
+			return c.Error("MathNode", err)
+		} else if q.Category() == UnitUnknown {
+			return c.Error("MathNode", ErrUnknownUnit)
+		}
+
+		n.Value = &q
+	case TokenIdent:
+		v, ok := mathConstants[strings.ToLower(tk.Data)]
+		if !ok {
+			return c.Error("MathNode", ErrInvalidMath)
+		}
+
+		c.Skip()
+
+		n.Value = &Quantity{Value: v}
+	case TokenOpenParen:
+		c.Skip()
+		c.AcceptRunWhitespace()
+
+		d := c.NewGoal()
+
+		if err := n.parseSum(&d); err != nil {
+			return err
+		}
+
+		c.Score(d)
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenCloseParen) {
+			return c.Error("MathNode", ErrInvalidMath)
+		}
+	case TokenFunction:
+		switch name := strings.ToLower(strings.TrimSuffix(tk.Data, "(")); name {
+		case "var", "env", "attr":
+			c.AcceptComponentValue()
+
+			n.Raw = c.ToTokens()
+		default:
+			n.Function = new(MathFunction)
+
+			if err := n.Function.parse(c); err != nil {
+				return c.Error("MathNode", err)
+			}
+		}
+	default:
+		return c.Error("MathNode", ErrInvalidMath)
+	}
+
+	n.Tokens = c.ToTokens()
+
+	return nil
+}
+
+// mathType holds the exponent of each base type of a calculation, indexed by
+// UnitCategory; UnitNumber is unused, as numbers have no base type.
+type mathType struct {
+	powers  [UnitFlex + 1]int
+	unknown bool
+}
+
+func (t mathType) category() (UnitCategory, bool) {
+	category := UnitNumber
+
+	for c, p := range t.powers {
+		if p == 0 {
+			continue
+		} else if p != 1 || category != UnitNumber {
+			return UnitUnknown, false
+		}
+
+		category = UnitCategory(c)
+	}
+
+	return category, true
+}
+
+func (t mathType) add(u mathType) (mathType, bool) {
+	if t.unknown || u.unknown {
+		return mathType{unknown: true}, true
+	}
+
+	return t, t.powers == u.powers
+}
+
+func (t mathType) multiply(u mathType) mathType {
+	for n, p := range u.powers {
+		t.powers[n] += p
+	}
+
+	t.unknown = t.unknown || u.unknown
+
+	return t
+}
+
+func (t mathType) invert() mathType {
+	for n, p := range t.powers {
+		t.powers[n] = -p
+	}
+
+	return t
+}
+
+func (t mathType) is(categories ...UnitCategory) bool {
+	if t.unknown {
+		return true
+	}
+
+	c, ok := t.category()
+
+	if !ok {
+		return false
+	}
+
+	for _, cat := range categories {
+		if c == cat {
+			return true
+		}
+	}
+
+	return false
+}
+
+func categoryType(c UnitCategory) mathType {
+	var t mathType
+
+	if c != UnitNumber {
+		t.powers[c] = 1
+	}
+
+	return t
+}
+
+// Type returns the UnitCategory that the calculation resolves to, checking
+// that all of its operations are valid per CSS Values 4.
+//
+// The percentages argument is the category that percentages within the
+// calculation resolve against, such as UnitLength for 'width'; when it is
+// UnitPercentage, percentages are treated as a distinct type that cannot be
+// combined with dimensions.
+//
+// Calculations that contain Raw nodes, such as var(), cannot be fully typed
+// and return UnitUnknown without error.
+func (n *MathNode) Type(percentages UnitCategory) (UnitCategory, error) {
+	t, err := n.mathType(percentages)
+	if err != nil {
+		return UnitUnknown, err
+	} else if t.unknown {
+		return UnitUnknown, nil
+	}
+
+	c, ok := t.category()
+	if !ok {
+		return UnitUnknown, ErrInvalidMathType
+	}
+
+	return c, nil
+}
+
+func (n *MathNode) mathType(percentages UnitCategory) (mathType, error) {
+	switch {
+	case n.Value != nil:
+		c := n.Value.Category()
+
+		if c == UnitUnknown {
+			return mathType{}, ErrUnknownUnit
+		} else if c == UnitPercentage {
+			c = percentages
+		}
+
+		return categoryType(c), nil
+	case n.Raw != nil:
+		return mathType{unknown: true}, nil
+	case n.Negate != nil:
+		return n.Negate.mathType(percentages)
+	case n.Invert != nil:
+		t, err := n.Invert.mathType(percentages)
+
+		return t.invert(), err
+	case n.Sum != nil:
+		return sameMathType(n.Sum, percentages)
+	case n.Product != nil:
+		var t mathType
+
+		for _, f := range n.Product {
+			ft, err := f.mathType(percentages)
+			if err != nil {
+				return t, err
+			}
+
+			t = t.multiply(ft)
+		}
+
+		return t, nil
+	case n.Function != nil:
+		return n.Function.mathType(percentages)
+	}
+
+	return mathType{}, ErrInvalidMath
+}
+
+func sameMathType(nodes []*MathNode, percentages UnitCategory) (mathType, error) {
+	var (
+		t     mathType
+		first = true
+	)
+
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		nt, err := node.mathType(percentages)
+		if err != nil {
+			return t, err
+		}
+
+		if first {
+			t, first = nt, false
+		} else if sum, ok := t.add(nt); !ok {
+			return t, ErrInvalidMathType
+		} else {
+			t = sum
+		}
+	}
+
+	return t, nil
+}
+
+func (f *MathFunction) mathType(percentages UnitCategory) (mathType, error) {
+	t, err := sameMathType(f.Args, percentages)
+	if err != nil {
+		return t, err
+	}
+
+	switch f.Name {
+	case "round":
+		if len(f.Args) == 1 && !t.is(UnitNumber) {
+			return t, ErrInvalidMathType
+		}
+	case "sign":
+		return mathType{unknown: t.unknown}, nil
+	case "sin", "cos", "tan":
+		if !t.is(UnitNumber, UnitAngle) {
+			return t, ErrInvalidMathType
+		}
+
+		return mathType{unknown: t.unknown}, nil
+	case "asin", "acos", "atan":
+		if !t.is(UnitNumber) {
+			return t, ErrInvalidMathType
+		}
+
+		return categoryType(UnitAngle), nil
+	case "atan2":
+		return categoryType(UnitAngle), nil
+	case "pow", "sqrt", "log", "exp":
+		if !t.is(UnitNumber) {
+			return t, ErrInvalidMathType
+		}
+	}
+
+	return t, nil
+}
+
+// mathValue is an intermediate result of evaluating a calculation, in the
+// canonical units of its type.
+type mathValue struct {
+	value float64
+	typ   mathType
+}
+
+// Evaluate computes the result of the calculation, resolving relative units
+// and percentages using the given UnitContext, which may be nil if the
+// calculation contains only absolute units.
+//
+// The result is expressed in the canonical unit of its category. Percentages
+// that cannot be resolved are only permitted when they are not combined with
+// other dimensions, in which case the result is a percentage.
+func (n *MathNode) Evaluate(ctx *UnitContext) (Quantity, error) {
+	v, err := n.evaluate(ctx)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	c, ok := v.typ.category()
+	if !ok {
+		return Quantity{}, ErrInvalidMathType
+	}
+
+	return Quantity{Value: v.value, Unit: canonicalUnits[c]}, nil
+}
+
+func (n *MathNode) evaluate(ctx *UnitContext) (mathValue, error) {
+	switch {
+	case n.Value != nil:
+		q, err := n.Value.Resolve(ctx)
+		if err != nil {
+			return mathValue{}, err
+		}
+
+		return mathValue{value: q.Value, typ: categoryType(q.Category())}, nil
+	case n.Raw != nil:
+		return mathValue{}, ErrUnresolvedMath
+	case n.Negate != nil:
+		v, err := n.Negate.evaluate(ctx)
+		v.value = -v.value
+
+		return v, err
+	case n.Invert != nil:
+		v, err := n.Invert.evaluate(ctx)
+
+		return mathValue{value: 1 / v.value, typ: v.typ.invert()}, err
+	case n.Sum != nil:
+		var total mathValue
+
+		for i, term := range n.Sum {
+			v, err := term.evaluate(ctx)
+			if err != nil {
+				return total, err
+			}
+
+			if i == 0 {
+				total = v
+			} else if total.typ.powers != v.typ.powers {
+				return total, ErrInvalidMathType
+			} else {
+				total.value += v.value
+			}
+		}
+
+		return total, nil
+	case n.Product != nil:
+		total := mathValue{value: 1}
+
+		for _, factor := range n.Product {
+			v, err := factor.evaluate(ctx)
+			if err != nil {
+				return total, err
+			}
+
+			total.value *= v.value
+			total.typ = total.typ.multiply(v.typ)
+		}
+
+		return total, nil
+	case n.Function != nil:
+		return n.Function.evaluate(ctx)
+	}
+
+	return mathValue{}, ErrInvalidMath
+}
+
+func (f *MathFunction) evaluate(ctx *UnitContext) (mathValue, error) {
+	args := make([]mathValue, len(f.Args))
+
+	for i, arg := range f.Args {
+		if arg != nil {
+			v, err := arg.evaluate(ctx)
+			if err != nil {
+				return v, err
+			}
+
+			args[i] = v
+		}
+	}
+
+	if f.Name == "clamp" {
+		if f.Args[0] == nil {
+			args[0] = mathValue{value: math.Inf(-1), typ: args[1].typ}
+		}
+
+		if f.Args[2] == nil {
+			args[2] = mathValue{value: math.Inf(1), typ: args[1].typ}
+		}
+	}
+
+	first := args[0]
+
+	for _, arg := range args[1:] {
+		if arg.typ.powers != first.typ.powers {
+			return first, ErrInvalidMathType
+		}
+	}
+
+	result := mathValue{typ: first.typ}
+	number := mathType{}
+
+	switch f.Name {
+	case "calc":
+		result.value = first.value
+	case "min":
+		result.value = first.value
+
+		for _, arg := range args[1:] {
+			result.value = min(result.value, arg.value)
+		}
+	case "max":
+		result.value = first.value
+
+		for _, arg := range args[1:] {
+			result.value = max(result.value, arg.value)
+		}
+	case "clamp":
+		result.value = max(args[0].value, min(args[1].value, args[2].value))
+	case "round":
+		step := 1.0
+
+		if len(args) == 2 {
+			step = args[1].value
+		} else if first.typ != number {
+			return result, ErrInvalidMathType
+		}
+
+		result.value = roundTo(first.value, step, f.Rounding)
+	case "mod":
+		result.value = first.value - args[1].value*math.Floor(first.value/args[1].value)
+	case "rem":
+		result.value = math.Mod(first.value, args[1].value)
+	case "abs":
+		result.value = math.Abs(first.value)
+	case "sign":
+		result = mathValue{value: sign(first.value)}
+	case "sin", "cos", "tan":
+		radians := first.value
+
+		if first.typ == categoryType(UnitAngle) {
+			radians *= math.Pi / 180
+		} else if first.typ != number {
+			return result, ErrInvalidMathType
+		}
+
+		result = mathValue{value: map[string]func(float64) float64{"sin": math.Sin, "cos": math.Cos, "tan": math.Tan}[f.Name](radians)}
+	case "asin", "acos", "atan":
+		if first.typ != number {
+			return result, ErrInvalidMathType
+		}
+
+		fn := map[string]func(float64) float64{"asin": math.Asin, "acos": math.Acos, "atan": math.Atan}[f.Name]
+		result = mathValue{value: fn(first.value) * 180 / math.Pi, typ: categoryType(UnitAngle)}
+	case "atan2":
+		result = mathValue{value: math.Atan2(first.value, args[1].value) * 180 / math.Pi, typ: categoryType(UnitAngle)}
+	case "pow", "sqrt", "log", "exp":
+		if first.typ != number {
+			return result, ErrInvalidMathType
+		}
+
+		switch f.Name {
+		case "pow":
+			result.value = math.Pow(first.value, args[1].value)
+		case "sqrt":
+			result.value = math.Sqrt(first.value)
+		case "log":
+			result.value = math.Log(first.value)
+
+			if len(args) == 2 {
+				result.value /= math.Log(args[1].value)
+			}
+		case "exp":
+			result.value = math.Exp(first.value)
+		}
+	case "hypot":
+		for _, arg := range args {
+			result.value = math.Hypot(result.value, arg.value)
+		}
+	default:
+		return result, ErrInvalidMath
+	}
+
+	return result, nil
+}
+
+func roundTo(v, step float64, strategy string) float64 {
+	if step == 0 {
+		return math.NaN()
+	}
+
+	step = math.Abs(step)
+
+	switch strategy {
+	case "up":
+		return math.Ceil(v/step) * step
+	case "down":
+		return math.Floor(v/step) * step
+	case "to-zero":
+		return math.Trunc(v/step) * step
+	}
+
+	return math.Floor(v/step+0.5) * step
+}
+
+func sign(v float64) float64 {
+	if v > 0 {
+		return 1
+	} else if v < 0 {
+		return -1
+	}
+
+	return v
+}
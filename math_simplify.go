@@ -0,0 +1,550 @@
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// Simplify returns a simplified copy of the calculation tree, following the
+// CSS Values 4 simplification algorithm without requiring a UnitContext.
+//
+// Constants are folded, like units are combined, nested calc() functions are
+// removed, and functions whose arguments are all known are evaluated. Values
+// whose units cannot be converted without context, such as percentages and
+// font-relative lengths, are kept as separate terms, and Raw nodes are left
+// untouched.
+//
+// If the calculation simplifies to a single value, the returned node will
+// only have its Value set.
+func (n *MathNode) Simplify() *MathNode {
+	switch {
+	case n.Value != nil:
+		v := *n.Value
+
+		return &MathNode{Value: &v}
+	case n.Raw != nil:
+		return &MathNode{Raw: n.Raw}
+	case n.Negate != nil:
+		return simplifyNegate(n.Negate.Simplify())
+	case n.Invert != nil:
+		return simplifyInvert(n.Invert.Simplify())
+	case n.Sum != nil:
+		return simplifySum(n.Sum)
+	case n.Product != nil:
+		return simplifyProduct(n.Product)
+	case n.Function != nil:
+		return n.Function.simplify()
+	}
+
+	return &MathNode{}
+}
+
+func simplifyNegate(child *MathNode) *MathNode {
+	if child.Value != nil {
+		child.Value.Value = -child.Value.Value
+
+		return child
+	} else if child.Negate != nil {
+		return child.Negate
+	}
+
+	return &MathNode{Negate: child}
+}
+
+func simplifyInvert(child *MathNode) *MathNode {
+	if child.Value != nil && child.Value.Unit == "" {
+		child.Value.Value = 1 / child.Value.Value
+
+		return child
+	} else if child.Invert != nil {
+		return child.Invert
+	}
+
+	return &MathNode{Invert: child}
+}
+
+// combineKey returns the unit under which a value can be combined with others,
+// and the value expressed in that unit.
+func combineKey(q Quantity) (string, float64) {
+	if c, ok := q.Canonical(); ok {
+		return c.Unit, c.Value
+	}
+
+	return q.Unit, q.Value
+}
+
+func simplifySum(terms []*MathNode) *MathNode {
+	var (
+		children []*MathNode
+		groups   = map[string]int{}
+		units    = map[string]string{}
+	)
+
+	var add func(*MathNode)
+
+	add = func(term *MathNode) {
+		if term.Sum != nil {
+			for _, t := range term.Sum {
+				add(t)
+			}
+
+			return
+		} else if term.Value == nil {
+			children = append(children, term)
+
+			return
+		}
+
+		key, value := combineKey(*term.Value)
+
+		if pos, ok := groups[key]; ok {
+			children[pos].Value.Value += value
+
+			if units[key] != term.Value.Unit {
+				units[key] = key
+			}
+
+			return
+		}
+
+		groups[key] = len(children)
+		units[key] = term.Value.Unit
+		children = append(children, &MathNode{Value: &Quantity{Value: value, Unit: key}})
+	}
+
+	for _, t := range terms {
+		add(t.Simplify())
+	}
+
+	for key, pos := range groups {
+		if units[key] != key {
+			if q, err := children[pos].Value.ConvertTo(units[key]); err == nil {
+				children[pos].Value = &q
+			}
+		}
+	}
+
+	if len(children) == 1 {
+		return children[0]
+	}
+
+	return &MathNode{Sum: children}
+}
+
+func simplifyProduct(factors []*MathNode) *MathNode {
+	var (
+		children    []*MathNode
+		coefficient = 1.0
+		numeric     = true
+	)
+
+	var add func(*MathNode)
+
+	add = func(factor *MathNode) {
+		if factor.Product != nil {
+			for _, f := range factor.Product {
+				add(f)
+			}
+
+			return
+		} else if factor.Value != nil && factor.Value.Unit == "" {
+			coefficient *= factor.Value.Value
+
+			return
+		} else if (factor.Value == nil || factor.Value.IsRelative()) && (factor.Invert == nil || factor.Invert.Value == nil || factor.Invert.Value.IsRelative()) {
+			numeric = false
+		}
+
+		children = append(children, factor)
+	}
+
+	for _, f := range factors {
+		add(f.Simplify())
+	}
+
+	if len(children) == 0 {
+		return &MathNode{Value: &Quantity{Value: coefficient}}
+	} else if len(children) == 1 && children[0].Value != nil {
+		children[0].Value.Value *= coefficient
+
+		return children[0]
+	} else if len(children) == 1 && children[0].Sum != nil && allValues(children[0].Sum) {
+		for _, term := range children[0].Sum {
+			term.Value.Value *= coefficient
+		}
+
+		return children[0]
+	} else if numeric {
+		product := &MathNode{Product: append(children, &MathNode{Value: &Quantity{Value: coefficient}})}
+
+		if v, err := product.evaluate(nil); err == nil {
+			if c, ok := v.typ.category(); ok && c != UnitPercentage {
+				return &MathNode{Value: &Quantity{Value: v.value, Unit: canonicalUnits[c]}}
+			}
+		}
+	}
+
+	if coefficient != 1 {
+		children = append([]*MathNode{{Value: &Quantity{Value: coefficient}}}, children...)
+	} else if len(children) == 1 {
+		return children[0]
+	}
+
+	return &MathNode{Product: children}
+}
+
+func allValues(nodes []*MathNode) bool {
+	for _, n := range nodes {
+		if n == nil || n.Value == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *MathFunction) simplify() *MathNode {
+	args := make([]*MathNode, len(f.Args))
+
+	for i, arg := range f.Args {
+		if arg != nil {
+			args[i] = arg.Simplify()
+		}
+	}
+
+	switch f.Name {
+	case "calc":
+		return args[0]
+	case "min", "max":
+		args = simplifyMinMax(f.Name, args)
+
+		if len(args) == 1 {
+			return args[0]
+		}
+	}
+
+	simplified := &MathFunction{Name: f.Name, Rounding: f.Rounding, Args: args}
+
+	if unit, ok := commonUnit(args); ok {
+		if v, err := simplified.evaluate(nil); err == nil {
+			if c, ok := v.typ.category(); ok {
+				q := Quantity{Value: v.value, Unit: canonicalUnits[c]}
+
+				if converted, err := q.ConvertTo(unit); err == nil {
+					q = converted
+				}
+
+				return &MathNode{Value: &q}
+			}
+		}
+	}
+
+	return &MathNode{Function: simplified}
+}
+
+// commonUnit returns the unit shared by all of the non-nil arguments, if they
+// are all values, or the empty string if they have differing units.
+func commonUnit(args []*MathNode) (string, bool) {
+	var (
+		unit  string
+		first = true
+	)
+
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		} else if arg.Value == nil || arg.Value.IsRelative() {
+			return "", false
+		}
+
+		if first {
+			unit, first = arg.Value.Unit, false
+		} else if unit != arg.Value.Unit {
+			unit = ""
+		}
+	}
+
+	return unit, true
+}
+
+func simplifyMinMax(name string, args []*MathNode) []*MathNode {
+	var (
+		kept   []*MathNode
+		groups = map[string]int{}
+		values = map[string]float64{}
+	)
+
+	for _, arg := range args {
+		if arg.Value == nil {
+			kept = append(kept, arg)
+
+			continue
+		}
+
+		key, value := combineKey(*arg.Value)
+
+		pos, ok := groups[key]
+		if !ok {
+			groups[key] = len(kept)
+			values[key] = value
+			kept = append(kept, arg)
+		} else if name == "min" && value < values[key] || name == "max" && value > values[key] {
+			values[key] = value
+			kept[pos] = arg
+		}
+	}
+
+	return kept
+}
+
+// String returns the calculation serialised as CSS.
+//
+// A tree that consists of a single finite Value is written as that value,
+// such as '12px', and a math function is written as itself; anything else is
+// wrapped in calc().
+func (n *MathNode) String() string {
+	var sb strings.Builder
+
+	switch {
+	case n.Value != nil && isFinite(n.Value.Value), n.Function != nil:
+		n.writeTo(&sb)
+	case n.Value != nil:
+		sb.WriteString("calc(")
+		writeMathValue(&sb, Quantity{Value: n.Value.Value})
+
+		if n.Value.Unit != "" {
+			sb.WriteString(" * 1")
+			sb.WriteString(n.Value.Unit)
+		}
+
+		sb.WriteString(")")
+	default:
+		sb.WriteString("calc(")
+		n.writeTo(&sb)
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+func isFinite(v float64) bool {
+	return !math.IsInf(v, 0) && !math.IsNaN(v)
+}
+
+func (n *MathNode) writeTo(sb *strings.Builder) {
+	switch {
+	case n.Value != nil:
+		writeMathValue(sb, *n.Value)
+	case n.Raw != nil:
+		sb.WriteString(n.Raw.String())
+	case n.Negate != nil:
+		sb.WriteString("-1 * ")
+		n.Negate.writeOperand(sb)
+	case n.Invert != nil:
+		sb.WriteString("1 / ")
+		n.Invert.writeOperand(sb)
+	case n.Sum != nil:
+		for i, term := range n.Sum {
+			if i == 0 {
+				term.writeOperand(sb)
+			} else if term.Negate != nil {
+				sb.WriteString(" - ")
+				term.Negate.writeOperand(sb)
+			} else if term.Value != nil && term.Value.Value < 0 {
+				sb.WriteString(" - ")
+				writeMathValue(sb, Quantity{Value: -term.Value.Value, Unit: term.Value.Unit})
+			} else {
+				sb.WriteString(" + ")
+				term.writeOperand(sb)
+			}
+		}
+	case n.Product != nil:
+		for i, factor := range n.Product {
+			if i == 0 {
+				factor.writeOperand(sb)
+			} else if factor.Invert != nil {
+				sb.WriteString(" / ")
+				factor.Invert.writeOperand(sb)
+			} else {
+				sb.WriteString(" * ")
+				factor.writeOperand(sb)
+			}
+		}
+	case n.Function != nil:
+		sb.WriteString(n.Function.Name)
+		sb.WriteString("(")
+
+		if n.Function.Rounding != "" {
+			sb.WriteString(n.Function.Rounding)
+			sb.WriteString(", ")
+		}
+
+		for i, arg := range n.Function.Args {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+
+			if arg == nil {
+				sb.WriteString("none")
+			} else {
+				arg.writeTo(sb)
+			}
+		}
+
+		sb.WriteString(")")
+	}
+}
+
+// writeOperand writes the node as an operand of a sum or product, adding
+// parentheses where necessary to preserve precedence.
+func (n *MathNode) writeOperand(sb *strings.Builder) {
+	if n.Sum != nil || n.Product != nil || n.Negate != nil || n.Invert != nil {
+		sb.WriteString("(")
+		n.writeTo(sb)
+		sb.WriteString(")")
+	} else {
+		n.writeTo(sb)
+	}
+}
+
+func writeMathValue(sb *strings.Builder, q Quantity) {
+	if isFinite(q.Value) {
+		sb.WriteString(formatMathNumber(q.Value))
+		sb.WriteString(q.Unit)
+
+		return
+	}
+
+	if q.Unit != "" {
+		sb.WriteString("(")
+	}
+
+	switch {
+	case math.IsNaN(q.Value):
+		sb.WriteString("NaN")
+	case q.Value > 0:
+		sb.WriteString("infinity")
+	default:
+		sb.WriteString("-infinity")
+	}
+
+	if q.Unit != "" {
+		sb.WriteString(" * 1")
+		sb.WriteString(q.Unit)
+		sb.WriteString(")")
+	}
+}
+
+func formatMathNumber(v float64) string {
+	v = math.Round(v*1e6) / 1e6
+
+	if v == 0 {
+		v = 0
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// SimplifyMath rewrites each math function in the declaration values of the
+// Sheet into its simplest equivalent form, as determined by Simplify.
+//
+// Math functions nested in other functions, such as rgb(), are also rewritten,
+// and those that cannot be parsed are left unchanged, as are the values of
+// custom properties. A simplified form that does not parse back into the same
+// calculation is discarded, leaving the original tokens in place.
+//
+// A calculation that reduces to a negative value, or to a fractional number,
+// keeps its calc() wrapper, as a top-level calculation is clamped to the range
+// allowed by the property where the bare value would be invalid.
+func (s *Sheet) SimplifyMath() {
+	s.walkRules(func(r *Rule) {
+		if b := r.block(); b != nil {
+			for _, d := range b.Declarations() {
+				if !strings.HasPrefix(d.Name.Data, "--") {
+					d.Value = simplifyMathTokens(d.Value)
+				}
+			}
+		}
+	})
+}
+
+func simplifyMathTokens(ts Tokens) Tokens {
+	var (
+		simplified Tokens
+		c          = newCSSParserFromTokens(ts)
+	)
+
+	for {
+		d := c.NewGoal()
+
+		if !d.AcceptComponentValue() {
+			return simplified
+		}
+
+		v := d.ToTokens()
+
+		c.Score(d)
+
+		switch last := len(v) - 1; {
+		case v[0].Type == TokenFunction && isMathFunction(v):
+			if m, err := ParseMath(v); err == nil {
+				if ts, ok := simplifiedMathTokens(m.Simplify(), v); ok {
+					v = ts
+				}
+			}
+		case last > 0 && v[last].Type == TokenCloseParen:
+			v = append(append(Tokens{v[0]}, simplifyMathTokens(v[1:last])...), v[last])
+		}
+
+		simplified = append(simplified, v...)
+	}
+}
+
+func simplifiedMathTokens(m *MathNode, original Tokens) (Tokens, bool) {
+	str := m.String()
+
+	if m.Value != nil && isFinite(m.Value.Value) && (m.Value.Value < 0 || m.Value.Unit == "" && m.Value.Value != math.Trunc(m.Value.Value)) {
+		str = "calc(" + str + ")"
+	}
+
+	if str == original.String() {
+		return nil, false
+	}
+
+	c, err := newCSSParser(CreateTokeniser(parser.NewStringTokeniser(str), false))
+	if err != nil {
+		return nil, false
+	}
+
+	ts := Tokens(c[:cap(c)-1])
+
+	if !isMathRoundTrip(ts, str) {
+		return nil, false
+	}
+
+	for n := range ts {
+		ts[n].Pos = original[0].Pos
+		ts[n].Line = original[0].Line
+		ts[n].LinePos = original[0].LinePos
+	}
+
+	return ts, true
+}
+
+func isMathRoundTrip(ts Tokens, str string) bool {
+	switch {
+	case len(ts) == 0:
+		return false
+	case ts[0].Type != TokenFunction:
+		return len(ts) == 1 && (ts[0].Type == TokenNumber || ts[0].Type == TokenPercentage || ts[0].Type == TokenDimension)
+	case !isMathFunction(ts):
+		return false
+	}
+
+	m, err := ParseMath(ts)
+
+	return err == nil && m.String() == str
+}
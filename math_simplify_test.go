@@ -0,0 +1,118 @@
+package css
+
+import (
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func TestSheetSimplifyMath(t *testing.T) {
+	for n, test := range [...]struct {
+		Input, Output string
+	}{
+		{ // 1
+			Input:  "a{width:calc(1px)}",
+			Output: "a {\n\twidth: 1px;\n}\n",
+		},
+		{ // 2
+			Input:  "a{width:calc(10px + calc(2px * 3))}",
+			Output: "a {\n\twidth: 16px;\n}\n",
+		},
+		{ // 3
+			Input:  "a{width:calc(100% - calc(10px + 5px))}",
+			Output: "a {\n\twidth: calc(100% - 15px);\n}\n",
+		},
+		{ // 4
+			Input:  "a{width:calc(var(--a) + 2px + 3px)}",
+			Output: "a {\n\twidth: calc(var(--a) + 5px);\n}\n",
+		},
+		{ // 5
+			Input:  "a{margin:calc(1px + 1px) auto calc(2em * 2)}",
+			Output: "a {\n\tmargin: 2px auto 4em;\n}\n",
+		},
+		{ // 6
+			Input:  "a{color:rgb(calc(100 + 155) 0 0)}",
+			Output: "a {\n\tcolor: rgb(255 0 0);\n}\n",
+		},
+		{ // 7
+			Input:  "a{width:calc(1px - 2px)}",
+			Output: "a {\n\twidth: calc(-1px);\n}\n",
+		},
+		{ // 8
+			Input:  "a{z-index:calc(3 / 2)}",
+			Output: "a {\n\tz-index: calc(1.5);\n}\n",
+		},
+		{ // 9
+			Input:  "a{width:min(1in, 90px, 50%)}",
+			Output: "a {\n\twidth: min(90px, 50%);\n}\n",
+		},
+		{ // 10
+			Input:  "a{width:calc(1px+2px)}",
+			Output: "a {\n\twidth: calc(1px+2px);\n}\n",
+		},
+		{ // 11
+			Input:  "@media screen{a{b{width:calc(2 * (1px + 2em))}}}",
+			Output: "@media screen {\n\ta {\n\t\tb {\n\t\t\twidth: calc(2px + 4em);\n\t\t}\n\t}\n}\n",
+		},
+		{ // 12
+			Input:  "a{--x:calc(1px + 1px);width:calc(infinity * 1px)}",
+			Output: "a {\n\t--x: calc(1px + 1px);\n\twidth: calc(infinity * 1px);\n}\n",
+		},
+		{ // 13
+			Input:  "a{width: calc(2 * var(--x)); margin: calc(1px * env(foo))}",
+			Output: "a {\n\twidth: calc(2 * var(--x));\n\tmargin: calc(1px * env(foo));\n}\n",
+		},
+		{ // 14
+			Input:  "a{width:calc(var(--x) / 2 * var(--y))}",
+			Output: "a {\n\twidth: calc(0.5 * var(--x) * var(--y));\n}\n",
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		s.SimplifyMath()
+
+		if out := s.String(); out != test.Output {
+			t.Errorf("test %d: expecting output %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
+
+func TestSimplifiedMathRoundTrip(t *testing.T) {
+	original := tokenise(t, "calc(1px * var(--x))")
+	m := &MathNode{
+		Function: &MathFunction{
+			Name: "calc",
+			Args: []*MathNode{
+				{
+					Product: []*MathNode{
+						{Value: &Quantity{Value: 1, Unit: "px"}},
+						{Raw: tokenise(t, " * var(--x)")},
+					},
+				},
+			},
+		},
+	}
+
+	if ts, ok := simplifiedMathTokens(m, original); ok {
+		t.Errorf("expecting invalid simplification to be rejected, got %q", ts.String())
+	}
+
+	m.Function.Args[0].Product[1].Raw = tokenise(t, "var(--x)")
+
+	if _, ok := simplifiedMathTokens(m, original); ok {
+		t.Errorf("expecting unchanged simplification to be rejected")
+	}
+
+	m.Function.Args[0].Product[0].Value.Value = 2
+
+	if ts, ok := simplifiedMathTokens(m, original); !ok {
+		t.Errorf("expecting valid simplification to be accepted")
+	} else if str := ts.String(); str != "calc(2px * var(--x))" {
+		t.Errorf("expecting %q, got %q", "calc(2px * var(--x))", str)
+	}
+}
@@ -0,0 +1,496 @@
+package css
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMath(t *testing.T) {
+	for n, test := range [...]struct {
+		Input string
+		Err   error
+	}{
+		{ // 1
+			Input: "calc(1px + 2px)",
+		},
+		{ // 2
+			Input: "calc(1px+2px)",
+			Err:   ErrInvalidMath,
+		},
+		{ // 3
+			Input: "calc(1px +2px)",
+			Err:   ErrInvalidMath,
+		},
+		{ // 4
+			Input: "calc(1px * -2)",
+		},
+		{ // 5
+			Input: "calc((1px + 2px) / 3)",
+		},
+		{ // 6
+			Input: "calc(foo)",
+			Err:   ErrInvalidMath,
+		},
+		{ // 7
+			Input: "calc(1px, 2px)",
+			Err:   ErrInvalidMath,
+		},
+		{ // 8
+			Input: "min(1px, 2em, 3%)",
+		},
+		{ // 9
+			Input: "clamp(none, 1px, none)",
+		},
+		{ // 10
+			Input: "clamp(1px, 2px)",
+			Err:   ErrInvalidMath,
+		},
+		{ // 11
+			Input: "round(up, 3.5px, 1px)",
+		},
+		{ // 12
+			Input: "round(sideways, 3.5px, 1px)",
+			Err:   ErrInvalidMath,
+		},
+		{ // 13
+			Input: "calc(pi * 2)",
+		},
+		{ // 14
+			Input: "calc(var(--x) + 1px)",
+		},
+		{ // 15
+			Input: "calc(1foo)",
+			Err:   ErrUnknownUnit,
+		},
+		{ // 16
+			Input: "1px",
+			Err:   ErrInvalidMath,
+		},
+		{ // 17
+			Input: "calc(1px) 2px",
+			Err:   ErrUnexpectedToken,
+		},
+	} {
+		_, err := ParseMath(tokenise(t, test.Input))
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		}
+	}
+}
+
+func TestMathProductFactors(t *testing.T) {
+	for n, test := range [...]struct {
+		Input   string
+		Factors []string
+	}{
+		{ // 1
+			Input:   "calc(-1 * var(--x))",
+			Factors: []string{"-1", "var(--x)"},
+		},
+		{ // 2
+			Input:   "calc(var(--x) / 2 * var(--y))",
+			Factors: []string{"var(--x)", "2", "var(--y)"},
+		},
+		{ // 3
+			Input:   "calc(1px*env(foo) / attr(data-x type(<number>)))",
+			Factors: []string{"1px", "env(foo)", "attr(data-x type(<number>))"},
+		},
+	} {
+		m, err := ParseMath(tokenise(t, test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		product := m.Function.Args[0].Product
+
+		if len(product) != len(test.Factors) {
+			t.Errorf("test %d: expecting %d factors, got %d", n+1, len(test.Factors), len(product))
+
+			continue
+		}
+
+		for m, factor := range product {
+			if factor.Invert != nil {
+				factor = factor.Invert
+			}
+
+			if tks := factor.Tokens.String(); tks != test.Factors[m] {
+				t.Errorf("test %d.%d: expecting tokens %q, got %q", n+1, m+1, test.Factors[m], tks)
+			} else if factor.Raw != nil && factor.Raw.String() != test.Factors[m] {
+				t.Errorf("test %d.%d: expecting raw tokens %q, got %q", n+1, m+1, test.Factors[m], factor.Raw.String())
+			}
+		}
+	}
+}
+
+func TestMathType(t *testing.T) {
+	for n, test := range [...]struct {
+		Input       string
+		Percentages UnitCategory
+		Output      UnitCategory
+		Err         error
+	}{
+		{ // 1
+			Input:       "calc(1px + 2em)",
+			Percentages: UnitPercentage,
+			Output:      UnitLength,
+		},
+		{ // 2
+			Input:       "calc(1px + 2s)",
+			Percentages: UnitPercentage,
+			Err:         ErrInvalidMathType,
+		},
+		{ // 3
+			Input:       "calc(100% - 2em)",
+			Percentages: UnitPercentage,
+			Err:         ErrInvalidMathType,
+		},
+		{ // 4
+			Input:       "calc(100% - 2em)",
+			Percentages: UnitLength,
+			Output:      UnitLength,
+		},
+		{ // 5
+			Input:       "calc(50%)",
+			Percentages: UnitPercentage,
+			Output:      UnitPercentage,
+		},
+		{ // 6
+			Input:       "calc(10px * 2px / 1px)",
+			Percentages: UnitPercentage,
+			Output:      UnitLength,
+		},
+		{ // 7
+			Input:       "calc(10px / 2px)",
+			Percentages: UnitPercentage,
+			Output:      UnitNumber,
+		},
+		{ // 8
+			Input:       "calc(10px * 2px)",
+			Percentages: UnitPercentage,
+			Err:         ErrInvalidMathType,
+		},
+		{ // 9
+			Input:       "sin(45deg)",
+			Percentages: UnitPercentage,
+			Output:      UnitNumber,
+		},
+		{ // 10
+			Input:       "atan2(1px, 1px)",
+			Percentages: UnitPercentage,
+			Output:      UnitAngle,
+		},
+		{ // 11
+			Input:       "sin(1px)",
+			Percentages: UnitPercentage,
+			Err:         ErrInvalidMathType,
+		},
+		{ // 12
+			Input:       "calc(var(--x) + 1px)",
+			Percentages: UnitPercentage,
+			Output:      UnitUnknown,
+		},
+		{ // 13
+			Input:       "min(1px, 2s)",
+			Percentages: UnitPercentage,
+			Err:         ErrInvalidMathType,
+		},
+		{ // 14
+			Input:       "clamp(1s, 2ms, none)",
+			Percentages: UnitPercentage,
+			Output:      UnitTime,
+		},
+	} {
+		m, err := ParseMath(tokenise(t, test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected parse error: %s", n+1, err)
+
+			continue
+		}
+
+		c, err := m.Type(test.Percentages)
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if c != test.Output {
+			t.Errorf("test %d: expecting type %s, got %s", n+1, test.Output, c)
+		}
+	}
+}
+
+func TestMathEvaluate(t *testing.T) {
+	ctx := &UnitContext{
+		FontSize:        16,
+		ViewportWidth:   1000,
+		ViewportHeight:  500,
+		PercentageBasis: &Quantity{Value: 200, Unit: "px"},
+	}
+
+	for n, test := range [...]struct {
+		Input   string
+		Context *UnitContext
+		Output  Quantity
+		Err     error
+	}{
+		{ // 1
+			Input:  "calc(1in + 4px)",
+			Output: Quantity{Value: 100, Unit: "px"},
+		},
+		{ // 2
+			Input:  "calc((1px + 2px) * 3)",
+			Output: Quantity{Value: 9, Unit: "px"},
+		},
+		{ // 3
+			Input:  "calc(1px - 2px - 3px)",
+			Output: Quantity{Value: -4, Unit: "px"},
+		},
+		{ // 4
+			Input:  "calc(10px / 4)",
+			Output: Quantity{Value: 2.5, Unit: "px"},
+		},
+		{ // 5
+			Input: "calc(2em + 1px)",
+			Err:   ErrUnresolvedUnit,
+		},
+		{ // 6
+			Input:   "calc(2em + 1px)",
+			Context: ctx,
+			Output:  Quantity{Value: 33, Unit: "px"},
+		},
+		{ // 7
+			Input:   "calc(50% - 10vw)",
+			Context: ctx,
+			Output:  Quantity{Value: 0, Unit: "px"},
+		},
+		{ // 8
+			Input:  "calc(50% * 2)",
+			Output: Quantity{Value: 100, Unit: "%"},
+		},
+		{ // 9
+			Input:  "min(1in, 90px, 2in)",
+			Output: Quantity{Value: 90, Unit: "px"},
+		},
+		{ // 10
+			Input:  "max(1s, 1200ms)",
+			Output: Quantity{Value: 1.2, Unit: "s"},
+		},
+		{ // 11
+			Input:  "clamp(10px, 5px, 20px)",
+			Output: Quantity{Value: 10, Unit: "px"},
+		},
+		{ // 12
+			Input:  "clamp(10px, 50px, 20px)",
+			Output: Quantity{Value: 20, Unit: "px"},
+		},
+		{ // 13
+			Input:  "clamp(none, 50px, 20px)",
+			Output: Quantity{Value: 20, Unit: "px"},
+		},
+		{ // 14
+			Input:  "clamp(30px, 5px, none)",
+			Output: Quantity{Value: 30, Unit: "px"},
+		},
+		{ // 15
+			Input:  "round(3.5px, 1px)",
+			Output: Quantity{Value: 4, Unit: "px"},
+		},
+		{ // 16
+			Input:  "round(down, 3.5px, 1px)",
+			Output: Quantity{Value: 3, Unit: "px"},
+		},
+		{ // 17
+			Input:  "round(to-zero, -3.5px, 1px)",
+			Output: Quantity{Value: -3, Unit: "px"},
+		},
+		{ // 18
+			Input:  "round(up, 11px, 5px)",
+			Output: Quantity{Value: 15, Unit: "px"},
+		},
+		{ // 19
+			Input:  "mod(-18px, 5px)",
+			Output: Quantity{Value: 2, Unit: "px"},
+		},
+		{ // 20
+			Input:  "rem(-18px, 5px)",
+			Output: Quantity{Value: -3, Unit: "px"},
+		},
+		{ // 21
+			Input:  "calc(sin(30deg) * 2)",
+			Output: Quantity{Value: 1},
+		},
+		{ // 22
+			Input:  "acos(0)",
+			Output: Quantity{Value: 90, Unit: "deg"},
+		},
+		{ // 23
+			Input:  "atan2(1px, 1px)",
+			Output: Quantity{Value: 45, Unit: "deg"},
+		},
+		{ // 24
+			Input:  "calc(pow(2, 10) + sqrt(16) + hypot(3px, 4px) / 1px)",
+			Output: Quantity{Value: 1033},
+		},
+		{ // 25
+			Input:  "calc(log(e) + log(8, 2) + exp(0))",
+			Output: Quantity{Value: 5},
+		},
+		{ // 26
+			Input:  "calc(abs(-2px) * sign(-5))",
+			Output: Quantity{Value: -2, Unit: "px"},
+		},
+		{ // 27
+			Input:  "calc(1turn - 0.5rad * 0)",
+			Output: Quantity{Value: 360, Unit: "deg"},
+		},
+		{ // 28
+			Input:  "calc(1px / 0)",
+			Output: Quantity{Value: math.Inf(1), Unit: "px"},
+		},
+		{ // 29
+			Input: "calc(var(--x) + 1px)",
+			Err:   ErrUnresolvedMath,
+		},
+		{ // 30
+			Input: "calc(1px + 1s)",
+			Err:   ErrInvalidMathType,
+		},
+		{ // 31
+			Input: "calc(50% + 1px)",
+			Err:   ErrInvalidMathType,
+		},
+	} {
+		m, err := ParseMath(tokenise(t, test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected parse error: %s", n+1, err)
+
+			continue
+		}
+
+		q, err := m.Evaluate(test.Context)
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if q.Unit != test.Output.Unit || math.Abs(q.Value-test.Output.Value) > 1e-9 && q.Value != test.Output.Value {
+			t.Errorf("test %d: expecting %v, got %v", n+1, test.Output, q)
+		}
+	}
+}
+
+func TestMathSimplify(t *testing.T) {
+	for n, test := range [...]struct {
+		Input, Output string
+	}{
+		{ // 1
+			Input:  "calc(10px + 2px)",
+			Output: "12px",
+		},
+		{ // 2
+			Input:  "calc(1in + 4px)",
+			Output: "100px",
+		},
+		{ // 3
+			Input:  "calc(1in + 2in)",
+			Output: "3in",
+		},
+		{ // 4
+			Input:  "calc(100% - 2em + 10px)",
+			Output: "calc(100% - 2em + 10px)",
+		},
+		{ // 5
+			Input:  "calc(100% - 2em - 1em + 10px)",
+			Output: "calc(100% - 3em + 10px)",
+		},
+		{ // 6
+			Input:  "calc(calc(1px))",
+			Output: "1px",
+		},
+		{ // 7
+			Input:  "calc(1px)",
+			Output: "1px",
+		},
+		{ // 8
+			Input:  "min(1in, 90px)",
+			Output: "90px",
+		},
+		{ // 9
+			Input:  "min(1in, 90px, 10%)",
+			Output: "min(90px, 10%)",
+		},
+		{ // 10
+			Input:  "calc(var(--x))",
+			Output: "calc(var(--x))",
+		},
+		{ // 11
+			Input:  "calc(var(--x) * (2 + 3))",
+			Output: "calc(5 * var(--x))",
+		},
+		{ // 12
+			Input:  "calc((10px + 5%) * 2)",
+			Output: "calc(20px + 10%)",
+		},
+		{ // 13
+			Input:  "calc(2 * 3 / 4)",
+			Output: "1.5",
+		},
+		{ // 14
+			Input:  "calc(100% / 3)",
+			Output: "33.333333%",
+		},
+		{ // 15
+			Input:  "calc(1px / 0)",
+			Output: "calc(infinity * 1px)",
+		},
+		{ // 16
+			Input:  "clamp(1em, 10px + 5px, 2em)",
+			Output: "clamp(1em, 15px, 2em)",
+		},
+		{ // 17
+			Input:  "round(up, 11px, 5px)",
+			Output: "15px",
+		},
+		{ // 18
+			Input:  "calc(2em * 3)",
+			Output: "6em",
+		},
+		{ // 19
+			Input:  "calc(-1 * (1em - 2%))",
+			Output: "calc(-1em + 2%)",
+		},
+		{ // 20
+			Input:  "calc(1em / (2px + 1vw))",
+			Output: "calc(1em / (2px + 1vw))",
+		},
+		{ // 21
+			Input:  "calc(-1 * var(--x))",
+			Output: "calc(-1 * var(--x))",
+		},
+		{ // 22
+			Input:  "calc(var(--x) / 2 * var(--y))",
+			Output: "calc(0.5 * var(--x) * var(--y))",
+		},
+		{ // 23
+			Input:  "calc(1px * env(foo))",
+			Output: "calc(1px * env(foo))",
+		},
+	} {
+		m, err := ParseMath(tokenise(t, test.Input))
+		if err != nil {
+			t.Errorf("test %d: unexpected parse error: %s", n+1, err)
+		} else if out := m.Simplify().String(); out != test.Output {
+			t.Errorf("test %d: expecting %q, got %q", n+1, test.Output, out)
+		}
+	}
+}
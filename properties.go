@@ -0,0 +1,66 @@
+package css
+
+//go:generate go run gen_properties.go
+
+import (
+	"strings"
+	"sync"
+)
+
+// PropertyDefinition describes a standard CSS property, as given by the
+// property definition table in its specification.
+type PropertyDefinition struct {
+	Name          string
+	Syntax        string
+	Initial       string
+	Inherited     bool
+	AppliesTo     string
+	Percentages   string
+	AnimationType string
+
+	// Longhands lists, for a shorthand property, the properties that it sets,
+	// including those it can only reset to their initial values.
+	Longhands []string
+
+	// Shorthands lists the shorthand properties that set this property.
+	Shorthands []string
+}
+
+// LookupProperty returns the definition of the named standard CSS property.
+//
+// Property names are matched case-insensitively.
+//
+// The definitions cover the commonly used properties, rather than every
+// property of every specification; a property that is not defined is not
+// found, and a <'name'> reference to it in a Grammar matches nothing.
+func LookupProperty(name string) (*PropertyDefinition, bool) {
+	p, ok := propertyDefinitions[strings.ToLower(name)]
+
+	return p, ok
+}
+
+// IsShorthand returns true when the property is a shorthand for other
+// properties.
+func (p *PropertyDefinition) IsShorthand() bool {
+	return len(p.Longhands) > 0
+}
+
+// Grammar returns the parsed Syntax of the property.
+func (p *PropertyDefinition) Grammar() *Grammar {
+	return propertyGrammars()[p.Name]
+}
+
+var propertyGrammars = sync.OnceValue(func() map[string]*Grammar {
+	grammars := make(map[string]*Grammar, len(propertyDefinitions))
+
+	for name, p := range propertyDefinitions {
+		g, err := ParseGrammar(p.Syntax)
+		if err != nil {
+			panic(err)
+		}
+
+		grammars[name] = g
+	}
+
+	return grammars
+})
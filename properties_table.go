@@ -0,0 +1,2808 @@
+// Code generated by gen_properties.go from properties.json; DO NOT EDIT.
+
+package css
+
+// propertyTypeSource contains the definitions of the data types used by the
+// property grammars.
+var propertyTypeSource = map[string]string{
+	"absolute-size":                    "xx-small | x-small | small | medium | large | x-large | xx-large | xxx-large",
+	"animateable-feature":              "scroll-position | contents | <custom-ident>",
+	"attachment":                       "scroll | fixed | local",
+	"auto-repeat":                      "repeat( [ auto-fill | auto-fit ] , [ <line-names>? <fixed-size> ]+ <line-names>? )",
+	"auto-track-list":                  "[ <line-names>? [ <fixed-size> | <fixed-repeat> ] ]* <line-names>? <auto-repeat> [ <line-names>? [ <fixed-size> | <fixed-repeat> ] ]* <line-names>?",
+	"baseline-position":                "[ first | last ]? baseline",
+	"basic-shape":                      "<inset()> | <circle()> | <ellipse()> | <polygon()> | <path()> | <rect()> | <xywh()>",
+	"bg-clip":                          "<visual-box> | border-area | text",
+	"bg-image":                         "<image> | none",
+	"bg-layer":                         "<bg-image> || <bg-position> [ / <bg-size> ]? || <repeat-style> || <attachment> || <visual-box> || <visual-box>",
+	"bg-size":                          "[ <length-percentage [0,∞]> | auto ]{1,2} | cover | contain",
+	"blend-mode":                       "normal | multiply | screen | overlay | darken | lighten | color-dodge | color-burn | hard-light | soft-light | difference | exclusion | hue | saturation | color | luminosity",
+	"common-lig-values":                "common-ligatures | no-common-ligatures",
+	"compositing-operator":             "add | subtract | intersect | exclude",
+	"content-distribution":             "space-between | space-around | space-evenly | stretch",
+	"content-position":                 "center | start | end | flex-start | flex-end",
+	"contextual-alt-values":            "contextual | no-contextual",
+	"discretionary-lig-values":         "discretionary-ligatures | no-discretionary-ligatures",
+	"display-box":                      "contents | none",
+	"display-inside":                   "flow | flow-root | table | flex | grid | ruby",
+	"display-internal":                 "table-row-group | table-header-group | table-footer-group | table-row | table-cell | table-column-group | table-column | table-caption | ruby-base | ruby-text | ruby-base-container | ruby-text-container",
+	"display-legacy":                   "inline-block | inline-table | inline-flex | inline-grid",
+	"display-listitem":                 "<display-outside>? && [ flow | flow-root ]? && list-item",
+	"display-outside":                  "block | inline | run-in",
+	"easing-function":                  "linear | ease | ease-in | ease-out | ease-in-out | step-start | step-end | <linear()> | <cubic-bezier()> | <steps()>",
+	"east-asian-variant-values":        "jis78 | jis83 | jis90 | jis04 | simplified | traditional",
+	"east-asian-width-values":          "full-width | proportional-width",
+	"explicit-track-list":              "[ <line-names>? <track-size> ]+ <line-names>?",
+	"family-name":                      "<string> | <custom-ident>+",
+	"feature-tag-value":                "<opentype-tag> [ <integer [0,∞]> | on | off ]?",
+	"feature-value-name":               "<ident>",
+	"filter-function":                  "<blur()> | <brightness()> | <contrast()> | <drop-shadow()> | <grayscale()> | <hue-rotate()> | <invert()> | <opacity()> | <sepia()> | <saturate()>",
+	"final-bg-layer":                   "<bg-image> || <bg-position> [ / <bg-size> ]? || <repeat-style> || <attachment> || <visual-box> || <visual-box> || <'background-color'>",
+	"fixed-breadth":                    "<length-percentage [0,∞]>",
+	"fixed-repeat":                     "repeat( <integer [1,∞]> , [ <line-names>? <fixed-size> ]+ <line-names>? )",
+	"fixed-size":                       "<fixed-breadth> | minmax( <fixed-breadth> , <track-breadth> ) | minmax( <inflexible-breadth> , <fixed-breadth> )",
+	"font-variant-css2":                "normal | small-caps",
+	"font-weight-absolute":             "normal | bold | <number [1,1000]>",
+	"font-width-css3":                  "normal | ultra-condensed | extra-condensed | condensed | semi-condensed | semi-expanded | expanded | extra-expanded | ultra-expanded",
+	"generic-family":                   "serif | sans-serif | cursive | fantasy | monospace | system-ui | emoji | math | fangsong | ui-serif | ui-sans-serif | ui-monospace | ui-rounded",
+	"geometry-box":                     "<shape-box> | fill-box | stroke-box | view-box",
+	"grid-line":                        "auto | <custom-ident> | [ [ <integer [-∞,-1]> | <integer [1,∞]> ] && <custom-ident>? ] | [ span && [ <integer [1,∞]> || <custom-ident> ] ]",
+	"historical-lig-values":            "historical-ligatures | no-historical-ligatures",
+	"inflexible-breadth":               "<length-percentage [0,∞]> | min-content | max-content | auto",
+	"keyframes-name":                   "<custom-ident> | <string>",
+	"line-name-list":                   "[ <line-names> | <name-repeat> ]+",
+	"mask-layer":                       "<mask-reference> || <position> [ / <bg-size> ]? || <repeat-style> || <geometry-box> || [ <geometry-box> | no-clip ] || <compositing-operator> || <masking-mode>",
+	"mask-reference":                   "none | <image> | <mask-source>",
+	"mask-source":                      "<url>",
+	"masking-mode":                     "alpha | luminance | match-source",
+	"name-repeat":                      "repeat( [ <integer [1,∞]> | auto-fill ] , <line-names>+ )",
+	"numeric-figure-values":            "lining-nums | oldstyle-nums",
+	"numeric-fraction-values":          "diagonal-fractions | stacked-fractions",
+	"numeric-spacing-values":           "proportional-nums | tabular-nums",
+	"opentype-tag":                     "<string>",
+	"overflow-position":                "unsafe | safe",
+	"ratio":                            "<number [0,∞]> [ / <number [0,∞]> ]?",
+	"relative-size":                    "larger | smaller",
+	"repeat-style":                     "repeat-x | repeat-y | [ repeat | space | round | no-repeat ]{1,2}",
+	"self-position":                    "center | start | end | self-start | self-end | flex-start | flex-end",
+	"shadow":                           "<color>? && [ <length>{2} <length [0,∞]>? <length>? ] && inset?",
+	"shadow-t":                         "[ <length>{2} <length [0,∞]>? ] && <color>?",
+	"shape-box":                        "<visual-box> | margin-box",
+	"single-animation":                 "<time [0s,∞]> || <easing-function> || <time> || <single-animation-iteration-count> || <single-animation-direction> || <single-animation-fill-mode> || <single-animation-play-state> || [ none | <keyframes-name> ]",
+	"single-animation-direction":       "normal | reverse | alternate | alternate-reverse",
+	"single-animation-fill-mode":       "none | forwards | backwards | both",
+	"single-animation-iteration-count": "infinite | <number [0,∞]>",
+	"single-animation-play-state":      "running | paused",
+	"single-transition":                "[ none | <single-transition-property> ] || <time [0s,∞]> || <easing-function> || <time> || <transition-behavior-value>",
+	"single-transition-property":       "all | <custom-ident>",
+	"system-family-name":               "caption | icon | menu | message-box | small-caption | status-bar",
+	"track-breadth":                    "<length-percentage [0,∞]> | <flex [0,∞]> | min-content | max-content | auto",
+	"track-list":                       "[ <line-names>? [ <track-size> | <track-repeat> ] ]+ <line-names>?",
+	"track-repeat":                     "repeat( <integer [1,∞]> , [ <line-names>? <track-size> ]+ <line-names>? )",
+	"track-size":                       "<track-breadth> | minmax( <inflexible-breadth> , <track-breadth> ) | fit-content( <length-percentage [0,∞]> )",
+	"transition-behavior-value":        "normal | allow-discrete",
+}
+
+// propertyDefinitions contains the standard CSS properties, keyed by name.
+var propertyDefinitions = map[string]*PropertyDefinition{
+	"accent-color": {
+		Name:          "accent-color",
+		Syntax:        "auto | <color>",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"align-content": {
+		Name:          "align-content",
+		Syntax:        "normal | <baseline-position> | <content-distribution> | <overflow-position>? <content-position>",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "block containers, multicol containers, flex containers, and grid containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"place-content"},
+	},
+	"align-items": {
+		Name:          "align-items",
+		Syntax:        "normal | stretch | <baseline-position> | [ <overflow-position>? <self-position> ]",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"place-items"},
+	},
+	"align-self": {
+		Name:          "align-self",
+		Syntax:        "auto | normal | stretch | <baseline-position> | <overflow-position>? <self-position>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "flex items, grid items, and absolutely-positioned boxes",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"place-self"},
+	},
+	"animation": {
+		Name:          "animation",
+		Syntax:        "<single-animation>#",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"animation-name", "animation-duration", "animation-timing-function", "animation-delay", "animation-iteration-count", "animation-direction", "animation-fill-mode", "animation-play-state"},
+	},
+	"animation-delay": {
+		Name:          "animation-delay",
+		Syntax:        "<time>#",
+		Initial:       "0s",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-direction": {
+		Name:          "animation-direction",
+		Syntax:        "<single-animation-direction>#",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-duration": {
+		Name:          "animation-duration",
+		Syntax:        "[ auto | <time [0s,∞]> ]#",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-fill-mode": {
+		Name:          "animation-fill-mode",
+		Syntax:        "<single-animation-fill-mode>#",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-iteration-count": {
+		Name:          "animation-iteration-count",
+		Syntax:        "<single-animation-iteration-count>#",
+		Initial:       "1",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-name": {
+		Name:          "animation-name",
+		Syntax:        "[ none | <keyframes-name> ]#",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-play-state": {
+		Name:          "animation-play-state",
+		Syntax:        "<single-animation-play-state>#",
+		Initial:       "running",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"animation-timing-function": {
+		Name:          "animation-timing-function",
+		Syntax:        "<easing-function>#",
+		Initial:       "ease",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"animation"},
+	},
+	"aspect-ratio": {
+		Name:          "aspect-ratio",
+		Syntax:        "auto || <ratio>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements except inline boxes and internal ruby or table boxes",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"background": {
+		Name:          "background",
+		Syntax:        "<bg-layer>#? , <final-bg-layer>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"background-color", "background-image", "background-repeat", "background-attachment", "background-position", "background-size", "background-clip", "background-origin"},
+	},
+	"background-attachment": {
+		Name:          "background-attachment",
+		Syntax:        "<attachment>#",
+		Initial:       "scroll",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"background"},
+	},
+	"background-clip": {
+		Name:          "background-clip",
+		Syntax:        "<bg-clip>#",
+		Initial:       "border-box",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "repeatable list",
+		Shorthands:    []string{"background"},
+	},
+	"background-color": {
+		Name:          "background-color",
+		Syntax:        "<color>",
+		Initial:       "transparent",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"background"},
+	},
+	"background-image": {
+		Name:          "background-image",
+		Syntax:        "<bg-image>#",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"background"},
+	},
+	"background-origin": {
+		Name:          "background-origin",
+		Syntax:        "<visual-box>#",
+		Initial:       "padding-box",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "repeatable list",
+		Shorthands:    []string{"background"},
+	},
+	"background-position": {
+		Name:          "background-position",
+		Syntax:        "<bg-position>#",
+		Initial:       "0% 0%",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "refer to size of background positioning area minus size of background image",
+		AnimationType: "repeatable list",
+		Shorthands:    []string{"background"},
+	},
+	"background-repeat": {
+		Name:          "background-repeat",
+		Syntax:        "<repeat-style>#",
+		Initial:       "repeat",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"background"},
+	},
+	"background-size": {
+		Name:          "background-size",
+		Syntax:        "<bg-size>#",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see prose",
+		AnimationType: "repeatable list",
+		Shorthands:    []string{"background"},
+	},
+	"block-size": {
+		Name:          "block-size",
+		Syntax:        "<'width'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "same as width and height",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"border": {
+		Name:          "border",
+		Syntax:        "<line-width> || <line-style> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-top-width", "border-right-width", "border-bottom-width", "border-left-width", "border-top-style", "border-right-style", "border-bottom-style", "border-left-style", "border-top-color", "border-right-color", "border-bottom-color", "border-left-color", "border-image-source", "border-image-slice", "border-image-width", "border-image-outset", "border-image-repeat"},
+	},
+	"border-block": {
+		Name:          "border-block",
+		Syntax:        "<'border-block-start'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-block-start-width", "border-block-end-width", "border-block-start-style", "border-block-end-style", "border-block-start-color", "border-block-end-color"},
+	},
+	"border-block-color": {
+		Name:          "border-block-color",
+		Syntax:        "<'border-top-color'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-block-start-color", "border-block-end-color"},
+	},
+	"border-block-end": {
+		Name:          "border-block-end",
+		Syntax:        "<'border-top-width'> || <'border-top-style'> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-block-end-width", "border-block-end-style", "border-block-end-color"},
+	},
+	"border-block-end-color": {
+		Name:          "border-block-end-color",
+		Syntax:        "<'border-top-color'>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-block", "border-block-color", "border-block-end"},
+	},
+	"border-block-end-style": {
+		Name:          "border-block-end-style",
+		Syntax:        "<'border-top-style'>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border-block", "border-block-end", "border-block-style"},
+	},
+	"border-block-end-width": {
+		Name:          "border-block-end-width",
+		Syntax:        "<'border-top-width'>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-block", "border-block-end", "border-block-width"},
+	},
+	"border-block-start": {
+		Name:          "border-block-start",
+		Syntax:        "<'border-top-width'> || <'border-top-style'> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-block-start-width", "border-block-start-style", "border-block-start-color"},
+	},
+	"border-block-start-color": {
+		Name:          "border-block-start-color",
+		Syntax:        "<'border-top-color'>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-block", "border-block-color", "border-block-start"},
+	},
+	"border-block-start-style": {
+		Name:          "border-block-start-style",
+		Syntax:        "<'border-top-style'>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border-block", "border-block-start", "border-block-style"},
+	},
+	"border-block-start-width": {
+		Name:          "border-block-start-width",
+		Syntax:        "<'border-top-width'>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-block", "border-block-start", "border-block-width"},
+	},
+	"border-block-style": {
+		Name:          "border-block-style",
+		Syntax:        "<'border-top-style'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-block-start-style", "border-block-end-style"},
+	},
+	"border-block-width": {
+		Name:          "border-block-width",
+		Syntax:        "<'border-top-width'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-block-start-width", "border-block-end-width"},
+	},
+	"border-bottom": {
+		Name:          "border-bottom",
+		Syntax:        "<line-width> || <line-style> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-bottom-width", "border-bottom-style", "border-bottom-color"},
+	},
+	"border-bottom-color": {
+		Name:          "border-bottom-color",
+		Syntax:        "<color>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-bottom", "border-color"},
+	},
+	"border-bottom-left-radius": {
+		Name:          "border-bottom-left-radius",
+		Syntax:        "<length-percentage [0,∞]>{1,2}",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "refer to corresponding dimension of the border box",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-radius"},
+	},
+	"border-bottom-right-radius": {
+		Name:          "border-bottom-right-radius",
+		Syntax:        "<length-percentage [0,∞]>{1,2}",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "refer to corresponding dimension of the border box",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-radius"},
+	},
+	"border-bottom-style": {
+		Name:          "border-bottom-style",
+		Syntax:        "<line-style>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border", "border-bottom", "border-style"},
+	},
+	"border-bottom-width": {
+		Name:          "border-bottom-width",
+		Syntax:        "<line-width>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-bottom", "border-width"},
+	},
+	"border-collapse": {
+		Name:          "border-collapse",
+		Syntax:        "separate | collapse",
+		Initial:       "separate",
+		Inherited:     true,
+		AppliesTo:     "table grid boxes",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"border-color": {
+		Name:          "border-color",
+		Syntax:        "<'border-top-color'>{1,4}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-top-color", "border-right-color", "border-bottom-color", "border-left-color"},
+	},
+	"border-end-end-radius": {
+		Name:          "border-end-end-radius",
+		Syntax:        "<'border-top-left-radius'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"border-end-start-radius": {
+		Name:          "border-end-start-radius",
+		Syntax:        "<'border-top-left-radius'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"border-image": {
+		Name:          "border-image",
+		Syntax:        "<'border-image-source'> || <'border-image-slice'> [ / <'border-image-width'> | / <'border-image-width'>? / <'border-image-outset'> ]? || <'border-image-repeat'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements, except internal table elements when border-collapse is collapse",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-image-source", "border-image-slice", "border-image-width", "border-image-outset", "border-image-repeat"},
+	},
+	"border-image-outset": {
+		Name:          "border-image-outset",
+		Syntax:        "[ <length [0,∞]> | <number [0,∞]> ]{1,4}",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements, except internal table elements when border-collapse is collapse",
+		Percentages:   "n/a",
+		AnimationType: "by computed value",
+		Shorthands:    []string{"border", "border-image"},
+	},
+	"border-image-repeat": {
+		Name:          "border-image-repeat",
+		Syntax:        "[ stretch | repeat | round | space ]{1,2}",
+		Initial:       "stretch",
+		Inherited:     false,
+		AppliesTo:     "all elements, except internal table elements when border-collapse is collapse",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border", "border-image"},
+	},
+	"border-image-slice": {
+		Name:          "border-image-slice",
+		Syntax:        "[ <number [0,∞]> | <percentage [0,∞]> ]{1,4} && fill?",
+		Initial:       "100%",
+		Inherited:     false,
+		AppliesTo:     "all elements, except internal table elements when border-collapse is collapse",
+		Percentages:   "refer to size of the border image",
+		AnimationType: "by computed value",
+		Shorthands:    []string{"border", "border-image"},
+	},
+	"border-image-source": {
+		Name:          "border-image-source",
+		Syntax:        "none | <image>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements, except internal table elements when border-collapse is collapse",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border", "border-image"},
+	},
+	"border-image-width": {
+		Name:          "border-image-width",
+		Syntax:        "[ <length-percentage [0,∞]> | <number [0,∞]> | auto ]{1,4}",
+		Initial:       "1",
+		Inherited:     false,
+		AppliesTo:     "all elements, except internal table elements when border-collapse is collapse",
+		Percentages:   "relative to width/height of the border image area",
+		AnimationType: "by computed value",
+		Shorthands:    []string{"border", "border-image"},
+	},
+	"border-inline": {
+		Name:          "border-inline",
+		Syntax:        "<'border-block-start'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-inline-start-width", "border-inline-end-width", "border-inline-start-style", "border-inline-end-style", "border-inline-start-color", "border-inline-end-color"},
+	},
+	"border-inline-color": {
+		Name:          "border-inline-color",
+		Syntax:        "<'border-top-color'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-inline-start-color", "border-inline-end-color"},
+	},
+	"border-inline-end": {
+		Name:          "border-inline-end",
+		Syntax:        "<'border-top-width'> || <'border-top-style'> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-inline-end-width", "border-inline-end-style", "border-inline-end-color"},
+	},
+	"border-inline-end-color": {
+		Name:          "border-inline-end-color",
+		Syntax:        "<'border-top-color'>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-inline", "border-inline-color", "border-inline-end"},
+	},
+	"border-inline-end-style": {
+		Name:          "border-inline-end-style",
+		Syntax:        "<'border-top-style'>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border-inline", "border-inline-end", "border-inline-style"},
+	},
+	"border-inline-end-width": {
+		Name:          "border-inline-end-width",
+		Syntax:        "<'border-top-width'>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-inline", "border-inline-end", "border-inline-width"},
+	},
+	"border-inline-start": {
+		Name:          "border-inline-start",
+		Syntax:        "<'border-top-width'> || <'border-top-style'> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-inline-start-width", "border-inline-start-style", "border-inline-start-color"},
+	},
+	"border-inline-start-color": {
+		Name:          "border-inline-start-color",
+		Syntax:        "<'border-top-color'>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-inline", "border-inline-color", "border-inline-start"},
+	},
+	"border-inline-start-style": {
+		Name:          "border-inline-start-style",
+		Syntax:        "<'border-top-style'>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border-inline", "border-inline-start", "border-inline-style"},
+	},
+	"border-inline-start-width": {
+		Name:          "border-inline-start-width",
+		Syntax:        "<'border-top-width'>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-inline", "border-inline-start", "border-inline-width"},
+	},
+	"border-inline-style": {
+		Name:          "border-inline-style",
+		Syntax:        "<'border-top-style'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-inline-start-style", "border-inline-end-style"},
+	},
+	"border-inline-width": {
+		Name:          "border-inline-width",
+		Syntax:        "<'border-top-width'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-inline-start-width", "border-inline-end-width"},
+	},
+	"border-left": {
+		Name:          "border-left",
+		Syntax:        "<line-width> || <line-style> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-left-width", "border-left-style", "border-left-color"},
+	},
+	"border-left-color": {
+		Name:          "border-left-color",
+		Syntax:        "<color>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-color", "border-left"},
+	},
+	"border-left-style": {
+		Name:          "border-left-style",
+		Syntax:        "<line-style>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border", "border-left", "border-style"},
+	},
+	"border-left-width": {
+		Name:          "border-left-width",
+		Syntax:        "<line-width>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-left", "border-width"},
+	},
+	"border-radius": {
+		Name:          "border-radius",
+		Syntax:        "<length-percentage [0,∞]>{1,4} [ / <length-percentage [0,∞]>{1,4} ]?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-top-left-radius", "border-top-right-radius", "border-bottom-right-radius", "border-bottom-left-radius"},
+	},
+	"border-right": {
+		Name:          "border-right",
+		Syntax:        "<line-width> || <line-style> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-right-width", "border-right-style", "border-right-color"},
+	},
+	"border-right-color": {
+		Name:          "border-right-color",
+		Syntax:        "<color>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-color", "border-right"},
+	},
+	"border-right-style": {
+		Name:          "border-right-style",
+		Syntax:        "<line-style>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border", "border-right", "border-style"},
+	},
+	"border-right-width": {
+		Name:          "border-right-width",
+		Syntax:        "<line-width>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-right", "border-width"},
+	},
+	"border-spacing": {
+		Name:          "border-spacing",
+		Syntax:        "<length [0,∞]>{1,2}",
+		Initial:       "0",
+		Inherited:     true,
+		AppliesTo:     "table grid boxes",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"border-start-end-radius": {
+		Name:          "border-start-end-radius",
+		Syntax:        "<'border-top-left-radius'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"border-start-start-radius": {
+		Name:          "border-start-start-radius",
+		Syntax:        "<'border-top-left-radius'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"border-style": {
+		Name:          "border-style",
+		Syntax:        "<'border-top-style'>{1,4}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-top-style", "border-right-style", "border-bottom-style", "border-left-style"},
+	},
+	"border-top": {
+		Name:          "border-top",
+		Syntax:        "<line-width> || <line-style> || <color>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-top-width", "border-top-style", "border-top-color"},
+	},
+	"border-top-color": {
+		Name:          "border-top-color",
+		Syntax:        "<color>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-color", "border-top"},
+	},
+	"border-top-left-radius": {
+		Name:          "border-top-left-radius",
+		Syntax:        "<length-percentage [0,∞]>{1,2}",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "refer to corresponding dimension of the border box",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-radius"},
+	},
+	"border-top-right-radius": {
+		Name:          "border-top-right-radius",
+		Syntax:        "<length-percentage [0,∞]>{1,2}",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except table element when border-collapse is collapse",
+		Percentages:   "refer to corresponding dimension of the border box",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border-radius"},
+	},
+	"border-top-style": {
+		Name:          "border-top-style",
+		Syntax:        "<line-style>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"border", "border-style", "border-top"},
+	},
+	"border-top-width": {
+		Name:          "border-top-width",
+		Syntax:        "<line-width>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"border", "border-top", "border-width"},
+	},
+	"border-width": {
+		Name:          "border-width",
+		Syntax:        "<'border-top-width'>{1,4}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"border-top-width", "border-right-width", "border-bottom-width", "border-left-width"},
+	},
+	"bottom": {
+		Name:          "bottom",
+		Syntax:        "auto | <length-percentage>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "refer to size of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset"},
+	},
+	"box-shadow": {
+		Name:          "box-shadow",
+		Syntax:        "none | <shadow>#",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "as shadow list",
+	},
+	"box-sizing": {
+		Name:          "box-sizing",
+		Syntax:        "content-box | border-box",
+		Initial:       "content-box",
+		Inherited:     false,
+		AppliesTo:     "all elements that accept width or height",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"caption-side": {
+		Name:          "caption-side",
+		Syntax:        "top | bottom",
+		Initial:       "top",
+		Inherited:     true,
+		AppliesTo:     "table-caption boxes",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"caret-color": {
+		Name:          "caret-color",
+		Syntax:        "auto | <color>",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"clear": {
+		Name:          "clear",
+		Syntax:        "inline-start | inline-end | left | right | both | none",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "block-level elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"clip-path": {
+		Name:          "clip-path",
+		Syntax:        "<url> | [ <basic-shape> || <geometry-box> ] | none",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "refer to reference box when specified, otherwise border-box",
+		AnimationType: "by computed value type",
+	},
+	"color": {
+		Name:          "color",
+		Syntax:        "<color>",
+		Initial:       "canvastext",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"color-scheme": {
+		Name:          "color-scheme",
+		Syntax:        "normal | [ light | dark | <custom-ident> ]+ && only?",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"column-gap": {
+		Name:          "column-gap",
+		Syntax:        "normal | <length-percentage [0,∞]>",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "multi-column containers, flex containers, and grid containers",
+		Percentages:   "refer to corresponding dimension of the content area",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"gap"},
+	},
+	"contain": {
+		Name:          "contain",
+		Syntax:        "none | strict | content | [ [ size | inline-size ] || layout || style || paint ]",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"container": {
+		Name:          "container",
+		Syntax:        "<'container-name'> [ / <'container-type'> ]?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"container-name", "container-type"},
+	},
+	"container-name": {
+		Name:          "container-name",
+		Syntax:        "none | <custom-ident>+",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"container"},
+	},
+	"container-type": {
+		Name:          "container-type",
+		Syntax:        "normal | [ [ size | inline-size ] || scroll-state ]",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"container"},
+	},
+	"content": {
+		Name:          "content",
+		Syntax:        "normal | none | [ <string> | <image> | <counter()> | <counters()> | <attr()> | open-quote | close-quote | no-open-quote | no-close-quote ]+ [ / [ <string> | <counter()> | <attr()> ]+ ]?",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "all elements, tree-abiding pseudo-elements, and page margin boxes",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"counter-increment": {
+		Name:          "counter-increment",
+		Syntax:        "[ <custom-ident> <integer>? ]+ | none",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"counter-reset": {
+		Name:          "counter-reset",
+		Syntax:        "[ <custom-ident> <integer>? ]+ | none",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"cursor": {
+		Name:          "cursor",
+		Syntax:        "[ <url> [ <number> <number> ]? , ]* [ auto | default | none | context-menu | help | pointer | progress | wait | cell | crosshair | text | vertical-text | alias | copy | move | no-drop | not-allowed | grab | grabbing | e-resize | n-resize | ne-resize | nw-resize | s-resize | se-resize | sw-resize | w-resize | ew-resize | ns-resize | nesw-resize | nwse-resize | col-resize | row-resize | all-scroll | zoom-in | zoom-out ]",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"direction": {
+		Name:          "direction",
+		Syntax:        "ltr | rtl",
+		Initial:       "ltr",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"display": {
+		Name:          "display",
+		Syntax:        "[ <display-outside> || <display-inside> ] | <display-listitem> | <display-internal> | <display-box> | <display-legacy>",
+		Initial:       "inline",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "see prose",
+	},
+	"empty-cells": {
+		Name:          "empty-cells",
+		Syntax:        "show | hide",
+		Initial:       "show",
+		Inherited:     true,
+		AppliesTo:     "table-cell boxes",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"filter": {
+		Name:          "filter",
+		Syntax:        "none | [ <filter-function> | <url> ]+",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "as filter function list",
+	},
+	"flex": {
+		Name:          "flex",
+		Syntax:        "none | [ <'flex-grow'> <'flex-shrink'>? || <'flex-basis'> ]",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "flex items",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"flex-grow", "flex-shrink", "flex-basis"},
+	},
+	"flex-basis": {
+		Name:          "flex-basis",
+		Syntax:        "content | <'width'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "flex items",
+		Percentages:   "relative to the flex container's inner main size",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"flex"},
+	},
+	"flex-direction": {
+		Name:          "flex-direction",
+		Syntax:        "row | row-reverse | column | column-reverse",
+		Initial:       "row",
+		Inherited:     false,
+		AppliesTo:     "flex containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"flex-flow"},
+	},
+	"flex-flow": {
+		Name:          "flex-flow",
+		Syntax:        "<'flex-direction'> || <'flex-wrap'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "flex containers",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"flex-direction", "flex-wrap"},
+	},
+	"flex-grow": {
+		Name:          "flex-grow",
+		Syntax:        "<number [0,∞]>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "flex items",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"flex"},
+	},
+	"flex-shrink": {
+		Name:          "flex-shrink",
+		Syntax:        "<number [0,∞]>",
+		Initial:       "1",
+		Inherited:     false,
+		AppliesTo:     "flex items",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"flex"},
+	},
+	"flex-wrap": {
+		Name:          "flex-wrap",
+		Syntax:        "nowrap | wrap | wrap-reverse",
+		Initial:       "nowrap",
+		Inherited:     false,
+		AppliesTo:     "flex containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"flex-flow"},
+	},
+	"float": {
+		Name:          "float",
+		Syntax:        "left | right | inline-start | inline-end | none",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"font": {
+		Name:          "font",
+		Syntax:        "[ [ <'font-style'> || <font-variant-css2> || <'font-weight'> || <font-width-css3> ]? <'font-size'> [ / <'line-height'> ]? <'font-family'> ] | <system-family-name>",
+		Initial:       "see individual properties",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"font-style", "font-variant-ligatures", "font-variant-caps", "font-variant-alternates", "font-variant-numeric", "font-variant-east-asian", "font-variant-position", "font-variant-emoji", "font-weight", "font-stretch", "font-size", "line-height", "font-family", "font-size-adjust", "font-kerning", "font-feature-settings", "font-language-override", "font-optical-sizing", "font-variation-settings", "font-palette"},
+	},
+	"font-family": {
+		Name:          "font-family",
+		Syntax:        "[ <family-name> | <generic-family> ]#",
+		Initial:       "depends on user agent",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font"},
+	},
+	"font-feature-settings": {
+		Name:          "font-feature-settings",
+		Syntax:        "normal | <feature-tag-value>#",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font"},
+	},
+	"font-kerning": {
+		Name:          "font-kerning",
+		Syntax:        "auto | normal | none",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font"},
+	},
+	"font-language-override": {
+		Name:          "font-language-override",
+		Syntax:        "normal | <string>",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font"},
+	},
+	"font-optical-sizing": {
+		Name:          "font-optical-sizing",
+		Syntax:        "auto | none",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font"},
+	},
+	"font-palette": {
+		Name:          "font-palette",
+		Syntax:        "normal | light | dark | <dashed-ident>",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "by computed value",
+		Shorthands:    []string{"font"},
+	},
+	"font-size": {
+		Name:          "font-size",
+		Syntax:        "<absolute-size> | <relative-size> | <length-percentage [0,∞]> | math",
+		Initial:       "medium",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "refer to parent element's font size",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"font"},
+	},
+	"font-size-adjust": {
+		Name:          "font-size-adjust",
+		Syntax:        "none | [ ex-height | cap-height | ch-width | ic-width | ic-height ]? [ from-font | <number [0,∞]> ]",
+		Initial:       "none",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete if the keywords differ, otherwise by computed value type",
+		Shorthands:    []string{"font"},
+	},
+	"font-stretch": {
+		Name:          "font-stretch",
+		Syntax:        "<font-width-css3> | <percentage [0,∞]>",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"font"},
+	},
+	"font-style": {
+		Name:          "font-style",
+		Syntax:        "normal | italic | oblique <angle [-90deg,90deg]>?",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"font"},
+	},
+	"font-variant": {
+		Name:          "font-variant",
+		Syntax:        "normal | none | [ [ <common-lig-values> || <discretionary-lig-values> || <historical-lig-values> || <contextual-alt-values> ] || [ small-caps | all-small-caps | petite-caps | all-petite-caps | unicase | titling-caps ] || [ stylistic( <feature-value-name> ) || historical-forms || styleset( <feature-value-name># ) || character-variant( <feature-value-name># ) || swash( <feature-value-name> ) || ornaments( <feature-value-name> ) || annotation( <feature-value-name> ) ] || [ <numeric-figure-values> || <numeric-spacing-values> || <numeric-fraction-values> || ordinal || slashed-zero ] || [ <east-asian-variant-values> || <east-asian-width-values> || ruby ] || [ sub | super ] || [ text | emoji | unicode ] ]",
+		Initial:       "see individual properties",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"font-variant-ligatures", "font-variant-caps", "font-variant-alternates", "font-variant-numeric", "font-variant-east-asian", "font-variant-position", "font-variant-emoji"},
+	},
+	"font-variant-alternates": {
+		Name:          "font-variant-alternates",
+		Syntax:        "normal | [ stylistic( <feature-value-name> ) || historical-forms || styleset( <feature-value-name># ) || character-variant( <feature-value-name># ) || swash( <feature-value-name> ) || ornaments( <feature-value-name> ) || annotation( <feature-value-name> ) ]",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variant-caps": {
+		Name:          "font-variant-caps",
+		Syntax:        "normal | small-caps | all-small-caps | petite-caps | all-petite-caps | unicase | titling-caps",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variant-east-asian": {
+		Name:          "font-variant-east-asian",
+		Syntax:        "normal | [ <east-asian-variant-values> || <east-asian-width-values> || ruby ]",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variant-emoji": {
+		Name:          "font-variant-emoji",
+		Syntax:        "normal | text | emoji | unicode",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variant-ligatures": {
+		Name:          "font-variant-ligatures",
+		Syntax:        "normal | none | [ <common-lig-values> || <discretionary-lig-values> || <historical-lig-values> || <contextual-alt-values> ]",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variant-numeric": {
+		Name:          "font-variant-numeric",
+		Syntax:        "normal | [ <numeric-figure-values> || <numeric-spacing-values> || <numeric-fraction-values> || ordinal || slashed-zero ]",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variant-position": {
+		Name:          "font-variant-position",
+		Syntax:        "normal | sub | super",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"font", "font-variant"},
+	},
+	"font-variation-settings": {
+		Name:          "font-variation-settings",
+		Syntax:        "normal | [ <opentype-tag> <number> ]#",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "see prose",
+		Shorthands:    []string{"font"},
+	},
+	"font-weight": {
+		Name:          "font-weight",
+		Syntax:        "<font-weight-absolute> | bolder | lighter",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "all elements and text",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"font"},
+	},
+	"gap": {
+		Name:          "gap",
+		Syntax:        "<'row-gap'> <'column-gap'>?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "multi-column containers, flex containers, and grid containers",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"row-gap", "column-gap"},
+	},
+	"grid": {
+		Name:          "grid",
+		Syntax:        "<'grid-template'> | <'grid-template-rows'> / [ auto-flow && dense? ] <'grid-auto-columns'>? | [ auto-flow && dense? ] <'grid-auto-rows'>? / <'grid-template-columns'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"grid-template-rows", "grid-template-columns", "grid-template-areas", "grid-auto-rows", "grid-auto-columns", "grid-auto-flow"},
+	},
+	"grid-area": {
+		Name:          "grid-area",
+		Syntax:        "<grid-line> [ / <grid-line> ]{0,3}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"grid-row-start", "grid-column-start", "grid-row-end", "grid-column-end"},
+	},
+	"grid-auto-columns": {
+		Name:          "grid-auto-columns",
+		Syntax:        "<track-size>+",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "see track sizing",
+		AnimationType: "if the list lengths match, by computed value type per item; discrete otherwise",
+		Shorthands:    []string{"grid"},
+	},
+	"grid-auto-flow": {
+		Name:          "grid-auto-flow",
+		Syntax:        "[ row | column ] || dense",
+		Initial:       "row",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"grid"},
+	},
+	"grid-auto-rows": {
+		Name:          "grid-auto-rows",
+		Syntax:        "<track-size>+",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "see track sizing",
+		AnimationType: "if the list lengths match, by computed value type per item; discrete otherwise",
+		Shorthands:    []string{"grid"},
+	},
+	"grid-column": {
+		Name:          "grid-column",
+		Syntax:        "<grid-line> [ / <grid-line> ]?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"grid-column-start", "grid-column-end"},
+	},
+	"grid-column-end": {
+		Name:          "grid-column-end",
+		Syntax:        "<grid-line>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"grid-area", "grid-column"},
+	},
+	"grid-column-start": {
+		Name:          "grid-column-start",
+		Syntax:        "<grid-line>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"grid-area", "grid-column"},
+	},
+	"grid-row": {
+		Name:          "grid-row",
+		Syntax:        "<grid-line> [ / <grid-line> ]?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"grid-row-start", "grid-row-end"},
+	},
+	"grid-row-end": {
+		Name:          "grid-row-end",
+		Syntax:        "<grid-line>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"grid-area", "grid-row"},
+	},
+	"grid-row-start": {
+		Name:          "grid-row-start",
+		Syntax:        "<grid-line>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "grid items and absolutely-positioned boxes whose containing block is a grid container",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"grid-area", "grid-row"},
+	},
+	"grid-template": {
+		Name:          "grid-template",
+		Syntax:        "none | [ <'grid-template-rows'> / <'grid-template-columns'> ] | [ <line-names>? <string> <track-size>? <line-names>? ]+ [ / <explicit-track-list> ]?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"grid-template-rows", "grid-template-columns", "grid-template-areas"},
+	},
+	"grid-template-areas": {
+		Name:          "grid-template-areas",
+		Syntax:        "none | <string>+",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"grid", "grid-template"},
+	},
+	"grid-template-columns": {
+		Name:          "grid-template-columns",
+		Syntax:        "none | <track-list> | <auto-track-list> | subgrid <line-name-list>?",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "refer to corresponding dimension of the content area",
+		AnimationType: "if the list lengths match, by computed value type per item in the computed track list; discrete otherwise",
+		Shorthands:    []string{"grid", "grid-template"},
+	},
+	"grid-template-rows": {
+		Name:          "grid-template-rows",
+		Syntax:        "none | <track-list> | <auto-track-list> | subgrid <line-name-list>?",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "grid containers",
+		Percentages:   "refer to corresponding dimension of the content area",
+		AnimationType: "if the list lengths match, by computed value type per item in the computed track list; discrete otherwise",
+		Shorthands:    []string{"grid", "grid-template"},
+	},
+	"height": {
+		Name:          "height",
+		Syntax:        "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements but non-replaced inline elements, table rows, and row groups",
+		Percentages:   "relative to height of containing block",
+		AnimationType: "by computed value type",
+	},
+	"inline-size": {
+		Name:          "inline-size",
+		Syntax:        "<'width'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "same as width and height",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"inset": {
+		Name:          "inset",
+		Syntax:        "<'top'>{1,4}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"top", "right", "bottom", "left"},
+	},
+	"inset-block": {
+		Name:          "inset-block",
+		Syntax:        "<'top'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"inset-block-start", "inset-block-end"},
+	},
+	"inset-block-end": {
+		Name:          "inset-block-end",
+		Syntax:        "<'top'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset-block"},
+	},
+	"inset-block-start": {
+		Name:          "inset-block-start",
+		Syntax:        "<'top'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset-block"},
+	},
+	"inset-inline": {
+		Name:          "inset-inline",
+		Syntax:        "<'top'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"inset-inline-start", "inset-inline-end"},
+	},
+	"inset-inline-end": {
+		Name:          "inset-inline-end",
+		Syntax:        "<'top'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset-inline"},
+	},
+	"inset-inline-start": {
+		Name:          "inset-inline-start",
+		Syntax:        "<'top'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset-inline"},
+	},
+	"isolation": {
+		Name:          "isolation",
+		Syntax:        "auto | isolate",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"justify-content": {
+		Name:          "justify-content",
+		Syntax:        "normal | <content-distribution> | <overflow-position>? [ <content-position> | left | right ]",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "multicol containers, flex containers, and grid containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"place-content"},
+	},
+	"justify-items": {
+		Name:          "justify-items",
+		Syntax:        "normal | stretch | <baseline-position> | <overflow-position>? [ <self-position> | left | right ] | legacy | legacy && [ left | right | center ]",
+		Initial:       "legacy",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"place-items"},
+	},
+	"justify-self": {
+		Name:          "justify-self",
+		Syntax:        "auto | normal | stretch | <baseline-position> | <overflow-position>? [ <self-position> | left | right ]",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "block-level boxes, absolutely-positioned boxes, and grid items",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"place-self"},
+	},
+	"left": {
+		Name:          "left",
+		Syntax:        "auto | <length-percentage>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "refer to size of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset"},
+	},
+	"letter-spacing": {
+		Name:          "letter-spacing",
+		Syntax:        "normal | <length-percentage>",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "inline boxes and text",
+		Percentages:   "relative to computed font-size",
+		AnimationType: "by computed value type",
+	},
+	"line-height": {
+		Name:          "line-height",
+		Syntax:        "normal | <number [0,∞]> | <length-percentage [0,∞]>",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "non-replaced inline boxes and SVG text content elements",
+		Percentages:   "refer to computed font-size of the element itself",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"font"},
+	},
+	"list-style": {
+		Name:          "list-style",
+		Syntax:        "<'list-style-position'> || <'list-style-image'> || <'list-style-type'>",
+		Initial:       "see individual properties",
+		Inherited:     true,
+		AppliesTo:     "list items",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"list-style-position", "list-style-image", "list-style-type"},
+	},
+	"list-style-image": {
+		Name:          "list-style-image",
+		Syntax:        "<image> | none",
+		Initial:       "none",
+		Inherited:     true,
+		AppliesTo:     "list items",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"list-style"},
+	},
+	"list-style-position": {
+		Name:          "list-style-position",
+		Syntax:        "inside | outside",
+		Initial:       "outside",
+		Inherited:     true,
+		AppliesTo:     "list items",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"list-style"},
+	},
+	"list-style-type": {
+		Name:          "list-style-type",
+		Syntax:        "<custom-ident> | <string> | none",
+		Initial:       "disc",
+		Inherited:     true,
+		AppliesTo:     "list items",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"list-style"},
+	},
+	"margin": {
+		Name:          "margin",
+		Syntax:        "<'margin-top'>{1,4}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"margin-top", "margin-right", "margin-bottom", "margin-left"},
+	},
+	"margin-block": {
+		Name:          "margin-block",
+		Syntax:        "<'margin-top'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "same as margin-top",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"margin-block-start", "margin-block-end"},
+	},
+	"margin-block-end": {
+		Name:          "margin-block-end",
+		Syntax:        "<'margin-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as margin-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin-block"},
+	},
+	"margin-block-start": {
+		Name:          "margin-block-start",
+		Syntax:        "<'margin-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as margin-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin-block"},
+	},
+	"margin-bottom": {
+		Name:          "margin-bottom",
+		Syntax:        "<length-percentage> | auto",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin"},
+	},
+	"margin-inline": {
+		Name:          "margin-inline",
+		Syntax:        "<'margin-top'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "same as margin-top",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"margin-inline-start", "margin-inline-end"},
+	},
+	"margin-inline-end": {
+		Name:          "margin-inline-end",
+		Syntax:        "<'margin-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as margin-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin-inline"},
+	},
+	"margin-inline-start": {
+		Name:          "margin-inline-start",
+		Syntax:        "<'margin-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as margin-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin-inline"},
+	},
+	"margin-left": {
+		Name:          "margin-left",
+		Syntax:        "<length-percentage> | auto",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin"},
+	},
+	"margin-right": {
+		Name:          "margin-right",
+		Syntax:        "<length-percentage> | auto",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin"},
+	},
+	"margin-top": {
+		Name:          "margin-top",
+		Syntax:        "<length-percentage> | auto",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"margin"},
+	},
+	"mask": {
+		Name:          "mask",
+		Syntax:        "<mask-layer>#",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"mask-image", "mask-position", "mask-size", "mask-repeat", "mask-origin", "mask-clip", "mask-composite", "mask-mode", "mask-border-source", "mask-border-slice", "mask-border-width", "mask-border-outset", "mask-border-repeat", "mask-border-mode"},
+	},
+	"mask-border": {
+		Name:          "mask-border",
+		Syntax:        "<'mask-border-source'> || <'mask-border-slice'> [ / <'mask-border-width'>? [ / <'mask-border-outset'> ]? ]? || <'mask-border-repeat'> || <'mask-border-mode'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"mask-border-source", "mask-border-slice", "mask-border-width", "mask-border-outset", "mask-border-repeat", "mask-border-mode"},
+	},
+	"mask-border-mode": {
+		Name:          "mask-border-mode",
+		Syntax:        "luminance | alpha",
+		Initial:       "alpha",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask", "mask-border"},
+	},
+	"mask-border-outset": {
+		Name:          "mask-border-outset",
+		Syntax:        "[ <length> | <number> ]{1,4}",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"mask", "mask-border"},
+	},
+	"mask-border-repeat": {
+		Name:          "mask-border-repeat",
+		Syntax:        "[ stretch | repeat | round | space ]{1,2}",
+		Initial:       "stretch",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask", "mask-border"},
+	},
+	"mask-border-slice": {
+		Name:          "mask-border-slice",
+		Syntax:        "[ <number> | <percentage> ]{1,4} fill?",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "refer to size of the mask border image",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"mask", "mask-border"},
+	},
+	"mask-border-source": {
+		Name:          "mask-border-source",
+		Syntax:        "none | <image>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask", "mask-border"},
+	},
+	"mask-border-width": {
+		Name:          "mask-border-width",
+		Syntax:        "[ <length-percentage> | <number> | auto ]{1,4}",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "relative to width/height of the mask border image area",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"mask", "mask-border"},
+	},
+	"mask-clip": {
+		Name:          "mask-clip",
+		Syntax:        "[ <coord-box> | no-clip ]#",
+		Initial:       "border-box",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-composite": {
+		Name:          "mask-composite",
+		Syntax:        "<compositing-operator>#",
+		Initial:       "add",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-image": {
+		Name:          "mask-image",
+		Syntax:        "<mask-reference>#",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-mode": {
+		Name:          "mask-mode",
+		Syntax:        "<masking-mode>#",
+		Initial:       "match-source",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-origin": {
+		Name:          "mask-origin",
+		Syntax:        "<coord-box>#",
+		Initial:       "border-box",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-position": {
+		Name:          "mask-position",
+		Syntax:        "<position>#",
+		Initial:       "0% 0%",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "refer to size of mask painting area minus size of mask layer image",
+		AnimationType: "repeatable list",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-repeat": {
+		Name:          "mask-repeat",
+		Syntax:        "<repeat-style>#",
+		Initial:       "repeat",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-size": {
+		Name:          "mask-size",
+		Syntax:        "<bg-size>#",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements; in SVG, container elements excluding the defs element, all graphics elements, and the use element",
+		Percentages:   "n/a",
+		AnimationType: "repeatable list",
+		Shorthands:    []string{"mask"},
+	},
+	"mask-type": {
+		Name:          "mask-type",
+		Syntax:        "luminance | alpha",
+		Initial:       "luminance",
+		Inherited:     false,
+		AppliesTo:     "mask elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"max-block-size": {
+		Name:          "max-block-size",
+		Syntax:        "<'max-width'>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "same as height and width",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"max-height": {
+		Name:          "max-height",
+		Syntax:        "none | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements but non-replaced inline elements, table rows, and row groups",
+		Percentages:   "relative to height of containing block",
+		AnimationType: "by computed value type",
+	},
+	"max-inline-size": {
+		Name:          "max-inline-size",
+		Syntax:        "<'max-width'>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "same as height and width",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"max-width": {
+		Name:          "max-width",
+		Syntax:        "none | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements but non-replaced inline elements, table rows, and row groups",
+		Percentages:   "relative to width of containing block",
+		AnimationType: "by computed value type",
+	},
+	"min-block-size": {
+		Name:          "min-block-size",
+		Syntax:        "<'min-width'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "same as height and width",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"min-height": {
+		Name:          "min-height",
+		Syntax:        "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements but non-replaced inline elements, table rows, and row groups",
+		Percentages:   "relative to height of containing block",
+		AnimationType: "by computed value type",
+	},
+	"min-inline-size": {
+		Name:          "min-inline-size",
+		Syntax:        "<'min-width'>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "same as height and width",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+	},
+	"min-width": {
+		Name:          "min-width",
+		Syntax:        "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements but non-replaced inline elements, table rows, and row groups",
+		Percentages:   "relative to width of containing block",
+		AnimationType: "by computed value type",
+	},
+	"mix-blend-mode": {
+		Name:          "mix-blend-mode",
+		Syntax:        "<blend-mode> | plus-darker | plus-lighter",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"object-fit": {
+		Name:          "object-fit",
+		Syntax:        "fill | contain | cover | none | scale-down",
+		Initial:       "fill",
+		Inherited:     false,
+		AppliesTo:     "replaced elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"object-position": {
+		Name:          "object-position",
+		Syntax:        "<position>",
+		Initial:       "50% 50%",
+		Inherited:     false,
+		AppliesTo:     "replaced elements",
+		Percentages:   "refer to width and height of element itself minus width and height of the object",
+		AnimationType: "by computed value type",
+	},
+	"opacity": {
+		Name:          "opacity",
+		Syntax:        "<alpha-value>",
+		Initial:       "1",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "map to the range [0,1]",
+		AnimationType: "by computed value type",
+	},
+	"order": {
+		Name:          "order",
+		Syntax:        "<integer>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "flex items and grid items",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"outline": {
+		Name:          "outline",
+		Syntax:        "<'outline-color'> || <'outline-style'> || <'outline-width'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"outline-color", "outline-style", "outline-width"},
+	},
+	"outline-color": {
+		Name:          "outline-color",
+		Syntax:        "auto | <color>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"outline"},
+	},
+	"outline-offset": {
+		Name:          "outline-offset",
+		Syntax:        "<length>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"outline-style": {
+		Name:          "outline-style",
+		Syntax:        "auto | <line-style>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"outline"},
+	},
+	"outline-width": {
+		Name:          "outline-width",
+		Syntax:        "<line-width>",
+		Initial:       "medium",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"outline"},
+	},
+	"overflow": {
+		Name:          "overflow",
+		Syntax:        "<'overflow-x'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "block containers, flex containers, and grid containers",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"overflow-x", "overflow-y"},
+	},
+	"overflow-wrap": {
+		Name:          "overflow-wrap",
+		Syntax:        "normal | break-word | anywhere",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"overflow-x": {
+		Name:          "overflow-x",
+		Syntax:        "visible | hidden | clip | scroll | auto",
+		Initial:       "visible",
+		Inherited:     false,
+		AppliesTo:     "block containers, flex containers, and grid containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"overflow"},
+	},
+	"overflow-y": {
+		Name:          "overflow-y",
+		Syntax:        "visible | hidden | clip | scroll | auto",
+		Initial:       "visible",
+		Inherited:     false,
+		AppliesTo:     "block containers, flex containers, and grid containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"overflow"},
+	},
+	"padding": {
+		Name:          "padding",
+		Syntax:        "<'padding-top'>{1,4}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"padding-top", "padding-right", "padding-bottom", "padding-left"},
+	},
+	"padding-block": {
+		Name:          "padding-block",
+		Syntax:        "<'padding-top'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "same as padding-top",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"padding-block-start", "padding-block-end"},
+	},
+	"padding-block-end": {
+		Name:          "padding-block-end",
+		Syntax:        "<'padding-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as padding-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding-block"},
+	},
+	"padding-block-start": {
+		Name:          "padding-block-start",
+		Syntax:        "<'padding-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as padding-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding-block"},
+	},
+	"padding-bottom": {
+		Name:          "padding-bottom",
+		Syntax:        "<length-percentage [0,∞]>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements other than table cells",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding"},
+	},
+	"padding-inline": {
+		Name:          "padding-inline",
+		Syntax:        "<'padding-top'>{1,2}",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "same as padding-top",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"padding-inline-start", "padding-inline-end"},
+	},
+	"padding-inline-end": {
+		Name:          "padding-inline-end",
+		Syntax:        "<'padding-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as padding-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding-inline"},
+	},
+	"padding-inline-start": {
+		Name:          "padding-inline-start",
+		Syntax:        "<'padding-top'>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "same as padding-top",
+		Percentages:   "as for the corresponding physical property",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding-inline"},
+	},
+	"padding-left": {
+		Name:          "padding-left",
+		Syntax:        "<length-percentage [0,∞]>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements other than table cells",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding"},
+	},
+	"padding-right": {
+		Name:          "padding-right",
+		Syntax:        "<length-percentage [0,∞]>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements other than table cells",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding"},
+	},
+	"padding-top": {
+		Name:          "padding-top",
+		Syntax:        "<length-percentage [0,∞]>",
+		Initial:       "0",
+		Inherited:     false,
+		AppliesTo:     "all elements except internal table elements other than table cells",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"padding"},
+	},
+	"place-content": {
+		Name:          "place-content",
+		Syntax:        "<'align-content'> <'justify-content'>?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "block containers, flex containers, and grid containers",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"align-content", "justify-content"},
+	},
+	"place-items": {
+		Name:          "place-items",
+		Syntax:        "<'align-items'> <'justify-items'>?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"align-items", "justify-items"},
+	},
+	"place-self": {
+		Name:          "place-self",
+		Syntax:        "<'align-self'> <'justify-self'>?",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "block-level boxes, absolutely-positioned boxes, and grid items",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"align-self", "justify-self"},
+	},
+	"pointer-events": {
+		Name:          "pointer-events",
+		Syntax:        "auto | none | visiblePainted | visibleFill | visibleStroke | visible | painted | fill | stroke | all",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"position": {
+		Name:          "position",
+		Syntax:        "static | relative | absolute | sticky | fixed",
+		Initial:       "static",
+		Inherited:     false,
+		AppliesTo:     "all elements except table-column-group and table-column",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"quotes": {
+		Name:          "quotes",
+		Syntax:        "auto | none | [ <string> <string> ]+",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"resize": {
+		Name:          "resize",
+		Syntax:        "none | both | horizontal | vertical | block | inline",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "elements with overflow other than visible, and optionally replaced elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"right": {
+		Name:          "right",
+		Syntax:        "auto | <length-percentage>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "refer to size of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset"},
+	},
+	"row-gap": {
+		Name:          "row-gap",
+		Syntax:        "normal | <length-percentage [0,∞]>",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "multi-column containers, flex containers, and grid containers",
+		Percentages:   "refer to corresponding dimension of the content area",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"gap"},
+	},
+	"scroll-behavior": {
+		Name:          "scroll-behavior",
+		Syntax:        "auto | smooth",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "scroll containers",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"tab-size": {
+		Name:          "tab-size",
+		Syntax:        "<number [0,∞]> | <length [0,∞]>",
+		Initial:       "8",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+	"table-layout": {
+		Name:          "table-layout",
+		Syntax:        "auto | fixed",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "table grid boxes",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"text-align": {
+		Name:          "text-align",
+		Syntax:        "start | end | left | right | center | justify | match-parent | justify-all",
+		Initial:       "start",
+		Inherited:     true,
+		AppliesTo:     "block containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"text-decoration": {
+		Name:          "text-decoration",
+		Syntax:        "<'text-decoration-line'> || <'text-decoration-thickness'> || <'text-decoration-style'> || <'text-decoration-color'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"text-decoration-line", "text-decoration-thickness", "text-decoration-style", "text-decoration-color"},
+	},
+	"text-decoration-color": {
+		Name:          "text-decoration-color",
+		Syntax:        "<color>",
+		Initial:       "currentcolor",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"text-decoration"},
+	},
+	"text-decoration-line": {
+		Name:          "text-decoration-line",
+		Syntax:        "none | [ underline || overline || line-through || blink ]",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"text-decoration"},
+	},
+	"text-decoration-style": {
+		Name:          "text-decoration-style",
+		Syntax:        "solid | double | dotted | dashed | wavy",
+		Initial:       "solid",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"text-decoration"},
+	},
+	"text-decoration-thickness": {
+		Name:          "text-decoration-thickness",
+		Syntax:        "auto | from-font | <length-percentage>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "refer to 1em in the element's own font",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"text-decoration"},
+	},
+	"text-indent": {
+		Name:          "text-indent",
+		Syntax:        "<length-percentage> && hanging? && each-line?",
+		Initial:       "0",
+		Inherited:     true,
+		AppliesTo:     "block containers",
+		Percentages:   "refer to logical width of containing block",
+		AnimationType: "by computed value type",
+	},
+	"text-overflow": {
+		Name:          "text-overflow",
+		Syntax:        "[ clip | ellipsis | <string> ]{1,2}",
+		Initial:       "clip",
+		Inherited:     false,
+		AppliesTo:     "block containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"text-shadow": {
+		Name:          "text-shadow",
+		Syntax:        "none | <shadow-t>#",
+		Initial:       "none",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "as shadow list",
+	},
+	"text-transform": {
+		Name:          "text-transform",
+		Syntax:        "none | [ capitalize | uppercase | lowercase ] || full-width || full-size-kana",
+		Initial:       "none",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"text-wrap": {
+		Name:          "text-wrap",
+		Syntax:        "<'text-wrap-mode'> || <'text-wrap-style'>",
+		Initial:       "see individual properties",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"text-wrap-mode", "text-wrap-style"},
+	},
+	"text-wrap-mode": {
+		Name:          "text-wrap-mode",
+		Syntax:        "wrap | nowrap",
+		Initial:       "wrap",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"text-wrap", "white-space"},
+	},
+	"text-wrap-style": {
+		Name:          "text-wrap-style",
+		Syntax:        "auto | balance | stable | pretty",
+		Initial:       "auto",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"text-wrap"},
+	},
+	"top": {
+		Name:          "top",
+		Syntax:        "auto | <length-percentage>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "refer to size of containing block",
+		AnimationType: "by computed value type",
+		Shorthands:    []string{"inset"},
+	},
+	"transform": {
+		Name:          "transform",
+		Syntax:        "none | <transform-list>",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "transformable elements",
+		Percentages:   "refer to the size of reference box",
+		AnimationType: "as transform",
+	},
+	"transform-origin": {
+		Name:          "transform-origin",
+		Syntax:        "[ left | center | right | top | bottom | <length-percentage> ] | [ left | center | right | <length-percentage> ] [ top | center | bottom | <length-percentage> ] <length>? | [ [ center | left | right ] && [ center | top | bottom ] ] <length>?",
+		Initial:       "50% 50% 0",
+		Inherited:     false,
+		AppliesTo:     "transformable elements",
+		Percentages:   "refer to the size of reference box",
+		AnimationType: "by computed value type",
+	},
+	"transition": {
+		Name:          "transition",
+		Syntax:        "<single-transition>#",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"transition-property", "transition-duration", "transition-timing-function", "transition-delay", "transition-behavior"},
+	},
+	"transition-behavior": {
+		Name:          "transition-behavior",
+		Syntax:        "<transition-behavior-value>#",
+		Initial:       "normal",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"transition"},
+	},
+	"transition-delay": {
+		Name:          "transition-delay",
+		Syntax:        "<time>#",
+		Initial:       "0s",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"transition"},
+	},
+	"transition-duration": {
+		Name:          "transition-duration",
+		Syntax:        "<time [0s,∞]>#",
+		Initial:       "0s",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"transition"},
+	},
+	"transition-property": {
+		Name:          "transition-property",
+		Syntax:        "none | <single-transition-property>#",
+		Initial:       "all",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"transition"},
+	},
+	"transition-timing-function": {
+		Name:          "transition-timing-function",
+		Syntax:        "<easing-function>#",
+		Initial:       "ease",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+		Shorthands:    []string{"transition"},
+	},
+	"user-select": {
+		Name:          "user-select",
+		Syntax:        "auto | text | none | contain | all",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"vertical-align": {
+		Name:          "vertical-align",
+		Syntax:        "baseline | sub | super | text-top | text-bottom | middle | top | bottom | <length-percentage>",
+		Initial:       "baseline",
+		Inherited:     false,
+		AppliesTo:     "inline-level and table-cell elements",
+		Percentages:   "refer to the line-height of the element itself",
+		AnimationType: "by computed value type",
+	},
+	"visibility": {
+		Name:          "visibility",
+		Syntax:        "visible | hidden | collapse",
+		Initial:       "visible",
+		Inherited:     true,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "see prose",
+	},
+	"white-space": {
+		Name:          "white-space",
+		Syntax:        "normal | pre | pre-wrap | pre-line | <'white-space-collapse'> || <'text-wrap-mode'> || <'white-space-trim'>",
+		Initial:       "see individual properties",
+		Inherited:     false,
+		AppliesTo:     "text",
+		Percentages:   "see individual properties",
+		AnimationType: "see individual properties",
+		Longhands:     []string{"white-space-collapse", "text-wrap-mode", "white-space-trim"},
+	},
+	"white-space-collapse": {
+		Name:          "white-space-collapse",
+		Syntax:        "collapse | discard | preserve | preserve-breaks | preserve-spaces | break-spaces",
+		Initial:       "collapse",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"white-space"},
+	},
+	"white-space-trim": {
+		Name:          "white-space-trim",
+		Syntax:        "none | discard-before || discard-after || discard-inner",
+		Initial:       "none",
+		Inherited:     false,
+		AppliesTo:     "inline boxes and block containers",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+		Shorthands:    []string{"white-space"},
+	},
+	"width": {
+		Name:          "width",
+		Syntax:        "auto | <length-percentage [0,∞]> | min-content | max-content | fit-content( <length-percentage [0,∞]> )",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements but non-replaced inline elements, table rows, and row groups",
+		Percentages:   "relative to width of containing block",
+		AnimationType: "by computed value type",
+	},
+	"will-change": {
+		Name:          "will-change",
+		Syntax:        "auto | <animateable-feature>#",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "all elements",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"word-break": {
+		Name:          "word-break",
+		Syntax:        "normal | break-all | keep-all | break-word",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "n/a",
+		AnimationType: "discrete",
+	},
+	"word-spacing": {
+		Name:          "word-spacing",
+		Syntax:        "normal | <length-percentage>",
+		Initial:       "normal",
+		Inherited:     true,
+		AppliesTo:     "text",
+		Percentages:   "relative to computed font-size",
+		AnimationType: "by computed value type",
+	},
+	"writing-mode": {
+		Name:          "writing-mode",
+		Syntax:        "horizontal-tb | vertical-rl | vertical-lr | sideways-rl | sideways-lr",
+		Initial:       "horizontal-tb",
+		Inherited:     true,
+		AppliesTo:     "all elements except table row groups, table column groups, table rows, table columns, ruby base containers, ruby annotation containers",
+		Percentages:   "n/a",
+		AnimationType: "not animatable",
+	},
+	"z-index": {
+		Name:          "z-index",
+		Syntax:        "auto | <integer>",
+		Initial:       "auto",
+		Inherited:     false,
+		AppliesTo:     "positioned elements",
+		Percentages:   "n/a",
+		AnimationType: "by computed value type",
+	},
+}
@@ -0,0 +1,398 @@
+package css
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPropertyGrammars(t *testing.T) {
+	for name := range propertyTypeSource {
+		if _, ok := grammarTypeSource[name]; ok {
+			t.Errorf("type %s: defined in both grammarTypeSource and propertyTypeSource", name)
+		}
+	}
+
+	for name, g := range grammarTypes() {
+		checkGrammarReferences(t, "type "+name, g)
+	}
+
+	for name, p := range propertyDefinitions {
+		if p.Name != name {
+			t.Errorf("property %s: has name %s", name, p.Name)
+		}
+
+		checkGrammarReferences(t, "property "+name, p.Grammar())
+	}
+}
+
+func checkGrammarReferences(t *testing.T, name string, g *Grammar) {
+	t.Helper()
+
+	switch {
+	case g.Property != "":
+		if _, ok := LookupProperty(g.Property); !ok {
+			t.Errorf("%s: unknown property <'%s'>", name, g.Property)
+		}
+	case g.Type != "":
+		_, isData := grammarDataTypes[g.Type]
+		_, isDefined := grammarTypes()[g.Type]
+
+		if !isData && !isDefined && !strings.HasSuffix(g.Type, "()") && g.Type != "declaration-value" && g.Type != "any-value" {
+			t.Errorf("%s: unknown type <%s>", name, g.Type)
+		}
+	}
+
+	for _, term := range g.Terms {
+		checkGrammarReferences(t, name, term)
+	}
+}
+
+func TestLookupProperty(t *testing.T) {
+	for n, test := range [...]struct {
+		Name       string
+		Found      bool
+		Initial    string
+		Inherited  bool
+		Longhands  []string
+		Shorthands []string
+	}{
+		{ // 1
+			Name:      "color",
+			Found:     true,
+			Initial:   "canvastext",
+			Inherited: true,
+		},
+		{ // 2
+			Name:    "Z-INDEX",
+			Found:   true,
+			Initial: "auto",
+		},
+		{ // 3
+			Name:       "margin-top",
+			Found:      true,
+			Initial:    "0",
+			Shorthands: []string{"margin"},
+		},
+		{ // 4
+			Name:      "margin",
+			Found:     true,
+			Initial:   "see individual properties",
+			Longhands: []string{"margin-top", "margin-right", "margin-bottom", "margin-left"},
+		},
+		{ // 5
+			Name:       "border-top-color",
+			Found:      true,
+			Initial:    "currentcolor",
+			Shorthands: []string{"border", "border-color", "border-top"},
+		},
+		{ // 6
+			Name:       "line-height",
+			Found:      true,
+			Initial:    "normal",
+			Inherited:  true,
+			Shorthands: []string{"font"},
+		},
+		{ // 7
+			Name:      "list-style",
+			Found:     true,
+			Initial:   "see individual properties",
+			Inherited: true,
+			Longhands: []string{"list-style-position", "list-style-image", "list-style-type"},
+		},
+		{ // 8
+			Name: "--custom",
+		},
+		{ // 9
+			Name: "not-a-property",
+		},
+		{ // 10
+			Name:       "grid-template-columns",
+			Found:      true,
+			Initial:    "none",
+			Shorthands: []string{"grid", "grid-template"},
+		},
+		{ // 11
+			Name:       "border-image-slice",
+			Found:      true,
+			Initial:    "100%",
+			Shorthands: []string{"border", "border-image"},
+		},
+		{ // 12
+			Name:       "font-variant-caps",
+			Found:      true,
+			Initial:    "normal",
+			Inherited:  true,
+			Shorthands: []string{"font", "font-variant"},
+		},
+		{ // 13
+			Name:      "margin-inline",
+			Found:     true,
+			Initial:   "see individual properties",
+			Longhands: []string{"margin-inline-start", "margin-inline-end"},
+		},
+		{ // 14
+			Name:       "text-wrap-mode",
+			Found:      true,
+			Initial:    "wrap",
+			Inherited:  true,
+			Shorthands: []string{"text-wrap", "white-space"},
+		},
+	} {
+		p, ok := LookupProperty(test.Name)
+		if ok != test.Found {
+			t.Errorf("test %d: expecting found %v, got %v", n+1, test.Found, ok)
+		} else if !ok {
+			continue
+		} else if p.Initial != test.Initial {
+			t.Errorf("test %d: expecting initial value %q, got %q", n+1, test.Initial, p.Initial)
+		} else if p.Inherited != test.Inherited {
+			t.Errorf("test %d: expecting inherited %v, got %v", n+1, test.Inherited, p.Inherited)
+		} else if p.IsShorthand() != (len(test.Longhands) > 0) {
+			t.Errorf("test %d: expecting shorthand %v, got %v", n+1, len(test.Longhands) > 0, p.IsShorthand())
+		} else if !slices.Equal(p.Longhands, test.Longhands) {
+			t.Errorf("test %d: expecting longhands %v, got %v", n+1, test.Longhands, p.Longhands)
+		} else if !slices.Equal(p.Shorthands, test.Shorthands) {
+			t.Errorf("test %d: expecting shorthands %v, got %v", n+1, test.Shorthands, p.Shorthands)
+		}
+	}
+}
+
+func TestPropertyShorthands(t *testing.T) {
+	for name, p := range propertyDefinitions {
+		for _, longhand := range p.Longhands {
+			if l, ok := propertyDefinitions[longhand]; !ok {
+				t.Errorf("property %s: unknown longhand %s", name, longhand)
+			} else if !slices.Contains(l.Shorthands, name) {
+				t.Errorf("property %s: longhand %s does not list it as a shorthand", name, longhand)
+			}
+		}
+
+		for _, shorthand := range p.Shorthands {
+			if s, ok := propertyDefinitions[shorthand]; !ok {
+				t.Errorf("property %s: unknown shorthand %s", name, shorthand)
+			} else if !slices.Contains(s.Longhands, name) {
+				t.Errorf("property %s: shorthand %s does not list it as a longhand", name, shorthand)
+			}
+		}
+	}
+}
+
+func TestPropertyInitialValues(t *testing.T) {
+	for name, p := range propertyDefinitions {
+		switch {
+		case p.IsShorthand(), strings.Contains(p.Initial, " ") && !strings.Contains(p.Initial, "%"):
+			continue
+		case name == "color":
+			// System colours, such as canvastext, are not matched by <color>.
+			continue
+		}
+
+		if _, ok := p.Grammar().Match(tokenise(t, p.Initial)); !ok {
+			t.Errorf("property %s: initial value %q does not match %s", name, p.Initial, p.Syntax)
+		}
+	}
+}
+
+func TestPropertyMatch(t *testing.T) {
+	for n, test := range [...]struct {
+		Property, Input string
+		Match           bool
+	}{
+		{ // 1
+			Property: "margin",
+			Input:    "0 auto",
+			Match:    true,
+		},
+		{ // 2
+			Property: "margin",
+			Input:    "1px 2px 3px 4px 5px",
+		},
+		{ // 3
+			Property: "padding",
+			Input:    "-1px",
+		},
+		{ // 4
+			Property: "border",
+			Input:    "1px solid red",
+			Match:    true,
+		},
+		{ // 5
+			Property: "border-radius",
+			Input:    "10px 5% / 20px",
+			Match:    true,
+		},
+		{ // 6
+			Property: "display",
+			Input:    "inline flex",
+			Match:    true,
+		},
+		{ // 7
+			Property: "display",
+			Input:    "block inline",
+		},
+		{ // 8
+			Property: "font",
+			Input:    "italic bold 12px / 1.5 \"Helvetica Neue\", sans-serif",
+			Match:    true,
+		},
+		{ // 9
+			Property: "font",
+			Input:    "bold sans-serif",
+		},
+		{ // 10
+			Property: "font-weight",
+			Input:    "1001",
+		},
+		{ // 11
+			Property: "background",
+			Input:    "url(a.png) no-repeat, center / cover red",
+			Match:    true,
+		},
+		{ // 12
+			Property: "background",
+			Input:    "red, url(a.png)",
+		},
+		{ // 13
+			Property: "transition",
+			Input:    "opacity 0.3s ease-in-out, transform 1s",
+			Match:    true,
+		},
+		{ // 14
+			Property: "transition-duration",
+			Input:    "-1s",
+		},
+		{ // 15
+			Property: "animation",
+			Input:    "spin 2s linear infinite",
+			Match:    true,
+		},
+		{ // 16
+			Property: "flex",
+			Input:    "1 1 0%",
+			Match:    true,
+		},
+		{ // 17
+			Property: "flex",
+			Input:    "none 1",
+		},
+		{ // 18
+			Property: "grid-area",
+			Input:    "1 / span 2 / auto / header",
+			Match:    true,
+		},
+		{ // 19
+			Property: "box-shadow",
+			Input:    "inset 0 0 4px #000, 1px 1px red",
+			Match:    true,
+		},
+		{ // 20
+			Property: "cursor",
+			Input:    "url(a.cur) 4 4, pointer",
+			Match:    true,
+		},
+		{ // 21
+			Property: "cursor",
+			Input:    "url(a.cur)",
+		},
+		{ // 22
+			Property: "justify-content",
+			Input:    "safe center",
+			Match:    true,
+		},
+		{ // 23
+			Property: "aspect-ratio",
+			Input:    "auto 16 / 9",
+			Match:    true,
+		},
+		{ // 24
+			Property: "width",
+			Input:    "fit-content(10em)",
+			Match:    true,
+		},
+		{ // 25
+			Property: "opacity",
+			Input:    "50%",
+			Match:    true,
+		},
+		{ // 26
+			Property: "grid-template-columns",
+			Input:    "[full-start] minmax(1em, 1fr) repeat(2, [a b] 100px) fit-content(50%) [full-end]",
+			Match:    true,
+		},
+		{ // 27
+			Property: "grid-template-columns",
+			Input:    "repeat(auto-fill, minmax(10px, 1fr))",
+			Match:    true,
+		},
+		{ // 28
+			Property: "grid-template-columns",
+			Input:    "[span] 1fr",
+		},
+		{ // 29
+			Property: "grid-template",
+			Input:    "\"a a\" 40px \"b c\" 1fr / auto 1fr",
+			Match:    true,
+		},
+		{ // 30
+			Property: "grid",
+			Input:    "auto-flow dense / 100px 1fr",
+			Match:    true,
+		},
+		{ // 31
+			Property: "place-items",
+			Input:    "center legacy left",
+			Match:    true,
+		},
+		{ // 32
+			Property: "margin-inline",
+			Input:    "auto 1px",
+			Match:    true,
+		},
+		{ // 33
+			Property: "font-variant",
+			Input:    "small-caps tabular-nums stylistic(fancy)",
+			Match:    true,
+		},
+		{ // 34
+			Property: "font-variant",
+			Input:    "small-caps unicase",
+		},
+		{ // 35
+			Property: "border-image",
+			Input:    "url(a.png) 30 fill / 10px / 2 round",
+			Match:    true,
+		},
+		{ // 36
+			Property: "mask",
+			Input:    "url(m.svg) center / contain no-repeat, linear-gradient(black, transparent) subtract",
+			Match:    true,
+		},
+		{ // 37
+			Property: "container",
+			Input:    "card / inline-size scroll-state",
+			Match:    true,
+		},
+		{ // 38
+			Property: "text-wrap",
+			Input:    "balance nowrap",
+			Match:    true,
+		},
+		{ // 39
+			Property: "white-space",
+			Input:    "preserve nowrap",
+			Match:    true,
+		},
+	} {
+		g, ok := lookupPropertyGrammar(test.Property)
+		if !ok {
+			t.Errorf("test %d: unknown property %s", n+1, test.Property)
+
+			continue
+		}
+
+		if _, ok := g.Match(tokenise(t, test.Input)); ok != test.Match {
+			t.Errorf("test %d: expecting match %v, got %v", n+1, test.Match, ok)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package css
+
+import (
+	"strings"
+	"sync"
+)
+
+var builtinAtRules = map[string]BlockKind{
+	"charset":             BlockKindNone,
+	"import":              BlockKindNone,
+	"namespace":           BlockKindNone,
+	"font-face":           BlockKindDeclarations,
+	"page":                BlockKindDeclarations,
+	"property":            BlockKindDeclarations,
+	"counter-style":       BlockKindDeclarations,
+	"view-transition":     BlockKindDeclarations,
+	"position-try":        BlockKindDeclarations,
+	"font-palette-values": BlockKindDeclarations,
+	"font-feature-values": BlockKindDeclarations,
+	"stylistic":           BlockKindDeclarations,
+	"historical-forms":    BlockKindDeclarations,
+	"styleset":            BlockKindDeclarations,
+	"character-variant":   BlockKindDeclarations,
+	"swash":               BlockKindDeclarations,
+	"ornaments":           BlockKindDeclarations,
+	"annotation":          BlockKindDeclarations,
+	"media":               BlockKindRules,
+	"supports":            BlockKindRules,
+	"layer":               BlockKindRules,
+	"container":           BlockKindRules,
+	"starting-style":      BlockKindRules,
+	"document":            BlockKindRules,
+	"-moz-document":       BlockKindRules,
+	"keyframes":           BlockKindRules,
+	"-webkit-keyframes":   BlockKindRules,
+	"-moz-keyframes":      BlockKindRules,
+	"-o-keyframes":        BlockKindRules,
+	"scope":               BlockKindMixed,
+}
+
+// AtRuleDefinition describes how to parse an at-rule added with
+// RegisterAtRule.
+//
+// When set, the Prelude function is called with the trimmed Prelude of the
+// at-rule, and the value it returns is stored in AtRule.Custom. The Block kind
+// determines how the contents of the Block are parsed, with BlockKindNone
+// disallowing a Block entirely, and RequireBlock disallowing its absence.
+type AtRuleDefinition struct {
+	Prelude      func(prelude Tokens) (any, error)
+	Block        BlockKind
+	RequireBlock bool
+}
+
+var registry = struct {
+	sync.RWMutex
+	atRules map[string]AtRuleDefinition
+}{
+	atRules: make(map[string]AtRuleDefinition),
+}
+
+// RegisterAtRule adds an at-rule, named without the leading '@', to be parsed
+// according to the given definition. Names are case-insensitive.
+//
+// Registering a name a second time replaces the previous definition. The names
+// of built-in at-rules cannot be registered, and will return
+// ErrBuiltinAtRule. A definition that both requires and disallows a Block will
+// return ErrInvalidAtRuleDefinition.
+func RegisterAtRule(name string, def AtRuleDefinition) error {
+	name = strings.ToLower(name)
+
+	if isBuiltinAtRule(name) {
+		return ErrBuiltinAtRule
+	} else if def.Block == BlockKindNone && def.RequireBlock {
+		return ErrInvalidAtRuleDefinition
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.atRules[name] = def
+
+	return nil
+}
+
+// UnregisterAtRule removes an at-rule added with RegisterAtRule, so that it
+// will be parsed as a generic at-rule.
+func UnregisterAtRule(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	delete(registry.atRules, strings.ToLower(name))
+}
+
+func registeredAtRule(name string) (AtRuleDefinition, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	def, ok := registry.atRules[name]
+
+	return def, ok
+}
+
+func isBuiltinAtRule(name string) bool {
+	_, ok := builtinAtRules[name]
+
+	return ok || isMarginBox(name)
+}
+
+// BlockKind returns the kind of Block that the at-rule takes.
+//
+// At-rules taking BlockKindRules take a mixed Block when nested within a style
+// rule. At-rules that are neither built-in nor registered are treated as
+// taking a mixed Block.
+func (a *AtRule) BlockKind() BlockKind {
+	name := a.Name()
+
+	if kind, ok := builtinAtRules[name]; ok {
+		return kind
+	} else if isMarginBox(name) {
+		return BlockKindDeclarations
+	} else if def, ok := registeredAtRule(name); ok {
+		return def.Block
+	}
+
+	return BlockKindMixed
+}
+
+func (a *AtRule) parseRegistered(def AtRuleDefinition) error {
+	if def.RequireBlock && a.Block == nil {
+		return ErrMissingBlock
+	} else if def.Prelude == nil {
+		return nil
+	}
+
+	custom, err := def.Prelude(a.Prelude.Trim())
+	if err != nil {
+		return err
+	}
+
+	a.Custom = custom
+
+	return nil
+}
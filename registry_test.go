@@ -0,0 +1,144 @@
+package css
+
+import (
+	"errors"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+var errInvalidTheme = errors.New("invalid theme")
+
+func TestRegisterAtRule(t *testing.T) {
+	if err := RegisterAtRule("Theme", AtRuleDefinition{
+		Prelude: func(prelude Tokens) (any, error) {
+			if len(prelude) != 1 || prelude[0].Type != TokenIdent {
+				return nil, errInvalidTheme
+			}
+
+			return prelude[0].Data, nil
+		},
+		Block:        BlockKindDeclarations,
+		RequireBlock: true,
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := RegisterAtRule("mixin", AtRuleDefinition{Block: BlockKindMixed}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := RegisterAtRule("apply", AtRuleDefinition{Block: BlockKindNone}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := RegisterAtRule("wrapper", AtRuleDefinition{Block: BlockKindRules}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Cleanup(func() {
+		UnregisterAtRule("theme")
+		UnregisterAtRule("mixin")
+		UnregisterAtRule("apply")
+		UnregisterAtRule("wrapper")
+	})
+
+	if err := RegisterAtRule("include", AtRuleDefinition{Block: BlockKindNone, RequireBlock: true}); !errors.Is(err, ErrInvalidAtRuleDefinition) {
+		t.Errorf("registering %q: expecting error %v, got %v", "include", ErrInvalidAtRuleDefinition, err)
+	}
+
+	for _, name := range [...]string{"media", "PAGE", "top-left"} {
+		if err := RegisterAtRule(name, AtRuleDefinition{}); !errors.Is(err, ErrBuiltinAtRule) {
+			t.Errorf("registering %q: expecting error %v, got %v", name, ErrBuiltinAtRule, err)
+		}
+	}
+
+	for n, test := range [...]struct {
+		Input               string
+		Custom              any
+		Declarations, Rules int
+		Err                 error
+	}{
+		{ // 1
+			Input:        "@theme dark { --bg: black; --fg: white; }",
+			Custom:       "dark",
+			Declarations: 2,
+		},
+		{ // 2
+			Input: "@theme { --bg: black }",
+			Err:   errInvalidTheme,
+		},
+		{ // 3
+			Input: "@theme dark;",
+			Err:   ErrMissingBlock,
+		},
+		{ // 4
+			Input: "@theme dark { a {} }",
+			Err:   ErrMissingColon,
+		},
+		{ // 5
+			Input:        "@MIXIN foo(1) { color: red; &:hover { color: blue } }",
+			Declarations: 1,
+			Rules:        1,
+		},
+		{ // 6
+			Input: "@mixin bar { svg|a {} }",
+			Err:   ErrUndeclaredNamespace,
+		},
+		{ // 7
+			Input: "@apply --foo;",
+		},
+		{ // 8
+			Input: "@apply --foo {}",
+			Err:   ErrUnexpectedBlock,
+		},
+		{ // 9
+			Input: "@wrapper { a > b {} c {} }",
+			Rules: 2,
+		},
+		{ // 10
+			Input: "@wrapper { svg|a {} }",
+			Err:   ErrUndeclaredNamespace,
+		},
+	} {
+		s, err := ParseSheet(parser.NewStringTokeniser(test.Input))
+		if err == nil {
+			err = ruleError(s)
+		}
+
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		}
+
+		a := s.Rules[0].AtRule
+
+		if a.Custom != test.Custom {
+			t.Errorf("test %d: expecting custom value %v, got %v", n+1, test.Custom, a.Custom)
+		} else if a.Block == nil {
+			continue
+		} else if d := len(a.Block.Declarations()); d != test.Declarations {
+			t.Errorf("test %d: expecting %d declarations, got %d", n+1, test.Declarations, d)
+		} else if r := a.Block.Rules(); len(r) != test.Rules {
+			t.Errorf("test %d: expecting %d rules, got %d", n+1, test.Rules, len(r))
+		} else if len(r) > 0 && r[0].QualifiedRule.Selectors == nil {
+			t.Errorf("test %d: expecting nested rule selectors to be parsed", n+1)
+		}
+	}
+
+	UnregisterAtRule("theme")
+
+	s, err := ParseSheet(parser.NewStringTokeniser("@theme dark { a {} }"))
+	if err != nil {
+		t.Fatalf("unexpected error parsing unregistered at-rule: %s", err)
+	} else if a := s.Rules[0].AtRule; a.Custom != nil || a.Prelude.Trim().String() != "dark" || len(a.Block.Rules()) != 1 {
+		t.Errorf("expecting generic at-rule, got %v", a)
+	}
+}
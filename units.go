@@ -0,0 +1,353 @@
+package css
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// UnitCategory represents the type of a CSS quantity, such as a length or an
+// angle.
+type UnitCategory uint8
+
+// Unit categories.
+const (
+	UnitNumber UnitCategory = iota
+	UnitPercentage
+	UnitLength
+	UnitAngle
+	UnitTime
+	UnitFrequency
+	UnitResolution
+	UnitFlex
+	UnitUnknown
+)
+
+var unitCategoryNames = [...]string{
+	UnitNumber:     "number",
+	UnitPercentage: "percentage",
+	UnitLength:     "length",
+	UnitAngle:      "angle",
+	UnitTime:       "time",
+	UnitFrequency:  "frequency",
+	UnitResolution: "resolution",
+	UnitFlex:       "flex",
+	UnitUnknown:    "unknown",
+}
+
+// String returns the name of the UnitCategory.
+func (u UnitCategory) String() string {
+	if int(u) < len(unitCategoryNames) {
+		return unitCategoryNames[u]
+	}
+
+	return "unknown"
+}
+
+type unitInfo struct {
+	category UnitCategory
+
+	// factor converts a value in this unit to the canonical unit of its
+	// category; it is zero for units that require a UnitContext to resolve.
+	factor float64
+}
+
+// Canonical units for each category.
+var canonicalUnits = [...]string{
+	UnitNumber:     "",
+	UnitPercentage: "%",
+	UnitLength:     "px",
+	UnitAngle:      "deg",
+	UnitTime:       "s",
+	UnitFrequency:  "hz",
+	UnitResolution: "dppx",
+	UnitFlex:       "fr",
+}
+
+var units = map[string]unitInfo{
+	"":  {UnitNumber, 1},
+	"%": {UnitPercentage, 1},
+
+	"px": {UnitLength, 1},
+	"cm": {UnitLength, 96 / 2.54},
+	"mm": {UnitLength, 96 / 25.4},
+	"q":  {UnitLength, 96 / 101.6},
+	"in": {UnitLength, 96},
+	"pt": {UnitLength, 96.0 / 72},
+	"pc": {UnitLength, 16},
+
+	"em": {UnitLength, 0}, "rem": {UnitLength, 0},
+	"ex": {UnitLength, 0}, "rex": {UnitLength, 0},
+	"cap": {UnitLength, 0}, "rcap": {UnitLength, 0},
+	"ch": {UnitLength, 0}, "rch": {UnitLength, 0},
+	"ic": {UnitLength, 0}, "ric": {UnitLength, 0},
+	"lh": {UnitLength, 0}, "rlh": {UnitLength, 0},
+
+	"vw": {UnitLength, 0}, "vh": {UnitLength, 0}, "vi": {UnitLength, 0}, "vb": {UnitLength, 0}, "vmin": {UnitLength, 0}, "vmax": {UnitLength, 0},
+	"svw": {UnitLength, 0}, "svh": {UnitLength, 0}, "svi": {UnitLength, 0}, "svb": {UnitLength, 0}, "svmin": {UnitLength, 0}, "svmax": {UnitLength, 0},
+	"lvw": {UnitLength, 0}, "lvh": {UnitLength, 0}, "lvi": {UnitLength, 0}, "lvb": {UnitLength, 0}, "lvmin": {UnitLength, 0}, "lvmax": {UnitLength, 0},
+	"dvw": {UnitLength, 0}, "dvh": {UnitLength, 0}, "dvi": {UnitLength, 0}, "dvb": {UnitLength, 0}, "dvmin": {UnitLength, 0}, "dvmax": {UnitLength, 0},
+	"cqw": {UnitLength, 0}, "cqh": {UnitLength, 0}, "cqi": {UnitLength, 0}, "cqb": {UnitLength, 0}, "cqmin": {UnitLength, 0}, "cqmax": {UnitLength, 0},
+
+	"deg":  {UnitAngle, 1},
+	"rad":  {UnitAngle, 180 / math.Pi},
+	"grad": {UnitAngle, 0.9},
+	"turn": {UnitAngle, 360},
+
+	"s":  {UnitTime, 1},
+	"ms": {UnitTime, 0.001},
+
+	"hz":  {UnitFrequency, 1},
+	"khz": {UnitFrequency, 1000},
+
+	"dppx": {UnitResolution, 1},
+	"x":    {UnitResolution, 1},
+	"dpi":  {UnitResolution, 1.0 / 96},
+	"dpcm": {UnitResolution, 2.54 / 96},
+
+	"fr": {UnitFlex, 1},
+}
+
+// Quantity is a numeric CSS value with an optional unit.
+//
+// The Unit is lowercase; it is empty for plain numbers, and '%' for
+// percentages.
+type Quantity struct {
+	Value float64
+	Unit  string
+}
+
+// ParseQuantity parses a Number, Percentage, or Dimension token into a
+// Quantity.
+func ParseQuantity(tk Token) (Quantity, error) {
+	switch tk.Type {
+	case TokenNumber:
+		v, err := strconv.ParseFloat(tk.Data, 64)
+		if err != nil {
+			return Quantity{}, ErrInvalidNumber
+		}
+
+		return Quantity{Value: v}, nil
+	case TokenPercentage:
+		v, err := strconv.ParseFloat(strings.TrimSuffix(tk.Data, "%"), 64)
+		if err != nil {
+			return Quantity{}, ErrInvalidNumber
+		}
+
+		return Quantity{Value: v, Unit: "%"}, nil
+	case TokenDimension:
+		v, unit, err := parseDimension(tk.Data)
+		if err != nil {
+			return Quantity{}, err
+		}
+
+		return Quantity{Value: v, Unit: strings.ToLower(unit)}, nil
+	}
+
+	return Quantity{}, ErrInvalidNumber
+}
+
+// Category returns the UnitCategory of the Quantity's unit, or UnitUnknown if
+// the unit is not recognised.
+func (q Quantity) Category() UnitCategory {
+	if u, ok := units[strings.ToLower(q.Unit)]; ok {
+		return u.category
+	}
+
+	return UnitUnknown
+}
+
+// IsRelative returns true if the Quantity has a unit that can only be
+// resolved using a UnitContext, such as 'em' or 'vw'.
+func (q Quantity) IsRelative() bool {
+	u, ok := units[strings.ToLower(q.Unit)]
+
+	return ok && u.factor == 0
+}
+
+// Canonical converts a Quantity with an absolute unit into the canonical unit
+// of its category: 'px', 'deg', 's', 'hz', or 'dppx'.
+//
+// Returns false if the unit is relative or unknown.
+func (q Quantity) Canonical() (Quantity, bool) {
+	u, ok := units[strings.ToLower(q.Unit)]
+	if !ok || u.factor == 0 {
+		return q, false
+	}
+
+	return Quantity{Value: q.Value * u.factor, Unit: canonicalUnits[u.category]}, true
+}
+
+// ConvertTo converts the Quantity into the given absolute unit of the same
+// category.
+func (q Quantity) ConvertTo(unit string) (Quantity, error) {
+	unit = strings.ToLower(unit)
+
+	to, ok := units[unit]
+	if !ok {
+		return q, ErrUnknownUnit
+	}
+
+	from, ok := units[strings.ToLower(q.Unit)]
+	if !ok {
+		return q, ErrUnknownUnit
+	} else if from.category != to.category {
+		return q, ErrIncompatibleUnits
+	} else if from.factor == 0 || to.factor == 0 {
+		return q, ErrUnresolvedUnit
+	}
+
+	return Quantity{Value: q.Value * from.factor / to.factor, Unit: unit}, nil
+}
+
+// Resolve converts the Quantity into the canonical unit of its category,
+// resolving relative lengths, and percentages when the context has a
+// PercentageBasis, using the given UnitContext.
+//
+// Quantities that are already absolute do not require a context, and
+// percentages without a basis are returned unchanged. A PercentageBasis that is
+// itself a percentage cannot be resolved, and will return ErrUnresolvedUnit.
+func (q Quantity) Resolve(ctx *UnitContext) (Quantity, error) {
+	unit := strings.ToLower(q.Unit)
+
+	u, ok := units[unit]
+	if !ok {
+		return q, ErrUnknownUnit
+	} else if u.factor != 0 && u.category != UnitPercentage {
+		c, _ := q.Canonical()
+
+		return c, nil
+	} else if u.category == UnitPercentage {
+		if ctx == nil || ctx.PercentageBasis == nil {
+			return q, nil
+		} else if b, ok := units[strings.ToLower(ctx.PercentageBasis.Unit)]; ok && b.category == UnitPercentage {
+			return q, ErrUnresolvedUnit
+		}
+
+		return Quantity{Value: q.Value * ctx.PercentageBasis.Value / 100, Unit: ctx.PercentageBasis.Unit}.Resolve(ctx)
+	} else if ctx == nil {
+		return q, ErrUnresolvedUnit
+	}
+
+	return Quantity{Value: q.Value * ctx.lengthUnit(unit), Unit: "px"}, nil
+}
+
+// String returns the CSS representation of the Quantity.
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'f', -1, 64) + q.Unit
+}
+
+// UnitContext provides the values, in pixels, needed to resolve relative
+// units.
+//
+// Font-relative metrics that are zero fall back to values derived from the
+// font size: 'ex' and 'ch' to 0.5em, 'cap' to 0.7em, 'ic' to 1em, and 'lh' to
+// 1.2em. Small, large, and dynamic viewport sizes that are zero fall back to
+// the Viewport sizes, and container sizes fall back to the small viewport.
+type UnitContext struct {
+	FontSize, RootFontSize          float64
+	XHeight, RootXHeight            float64
+	CapHeight, RootCapHeight        float64
+	ChWidth, RootChWidth            float64
+	IcWidth, RootIcWidth            float64
+	LineHeight, RootLineHeight      float64
+	ViewportWidth, ViewportHeight   float64
+	SmallViewportWidth              float64
+	SmallViewportHeight             float64
+	LargeViewportWidth              float64
+	LargeViewportHeight             float64
+	DynamicViewportWidth            float64
+	DynamicViewportHeight           float64
+	ContainerWidth, ContainerHeight float64
+
+	// VerticalWritingMode swaps the inline and block axes used by the 'i'
+	// and 'b' viewport and container units.
+	VerticalWritingMode bool
+
+	// PercentageBasis is the value that 100% resolves to. When nil,
+	// percentages are left unresolved.
+	PercentageBasis *Quantity
+}
+
+func fallback(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+
+	return v
+}
+
+func (ctx *UnitContext) lengthUnit(unit string) float64 {
+	switch unit {
+	case "em":
+		return ctx.FontSize
+	case "rem":
+		return ctx.RootFontSize
+	case "ex":
+		return fallback(ctx.XHeight, ctx.FontSize/2)
+	case "rex":
+		return fallback(ctx.RootXHeight, ctx.RootFontSize/2)
+	case "cap":
+		return fallback(ctx.CapHeight, ctx.FontSize*0.7)
+	case "rcap":
+		return fallback(ctx.RootCapHeight, ctx.RootFontSize*0.7)
+	case "ch":
+		return fallback(ctx.ChWidth, ctx.FontSize/2)
+	case "rch":
+		return fallback(ctx.RootChWidth, ctx.RootFontSize/2)
+	case "ic":
+		return fallback(ctx.IcWidth, ctx.FontSize)
+	case "ric":
+		return fallback(ctx.RootIcWidth, ctx.RootFontSize)
+	case "lh":
+		return fallback(ctx.LineHeight, ctx.FontSize*1.2)
+	case "rlh":
+		return fallback(ctx.RootLineHeight, ctx.RootFontSize*1.2)
+	}
+
+	var width, height float64
+
+	switch unit[:len(unit)-len(strings.TrimLeft(unit, "svldcq"))] {
+	case "v":
+		width, height = ctx.ViewportWidth, ctx.ViewportHeight
+	case "sv":
+		width, height = ctx.smallViewport()
+	case "lv":
+		width = fallback(ctx.LargeViewportWidth, ctx.ViewportWidth)
+		height = fallback(ctx.LargeViewportHeight, ctx.ViewportHeight)
+	case "dv":
+		width = fallback(ctx.DynamicViewportWidth, ctx.ViewportWidth)
+		height = fallback(ctx.DynamicViewportHeight, ctx.ViewportHeight)
+	case "cq":
+		sw, sh := ctx.smallViewport()
+		width = fallback(ctx.ContainerWidth, sw)
+		height = fallback(ctx.ContainerHeight, sh)
+	}
+
+	inline, block := width, height
+
+	if ctx.VerticalWritingMode {
+		inline, block = height, width
+	}
+
+	switch strings.TrimLeft(unit, "svldcq") {
+	case "w":
+		return width / 100
+	case "h":
+		return height / 100
+	case "i":
+		return inline / 100
+	case "b":
+		return block / 100
+	case "min":
+		return min(width, height) / 100
+	case "max":
+		return max(width, height) / 100
+	}
+
+	return 0
+}
+
+func (ctx *UnitContext) smallViewport() (float64, float64) {
+	return fallback(ctx.SmallViewportWidth, ctx.ViewportWidth), fallback(ctx.SmallViewportHeight, ctx.ViewportHeight)
+}
@@ -0,0 +1,292 @@
+package css
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	for n, test := range [...]struct {
+		Input    string
+		Quantity Quantity
+		Category UnitCategory
+		Relative bool
+	}{
+		{ // 1
+			Input:    "12",
+			Quantity: Quantity{Value: 12},
+			Category: UnitNumber,
+		},
+		{ // 2
+			Input:    "-50%",
+			Quantity: Quantity{Value: -50, Unit: "%"},
+			Category: UnitPercentage,
+		},
+		{ // 3
+			Input:    "1.5E2PX",
+			Quantity: Quantity{Value: 150, Unit: "px"},
+			Category: UnitLength,
+		},
+		{ // 4
+			Input:    "2rem",
+			Quantity: Quantity{Value: 2, Unit: "rem"},
+			Category: UnitLength,
+			Relative: true,
+		},
+		{ // 5
+			Input:    "0.25turn",
+			Quantity: Quantity{Value: 0.25, Unit: "turn"},
+			Category: UnitAngle,
+		},
+		{ // 6
+			Input:    "300ms",
+			Quantity: Quantity{Value: 300, Unit: "ms"},
+			Category: UnitTime,
+		},
+		{ // 7
+			Input:    "2kHz",
+			Quantity: Quantity{Value: 2, Unit: "khz"},
+			Category: UnitFrequency,
+		},
+		{ // 8
+			Input:    "2x",
+			Quantity: Quantity{Value: 2, Unit: "x"},
+			Category: UnitResolution,
+		},
+		{ // 9
+			Input:    "1fr",
+			Quantity: Quantity{Value: 1, Unit: "fr"},
+			Category: UnitFlex,
+		},
+		{ // 10
+			Input:    "3furlongs",
+			Quantity: Quantity{Value: 3, Unit: "furlongs"},
+			Category: UnitUnknown,
+		},
+	} {
+		q, err := ParseQuantity(tokenise(t, test.Input)[0])
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if q != test.Quantity {
+			t.Errorf("test %d: expecting quantity %v, got %v", n+1, test.Quantity, q)
+		} else if c := q.Category(); c != test.Category {
+			t.Errorf("test %d: expecting category %s, got %s", n+1, test.Category, c)
+		} else if r := q.IsRelative(); r != test.Relative {
+			t.Errorf("test %d: expecting relative %v, got %v", n+1, test.Relative, r)
+		}
+	}
+}
+
+func TestQuantityConvert(t *testing.T) {
+	for n, test := range [...]struct {
+		Quantity Quantity
+		Unit     string
+		Output   float64
+		Err      error
+	}{
+		{ // 1
+			Quantity: Quantity{Value: 1, Unit: "in"},
+			Unit:     "px",
+			Output:   96,
+		},
+		{ // 2
+			Quantity: Quantity{Value: 2.54, Unit: "cm"},
+			Unit:     "pt",
+			Output:   72,
+		},
+		{ // 3
+			Quantity: Quantity{Value: 40, Unit: "q"},
+			Unit:     "mm",
+			Output:   10,
+		},
+		{ // 4
+			Quantity: Quantity{Value: 1, Unit: "pc"},
+			Unit:     "PT",
+			Output:   12,
+		},
+		{ // 5
+			Quantity: Quantity{Value: math.Pi, Unit: "rad"},
+			Unit:     "turn",
+			Output:   0.5,
+		},
+		{ // 6
+			Quantity: Quantity{Value: 90, Unit: "deg"},
+			Unit:     "grad",
+			Output:   100,
+		},
+		{ // 7
+			Quantity: Quantity{Value: 1.5, Unit: "s"},
+			Unit:     "ms",
+			Output:   1500,
+		},
+		{ // 8
+			Quantity: Quantity{Value: 96, Unit: "dpi"},
+			Unit:     "dppx",
+			Output:   1,
+		},
+		{ // 9
+			Quantity: Quantity{Value: 2, Unit: "x"},
+			Unit:     "dpcm",
+			Output:   192 / 2.54,
+		},
+		{ // 10
+			Quantity: Quantity{Value: 1, Unit: "khz"},
+			Unit:     "hz",
+			Output:   1000,
+		},
+		{ // 11
+			Quantity: Quantity{Value: 1, Unit: "px"},
+			Unit:     "deg",
+			Err:      ErrIncompatibleUnits,
+		},
+		{ // 12
+			Quantity: Quantity{Value: 1, Unit: "em"},
+			Unit:     "px",
+			Err:      ErrUnresolvedUnit,
+		},
+		{ // 13
+			Quantity: Quantity{Value: 1, Unit: "px"},
+			Unit:     "furlong",
+			Err:      ErrUnknownUnit,
+		},
+	} {
+		q, err := test.Quantity.ConvertTo(test.Unit)
+		if !errors.Is(err, test.Err) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+		} else if test.Err == nil && math.Abs(q.Value-test.Output) > 1e-9 {
+			t.Errorf("test %d: expecting %v, got %v", n+1, test.Output, q.Value)
+		}
+	}
+}
+
+func TestQuantityResolve(t *testing.T) {
+	ctx := &UnitContext{
+		FontSize:            20,
+		RootFontSize:        16,
+		ChWidth:             9,
+		ViewportWidth:       1000,
+		ViewportHeight:      800,
+		SmallViewportHeight: 700,
+		ContainerWidth:      300,
+		PercentageBasis:     &Quantity{Value: 2, Unit: "em"},
+	}
+	vertical := *ctx
+	vertical.VerticalWritingMode = true
+
+	for n, test := range [...]struct {
+		Quantity Quantity
+		Context  *UnitContext
+		Output   Quantity
+		Err      error
+	}{
+		{ // 1
+			Quantity: Quantity{Value: 1, Unit: "in"},
+			Output:   Quantity{Value: 96, Unit: "px"},
+		},
+		{ // 2
+			Quantity: Quantity{Value: 1, Unit: "turn"},
+			Output:   Quantity{Value: 360, Unit: "deg"},
+		},
+		{ // 3
+			Quantity: Quantity{Value: 2, Unit: "em"},
+			Context:  ctx,
+			Output:   Quantity{Value: 40, Unit: "px"},
+		},
+		{ // 4
+			Quantity: Quantity{Value: 2, Unit: "rem"},
+			Context:  ctx,
+			Output:   Quantity{Value: 32, Unit: "px"},
+		},
+		{ // 5
+			Quantity: Quantity{Value: 2, Unit: "ex"},
+			Context:  ctx,
+			Output:   Quantity{Value: 20, Unit: "px"},
+		},
+		{ // 6
+			Quantity: Quantity{Value: 2, Unit: "ch"},
+			Context:  ctx,
+			Output:   Quantity{Value: 18, Unit: "px"},
+		},
+		{ // 7
+			Quantity: Quantity{Value: 1, Unit: "lh"},
+			Context:  ctx,
+			Output:   Quantity{Value: 24, Unit: "px"},
+		},
+		{ // 8
+			Quantity: Quantity{Value: 10, Unit: "vw"},
+			Context:  ctx,
+			Output:   Quantity{Value: 100, Unit: "px"},
+		},
+		{ // 9
+			Quantity: Quantity{Value: 10, Unit: "svh"},
+			Context:  ctx,
+			Output:   Quantity{Value: 70, Unit: "px"},
+		},
+		{ // 10
+			Quantity: Quantity{Value: 10, Unit: "dvh"},
+			Context:  ctx,
+			Output:   Quantity{Value: 80, Unit: "px"},
+		},
+		{ // 11
+			Quantity: Quantity{Value: 10, Unit: "vmin"},
+			Context:  ctx,
+			Output:   Quantity{Value: 80, Unit: "px"},
+		},
+		{ // 12
+			Quantity: Quantity{Value: 10, Unit: "cqw"},
+			Context:  ctx,
+			Output:   Quantity{Value: 30, Unit: "px"},
+		},
+		{ // 13
+			Quantity: Quantity{Value: 10, Unit: "cqh"},
+			Context:  ctx,
+			Output:   Quantity{Value: 70, Unit: "px"},
+		},
+		{ // 14
+			Quantity: Quantity{Value: 10, Unit: "vi"},
+			Context:  &vertical,
+			Output:   Quantity{Value: 80, Unit: "px"},
+		},
+		{ // 15
+			Quantity: Quantity{Value: 50, Unit: "%"},
+			Context:  ctx,
+			Output:   Quantity{Value: 20, Unit: "px"},
+		},
+		{ // 16
+			Quantity: Quantity{Value: 50, Unit: "%"},
+			Output:   Quantity{Value: 50, Unit: "%"},
+		},
+		{ // 17
+			Quantity: Quantity{Value: 1, Unit: "em"},
+			Err:      ErrUnresolvedUnit,
+		},
+		{ // 18
+			Quantity: Quantity{Value: 1, Unit: "furlong"},
+			Context:  ctx,
+			Err:      ErrUnknownUnit,
+		},
+		{ // 19
+			Quantity: Quantity{Value: 50, Unit: "%"},
+			Context:  &UnitContext{PercentageBasis: &Quantity{Value: 100, Unit: "%"}},
+			Err:      ErrUnresolvedUnit,
+		},
+		{ // 20
+			Quantity: Quantity{Value: 2, Unit: "em"},
+			Context:  &UnitContext{FontSize: 10, PercentageBasis: &Quantity{Value: 100, Unit: "%"}},
+			Output:   Quantity{Value: 20, Unit: "px"},
+		},
+		{ // 21
+			Quantity: Quantity{Value: 50, Unit: "%"},
+			Context:  &UnitContext{PercentageBasis: &Quantity{Value: 90, Unit: "deg"}},
+			Output:   Quantity{Value: 45, Unit: "deg"},
+		},
+	} {
+		q, err := test.Quantity.Resolve(test.Context)
+		if !errors.Is(err, test.Err) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+		} else if test.Err == nil && (q.Unit != test.Output.Unit || math.Abs(q.Value-test.Output.Value) > 1e-9) {
+			t.Errorf("test %d: expecting %v, got %v", n+1, test.Output, q)
+		}
+	}
+}
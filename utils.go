@@ -102,8 +102,150 @@ func UnURL(str string) (string, error) {
 	}
 }
 
+func parseDimension(data string) (float64, string, error) {
+	n := 0
+
+	if n < len(data) && (data[n] == '+' || data[n] == '-') {
+		n++
+	}
+
+	for n < len(data) && strings.IndexByte(digit, data[n]) >= 0 {
+		n++
+	}
+
+	if n+1 < len(data) && data[n] == '.' && strings.IndexByte(digit, data[n+1]) >= 0 {
+		for n++; n < len(data) && strings.IndexByte(digit, data[n]) >= 0; n++ {
+		}
+	}
+
+	if n+1 < len(data) && (data[n] == 'e' || data[n] == 'E') {
+		m := n + 1
+
+		if data[m] == '+' || data[m] == '-' {
+			m++
+		}
+
+		if m < len(data) && strings.IndexByte(digit, data[m]) >= 0 {
+			for n = m; n < len(data) && strings.IndexByte(digit, data[n]) >= 0; n++ {
+			}
+		}
+	}
+
+	v, err := strconv.ParseFloat(data[:n], 64)
+	if err != nil {
+		return 0, "", ErrInvalidNumber
+	}
+
+	return v, data[n:], nil
+}
+
+func absoluteLength(v float64, unit string) (float64, bool) {
+	q, ok := Quantity{Value: v, Unit: unit}.Canonical()
+
+	return q.Value, ok && q.Unit == "px"
+}
+
+func isRelativeLengthUnit(unit string) bool {
+	q := Quantity{Unit: unit}
+
+	return q.Category() == UnitLength && q.IsRelative()
+}
+
+func isLengthUnit(unit string) bool {
+	_, ok := absoluteLength(0, unit)
+
+	return ok || isRelativeLengthUnit(unit)
+}
+
 // Errors
 var (
 	ErrBadString = errors.New("bad string")
 	ErrBadURL    = errors.New("bad url")
+
+	ErrUnexpectedToken   = errors.New("unexpected token")
+	ErrMissingBlock      = errors.New("missing block")
+	ErrMissingCloseBrace = errors.New("missing closing brace")
+	ErrMissingName       = errors.New("missing name")
+	ErrMissingColon      = errors.New("missing colon")
+	ErrMissingCloseParen = errors.New("missing closing paren")
+
+	ErrMixedOperators           = errors.New("mixed 'and' and 'or' operators")
+	ErrMissingSelector          = errors.New("missing selector")
+	ErrInvalidFontFeature       = errors.New("invalid font feature")
+	ErrInvalidSupportsCondition = errors.New("invalid supports condition")
+
+	ErrUnexpectedBlock       = errors.New("unexpected block")
+	ErrUnexpectedPrelude     = errors.New("unexpected prelude")
+	ErrMissingURL            = errors.New("missing url")
+	ErrInvalidLayerName      = errors.New("invalid layer name")
+	ErrTooManyLayerNames     = errors.New("too many layer names")
+	ErrInvalidImportPosition = errors.New("@import must precede all rules other than @charset and @layer statements")
+	ErrInvalidMediaQuery     = errors.New("invalid media query")
+	ErrMissingMediaType      = errors.New("missing media type")
+	ErrInvalidMediaFeature   = errors.New("invalid media feature")
+	ErrImportCycle           = errors.New("import cycle")
+	ErrExternalImport        = errors.New("cannot inline conditional import of external url")
+	ErrHoistedImport         = errors.New("cannot move external import above inlined rules")
+	ErrInlinedNamespace      = errors.New("cannot inline stylesheet that declares namespaces")
+	ErrInvalidNumber         = errors.New("invalid number")
+
+	ErrMissingFontFamily   = errors.New("missing font-family")
+	ErrMissingFontSource   = errors.New("missing src")
+	ErrInvalidFamilyName   = errors.New("invalid family name")
+	ErrInvalidKeyword      = errors.New("invalid keyword")
+	ErrInvalidFontRange    = errors.New("invalid font range")
+	ErrInvalidPercentage   = errors.New("invalid percentage")
+	ErrInvalidAngle        = errors.New("invalid angle")
+	ErrInvalidUnicodeRange = errors.New("invalid unicode range")
+
+	ErrInvalidKeyframesName    = errors.New("invalid keyframes name")
+	ErrInvalidKeyframe         = errors.New("invalid keyframe")
+	ErrInvalidKeyframeSelector = errors.New("invalid keyframe selector")
+	ErrInvalidContainerQuery   = errors.New("invalid container query")
+	ErrInvalidPageSelector     = errors.New("invalid page selector")
+	ErrInvalidPagePseudoClass  = errors.New("invalid page pseudo-class")
+	ErrInvalidMarginBox        = errors.New("invalid margin box")
+
+	ErrInvalidSelector          = errors.New("invalid selector")
+	ErrInvalidAttributeSelector = errors.New("invalid attribute selector")
+	ErrUndeclaredNamespace      = errors.New("undeclared namespace prefix")
+
+	ErrInvalidPropertyName  = errors.New("invalid property name")
+	ErrInvalidSyntax        = errors.New("invalid syntax descriptor")
+	ErrMissingSyntax        = errors.New("missing syntax descriptor")
+	ErrMissingInherits      = errors.New("missing inherits descriptor")
+	ErrMissingInitialValue  = errors.New("missing initial-value descriptor")
+	ErrInvalidInitialValue  = errors.New("invalid initial value")
+	ErrInvalidPropertyValue = errors.New("invalid property value")
+
+	ErrInvalidCounterStyleName = errors.New("invalid counter style name")
+	ErrInvalidCounterSymbol    = errors.New("invalid counter symbol")
+	ErrInvalidAdditiveSymbols  = errors.New("invalid additive symbols")
+	ErrInvalidCounterRange     = errors.New("invalid counter range")
+
+	ErrUnsupportedEncoding = errors.New("unsupported encoding")
+
+	ErrInvalidScope = errors.New("invalid scope")
+
+	ErrInvalidDashedIdent = errors.New("invalid dashed ident")
+	ErrInvalidColor       = errors.New("invalid color")
+	ErrUnresolvedColor    = errors.New("color cannot be resolved")
+
+	ErrBuiltinAtRule           = errors.New("cannot register built-in at-rule")
+	ErrInvalidAtRuleDefinition = errors.New("cannot require a block for an at-rule that disallows one")
+
+	ErrUnknownUnit       = errors.New("unknown unit")
+	ErrIncompatibleUnits = errors.New("incompatible units")
+	ErrUnresolvedUnit    = errors.New("unit cannot be resolved without context")
+
+	ErrInvalidMath     = errors.New("invalid math expression")
+	ErrInvalidMathType = errors.New("invalid math expression type")
+	ErrUnresolvedMath  = errors.New("math expression contains unresolved references")
+
+	ErrInvalidAtComputedValueTime = errors.New("invalid at computed-value time")
+	ErrUndefinedVariable          = errors.New("undefined custom property")
+	ErrUnusedVariable             = errors.New("unused custom property")
+	ErrVariableCycle              = errors.New("custom property dependency cycle")
+
+	ErrInvalidGrammar = errors.New("invalid value definition syntax")
 )
@@ -0,0 +1,241 @@
+package css
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// CustomProperties holds the computed values of custom properties, keyed by
+// name.
+//
+// A custom property that is not in the map has the guaranteed-invalid value,
+// and cannot be substituted by var().
+type CustomProperties map[string]Tokens
+
+// Cascade returns the CustomProperties that result from applying the custom
+// property declarations of a rule on top of those inherited from the parent
+// element. The receiver, which may be nil, is not modified.
+//
+// Where a custom property is declared more than once the winning
+// declaration, respecting '!important', is used. The var() references in
+// each value are substituted, per CSS Variables Level 1: properties that
+// reference each other in a cycle, and properties whose value is invalid at
+// computed-value time, are given the guaranteed-invalid value. References
+// in a var() fallback count towards a cycle even when the fallback is unused,
+// matching VariableGraph.Cycles.
+//
+// The 'initial' keyword gives a property the guaranteed-invalid value, and
+// the other CSS-wide keywords keep the inherited value.
+func (p CustomProperties) Cascade(decls []*Declaration) CustomProperties {
+	winners := make(map[string]*Declaration)
+
+	for _, d := range decls {
+		if name := d.Property(); strings.HasPrefix(name, "--") {
+			winners[name] = winningDeclaration(winners[name], d)
+		}
+	}
+
+	r := variableResolver{
+		computed: make(CustomProperties, len(p)+len(winners)),
+		declared: make(map[string]Tokens, len(winners)),
+		cyclic:   make(map[string]bool),
+	}
+
+	maps.Copy(r.computed, p)
+
+	for name, d := range winners {
+		if len(d.Value) == 1 && d.Value[0].Type == TokenIdent && isCSSWideKeyword(d.Value[0].Data) {
+			if strings.EqualFold(d.Value[0].Data, "initial") {
+				delete(r.computed, name)
+			}
+		} else {
+			r.declared[name] = d.Value
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(r.declared)) {
+		r.lookup(name)
+	}
+
+	return r.computed
+}
+
+// Substitute returns the value with each var() reference replaced by the
+// value of the referenced custom property, or by its fallback when the
+// property has the guaranteed-invalid value.
+//
+// Returns ErrInvalidAtComputedValueTime if a reference cannot be substituted
+// and has no fallback, or if a var() function is malformed.
+func (p CustomProperties) Substitute(value Tokens) (Tokens, error) {
+	r := variableResolver{computed: p}
+
+	substituted, ok := r.substitute(value)
+	if !ok {
+		return nil, ErrInvalidAtComputedValueTime
+	}
+
+	return substituted, nil
+}
+
+// SubstituteDeclarations returns copies of the declarations, other than
+// custom property declarations, with the var() references in their values
+// substituted using the CustomProperties, which would usually be the result
+// of Cascade.
+//
+// A declaration that is invalid at computed-value time is given the value
+// 'unset', as required by CSS Variables Level 1.
+func (p CustomProperties) SubstituteDeclarations(decls []*Declaration) []*Declaration {
+	var substituted []*Declaration
+
+	for _, d := range decls {
+		if strings.HasPrefix(d.Name.Data, "--") {
+			continue
+		}
+
+		nd := *d
+
+		if value, err := p.Substitute(d.Value); err == nil {
+			nd.Value = value
+		} else {
+			unset := *d.Name
+			unset.Token = parser.Token{Type: TokenIdent, Data: "unset"}
+			nd.Value = Tokens{unset}
+		}
+
+		substituted = append(substituted, &nd)
+	}
+
+	return substituted
+}
+
+type variableResolver struct {
+	computed  CustomProperties
+	declared  map[string]Tokens
+	resolving []string
+	cyclic    map[string]bool
+}
+
+func (r *variableResolver) lookup(name string) (Tokens, bool) {
+	if n := slices.Index(r.resolving, name); n >= 0 {
+		for _, m := range r.resolving[n:] {
+			r.cyclic[m] = true
+		}
+
+		return nil, false
+	}
+
+	if value, ok := r.declared[name]; ok {
+		delete(r.declared, name)
+
+		r.resolving = append(r.resolving, name)
+		value, ok = r.substitute(value)
+		r.resolving = r.resolving[:len(r.resolving)-1]
+
+		if ok && !r.cyclic[name] {
+			r.computed[name] = value
+		} else {
+			delete(r.computed, name)
+		}
+	}
+
+	value, ok := r.computed[name]
+
+	return value, ok
+}
+
+func (r *variableResolver) substitute(value Tokens) (Tokens, bool) {
+	if !hasVar(value) {
+		return value, true
+	}
+
+	var (
+		substituted Tokens
+		c           = newCSSParserFromTokens(value)
+	)
+
+	for {
+		d := c.NewGoal()
+
+		if !d.AcceptComponentValue() {
+			return substituted, true
+		}
+
+		v := d.ToTokens()
+
+		c.Score(d)
+
+		switch last := len(v) - 1; {
+		case functionName(v[0]) == "var":
+			ts, ok := r.substituteVar(v)
+			if !ok {
+				return nil, false
+			}
+
+			substituted = append(substituted, ts...)
+		case last > 0 && hasVar(v):
+			inner, ok := r.substitute(v[1:last])
+			if !ok {
+				return nil, false
+			}
+
+			substituted = append(append(append(substituted, v[0]), inner...), v[last])
+		default:
+			substituted = append(substituted, v...)
+		}
+	}
+}
+
+func (r *variableResolver) substituteVar(v Tokens) (Tokens, bool) {
+	if v[len(v)-1].Type != TokenCloseParen {
+		return nil, false
+	}
+
+	c := newCSSParserFromTokens(v[1 : len(v)-1])
+
+	c.AcceptRunWhitespace()
+
+	if !c.Accept(TokenIdent) || !strings.HasPrefix(c.GetLastToken().Data, "--") {
+		return nil, false
+	}
+
+	name := c.GetLastToken().Data
+
+	c.AcceptRunWhitespace()
+
+	hasFallback := c.Accept(TokenComma)
+	if !hasFallback && c.Peek().Type != parser.TokenDone {
+		return nil, false
+	}
+
+	value, ok := r.lookup(name)
+	if !hasFallback {
+		return value, ok
+	}
+
+	d := c.NewGoal()
+
+	for d.AcceptComponentValue() {
+	}
+
+	// The fallback is resolved even when unused, as its references are
+	// dependencies that can make the property cyclic.
+	fallback, fallbackOK := r.substitute(d.ToTokens().Trim())
+	if ok {
+		return value, true
+	}
+
+	return fallback, fallbackOK
+}
+
+func hasVar(value Tokens) bool {
+	for _, tk := range value {
+		if functionName(tk) == "var" {
+			return true
+		}
+	}
+
+	return false
+}
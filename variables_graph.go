@@ -0,0 +1,247 @@
+package css
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// VariableReference is a var() reference to a custom property.
+type VariableReference struct {
+	// Token is the custom property name within the var() function.
+	Token Token
+
+	// Declaration is the declaration whose value contains the reference.
+	Declaration *Declaration
+
+	// HasFallback is true when the var() function has a fallback value,
+	// which may be empty.
+	HasFallback bool
+}
+
+// VariableGraph describes where the custom properties of a Sheet are defined,
+// and the var() references between them.
+type VariableGraph struct {
+	// Definitions holds the declarations of each custom property, in Sheet
+	// order.
+	Definitions map[string][]*Declaration
+
+	// References holds the var() references to each custom property, in
+	// Sheet order.
+	References map[string][]VariableReference
+
+	// Dependencies holds, for each defined custom property, the sorted names
+	// of the custom properties referenced by any of its definitions.
+	Dependencies map[string][]string
+
+	// Registered holds the custom properties registered with an @property
+	// rule that have an initial value.
+	Registered map[string]*PropertyRule
+}
+
+// VariableGraph builds a VariableGraph of the custom properties defined and
+// referenced in the declarations of the Sheet.
+//
+// The graph is built over the whole Sheet, without regard for which rules
+// apply to which elements.
+func (s *Sheet) VariableGraph() *VariableGraph {
+	g := &VariableGraph{
+		Definitions:  make(map[string][]*Declaration),
+		References:   make(map[string][]VariableReference),
+		Dependencies: make(map[string][]string),
+		Registered:   make(map[string]*PropertyRule),
+	}
+
+	for name, p := range s.PropertyRegistrations() {
+		if len(p.InitialValue) > 0 {
+			g.Registered[name] = p
+		}
+	}
+
+	s.walkRules(func(r *Rule) {
+		b := r.block()
+		if b == nil {
+			return
+		}
+
+		for _, d := range b.Declarations() {
+			name := d.Property()
+			isCustom := strings.HasPrefix(name, "--")
+
+			if isCustom {
+				g.Definitions[name] = append(g.Definitions[name], d)
+			}
+
+			for _, ref := range variableReferences(d) {
+				g.References[ref.Token.Data] = append(g.References[ref.Token.Data], ref)
+
+				if isCustom && !slices.Contains(g.Dependencies[name], ref.Token.Data) {
+					g.Dependencies[name] = append(g.Dependencies[name], ref.Token.Data)
+				}
+			}
+		}
+	})
+
+	for _, deps := range g.Dependencies {
+		slices.Sort(deps)
+	}
+
+	return g
+}
+
+func variableReferences(d *Declaration) []VariableReference {
+	var refs []VariableReference
+
+	for n, tk := range d.Value {
+		if functionName(tk) != "var" {
+			continue
+		}
+
+		c := newCSSParserFromTokens(d.Value[n+1:])
+
+		c.AcceptRunWhitespace()
+
+		if !c.Accept(TokenIdent) || !strings.HasPrefix(c.GetLastToken().Data, "--") {
+			continue
+		}
+
+		name := *c.GetLastToken()
+
+		c.AcceptRunWhitespace()
+
+		refs = append(refs, VariableReference{
+			Token:       name,
+			Declaration: d,
+			HasFallback: c.Accept(TokenComma),
+		})
+	}
+
+	return refs
+}
+
+// Cycles returns each set of custom properties whose definitions depend on
+// each other, including properties that reference themselves. References in
+// var() fallbacks are dependencies, as they are for CustomProperties.Cascade.
+// Each cycle is sorted, as is the list of cycles.
+func (g *VariableGraph) Cycles() [][]string {
+	t := tarjan{
+		graph: g.Dependencies,
+		index: make(map[string]int),
+		low:   make(map[string]int),
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.Dependencies)) {
+		if _, ok := t.index[name]; !ok {
+			t.connect(name)
+		}
+	}
+
+	slices.SortFunc(t.cycles, slices.Compare)
+
+	return t.cycles
+}
+
+type tarjan struct {
+	graph      map[string][]string
+	index, low map[string]int
+	stack      []string
+	cycles     [][]string
+}
+
+func (t *tarjan) connect(name string) {
+	t.index[name] = len(t.index)
+	t.low[name] = t.index[name]
+	t.stack = append(t.stack, name)
+
+	for _, dep := range t.graph[name] {
+		if _, ok := t.index[dep]; !ok {
+			t.connect(dep)
+
+			t.low[name] = min(t.low[name], t.low[dep])
+		} else if slices.Contains(t.stack, dep) {
+			t.low[name] = min(t.low[name], t.index[dep])
+		}
+	}
+
+	if t.low[name] != t.index[name] {
+		return
+	}
+
+	pos := slices.Index(t.stack, name)
+	component := slices.Clone(t.stack[pos:])
+	t.stack = t.stack[:pos]
+
+	if len(component) > 1 || slices.Contains(t.graph[name], name) {
+		slices.Sort(component)
+
+		t.cycles = append(t.cycles, component)
+	}
+}
+
+// Check reports the problems found in the VariableGraph, as a list of Error
+// values ordered by position:
+//
+//   - ErrUndefinedVariable, for each var() reference without a fallback to a
+//     custom property that is neither defined nor registered with an initial
+//     value, at the referenced name;
+//   - ErrUnusedVariable, for each definition of a custom property that is
+//     never referenced, at the property name; and
+//   - ErrVariableCycle, for each definition of a custom property that is part
+//     of a cycle, at the property name.
+//
+// As the graph covers the whole Sheet, a reported cycle may only occur for
+// elements that match all of the rules involved.
+func (g *VariableGraph) Check() []error {
+	var errs []Error
+
+	for name, refs := range g.References {
+		if _, ok := g.Definitions[name]; ok {
+			continue
+		} else if _, ok := g.Registered[name]; ok {
+			continue
+		}
+
+		for _, ref := range refs {
+			if !ref.HasFallback {
+				errs = append(errs, Error{Err: ErrUndefinedVariable, Parsing: "Declaration", Token: ref.Token})
+			}
+		}
+	}
+
+	for name, defs := range g.Definitions {
+		if _, ok := g.References[name]; ok {
+			continue
+		}
+
+		for _, d := range defs {
+			errs = append(errs, Error{Err: ErrUnusedVariable, Parsing: "Declaration", Token: *d.Name})
+		}
+	}
+
+	for _, cycle := range g.Cycles() {
+		for _, name := range cycle {
+			for _, d := range g.Definitions[name] {
+				errs = append(errs, Error{Err: ErrVariableCycle, Parsing: "Declaration", Token: *d.Name})
+			}
+		}
+	}
+
+	slices.SortStableFunc(errs, func(a, b Error) int {
+		return cmp.Compare(a.Token.Pos, b.Token.Pos)
+	})
+
+	reported := make([]error, len(errs))
+
+	for n, err := range errs {
+		reported[n] = err
+	}
+
+	return reported
+}
+
+// CheckVariables builds the VariableGraph of the Sheet and returns the
+// problems it finds; see VariableGraph.Check.
+func (s *Sheet) CheckVariables() []error {
+	return s.VariableGraph().Check()
+}
@@ -0,0 +1,122 @@
+package css
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+const variableGraphSheet = `@property --registered {
+	syntax: "<length>";
+	inherits: false;
+	initial-value: 0px;
+}
+:root {
+	--primary: blue;
+	--unused: 1px;
+	--a: var(--b);
+	--b: var(--c, var(--a));
+	--self: var(--self);
+}
+.x {
+	color: var(--primary);
+	margin: var(--missing);
+	padding: var(--fallback, 2px) var(--registered);
+	@media screen {
+		--primary: red;
+		border-color: var(--absent) var(--self);
+	}
+}`
+
+func TestVariableGraph(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(variableGraphSheet))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	g := s.VariableGraph()
+
+	if defs := g.Definitions["--primary"]; len(defs) != 2 {
+		t.Errorf("expecting 2 definitions of --primary, got %d", len(defs))
+	} else if line := defs[1].Name.Line; line != 17 {
+		t.Errorf("expecting second definition of --primary on line 17, got %d", line)
+	}
+
+	if refs := g.References["--fallback"]; len(refs) != 1 {
+		t.Errorf("expecting 1 reference to --fallback, got %d", len(refs))
+	} else if !refs[0].HasFallback || refs[0].Declaration.Property() != "padding" {
+		t.Errorf("unexpected reference to --fallback: %v", refs[0])
+	}
+
+	if deps := g.Dependencies["--b"]; !slices.Equal(deps, []string{"--a", "--c"}) {
+		t.Errorf("expecting --b to depend on [--a --c], got %v", deps)
+	}
+
+	if _, ok := g.Registered["--registered"]; !ok {
+		t.Error("expecting --registered to be registered")
+	}
+
+	if cycles := g.Cycles(); !reflect.DeepEqual(cycles, [][]string{{"--a", "--b"}, {"--self"}}) {
+		t.Errorf("unexpected cycles: %v", cycles)
+	}
+}
+
+func TestCheckVariables(t *testing.T) {
+	s, err := ParseSheet(parser.NewStringTokeniser(variableGraphSheet))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	errs := s.CheckVariables()
+
+	expected := [...]struct {
+		Err  error
+		Data string
+		Line uint64
+	}{
+		{ErrUnusedVariable, "--unused", 7},
+		{ErrVariableCycle, "--a", 8},
+		{ErrVariableCycle, "--b", 9},
+		{ErrVariableCycle, "--self", 10},
+		{ErrUndefinedVariable, "--missing", 14},
+		{ErrUndefinedVariable, "--absent", 18},
+	}
+
+	if len(errs) != len(expected) {
+		t.Fatalf("expecting %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+
+	for n, test := range expected {
+		var e Error
+
+		if !errors.As(errs[n], &e) {
+			t.Errorf("test %d: expecting Error, got %T", n+1, errs[n])
+		} else if !errors.Is(e, test.Err) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, e.Err)
+		} else if e.Token.Data != test.Data {
+			t.Errorf("test %d: expecting token %q, got %q", n+1, test.Data, e.Token.Data)
+		} else if e.Token.Line != test.Line {
+			t.Errorf("test %d: expecting line %d, got %d", n+1, test.Line, e.Token.Line)
+		}
+	}
+}
+
+func TestVariableGraphCascadeCycles(t *testing.T) {
+	const input = "--a: var(--b, var(--a)); --b: 1"
+
+	s, err := ParseSheet(parser.NewStringTokeniser("a{" + input + "}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cycles := s.VariableGraph().Cycles(); !reflect.DeepEqual(cycles, [][]string{{"--a"}}) {
+		t.Errorf("unexpected cycles: %v", cycles)
+	}
+
+	if p := CustomProperties(nil).Cascade(declarations(t, input)); !reflect.DeepEqual(customPropertyStrings(p), map[string]string{"--b": "1"}) {
+		t.Errorf("expecting only --b to be valid, got %v", customPropertyStrings(p))
+	}
+}
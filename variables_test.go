@@ -0,0 +1,224 @@
+package css
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"vimagination.zapto.org/parser"
+)
+
+func declarations(t *testing.T, input string) []*Declaration {
+	t.Helper()
+
+	s, err := ParseSheet(parser.NewStringTokeniser("a{" + input + "}"))
+	if err != nil {
+		t.Fatalf("unexpected error parsing %q: %s", input, err)
+	}
+
+	return s.Rules[0].QualifiedRule.Block.Declarations()
+}
+
+func customPropertyStrings(p CustomProperties) map[string]string {
+	strs := make(map[string]string, len(p))
+
+	for name, value := range p {
+		strs[name] = value.String()
+	}
+
+	return strs
+}
+
+func TestCustomPropertiesCascade(t *testing.T) {
+	for n, test := range [...]struct {
+		Inherited map[string]string
+		Input     string
+		Output    map[string]string
+	}{
+		{ // 1
+			Input:  "--a: 1px",
+			Output: map[string]string{"--a": "1px"},
+		},
+		{ // 2
+			Input:  "--a: 1px; --b: calc(var(--a) * 2)",
+			Output: map[string]string{"--a": "1px", "--b": "calc(1px * 2)"},
+		},
+		{ // 3
+			Input:  "--b: var(--a); --a: red",
+			Output: map[string]string{"--a": "red", "--b": "red"},
+		},
+		{ // 4
+			Inherited: map[string]string{"--a": "blue"},
+			Input:     "--b: var(--a)",
+			Output:    map[string]string{"--a": "blue", "--b": "blue"},
+		},
+		{ // 5
+			Inherited: map[string]string{"--a": "blue"},
+			Input:     "--a: green; --a: red",
+			Output:    map[string]string{"--a": "red"},
+		},
+		{ // 6
+			Input:  "--a: green !important; --a: red",
+			Output: map[string]string{"--a": "green"},
+		},
+		{ // 7
+			Input:  "--a: var(--b); --b: var(--a); --c: var(--a, fallback)",
+			Output: map[string]string{"--c": "fallback"},
+		},
+		{ // 8
+			Input:  "--a: var(--a)",
+			Output: map[string]string{},
+		},
+		{ // 9
+			Input:  "--a: var(--b, 1px); --b: var(--a)",
+			Output: map[string]string{},
+		},
+		{ // 10
+			Input:  "--a: var(--missing)",
+			Output: map[string]string{},
+		},
+		{ // 11
+			Inherited: map[string]string{"--a": "blue"},
+			Input:     "--a: var(--missing)",
+			Output:    map[string]string{},
+		},
+		{ // 12
+			Input:  "--a: var(--missing, var(--also-missing, 2px))",
+			Output: map[string]string{"--a": "2px"},
+		},
+		{ // 13
+			Input:  "--a: var(--missing,)",
+			Output: map[string]string{"--a": ""},
+		},
+		{ // 14
+			Inherited: map[string]string{"--a": "blue", "--b": "red"},
+			Input:     "--a: initial; --b: inherit",
+			Output:    map[string]string{"--b": "red"},
+		},
+		{ // 15
+			Input:  "--a: 1px; --b: foo(var(--a), [var(--a)])",
+			Output: map[string]string{"--a": "1px", "--b": "foo(1px, [1px])"},
+		},
+		{ // 16
+			Input:  "--a: var(a)",
+			Output: map[string]string{},
+		},
+		{ // 17
+			Input:  "--a: 1; --b: var(--a) var(--a, 2) var(--c, 3 4)",
+			Output: map[string]string{"--a": "1", "--b": "1 1 3 4"},
+		},
+		{ // 18
+			Input:  "--a: var(--b); --b: var(--c); --c: var(--d); --d: var(--b); --e: var(--a, 0)",
+			Output: map[string]string{"--e": "0"},
+		},
+		{ // 19
+			Input:  "--a: var(--b, var(--a)); --b: 1",
+			Output: map[string]string{"--b": "1"},
+		},
+		{ // 20
+			Input:  "--a: var(--b, var(--c)); --b: 1; --c: var(--a, 2)",
+			Output: map[string]string{"--b": "1"},
+		},
+	} {
+		inherited := make(CustomProperties)
+
+		for name, value := range test.Inherited {
+			inherited[name] = tokenise(t, value)
+		}
+
+		output := customPropertyStrings(inherited.Cascade(declarations(t, test.Input)))
+
+		if !maps.Equal(output, test.Output) {
+			t.Errorf("test %d: expecting %v, got %v", n+1, test.Output, output)
+		}
+
+		if inherited := customPropertyStrings(inherited); !maps.Equal(inherited, test.Inherited) {
+			t.Errorf("test %d: inherited properties modified: %v", n+1, inherited)
+		}
+	}
+}
+
+func TestCustomPropertiesSubstitute(t *testing.T) {
+	p := CustomProperties{
+		"--size":  tokenise(t, "10px"),
+		"--color": tokenise(t, "red"),
+		"--empty": Tokens{},
+	}
+
+	for n, test := range [...]struct {
+		Input, Output string
+		Err           error
+	}{
+		{ // 1
+			Input:  "1px solid var(--color)",
+			Output: "1px solid red",
+		},
+		{ // 2
+			Input:  "calc(var(--size) * 2)",
+			Output: "calc(10px * 2)",
+		},
+		{ // 3
+			Input:  "var(--missing, var(--size))",
+			Output: "10px",
+		},
+		{ // 4
+			Input: "var(--missing)",
+			Err:   ErrInvalidAtComputedValueTime,
+		},
+		{ // 5
+			Input: "var(--missing, var(--missing-too))",
+			Err:   ErrInvalidAtComputedValueTime,
+		},
+		{ // 6
+			Input:  "a var(--empty) b",
+			Output: "a  b",
+		},
+		{ // 7
+			Input: "var(--size --color)",
+			Err:   ErrInvalidAtComputedValueTime,
+		},
+		{ // 8
+			Input: "var()",
+			Err:   ErrInvalidAtComputedValueTime,
+		},
+		{ // 9
+			Input:  "VAR( --size )",
+			Output: "10px",
+		},
+		{ // 10
+			Input:  "no variables",
+			Output: "no variables",
+		},
+	} {
+		output, err := p.Substitute(tokenise(t, test.Input))
+		if test.Err != nil {
+			if !errors.Is(err, test.Err) {
+				t.Errorf("test %d: expecting error %v, got %v", n+1, test.Err, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if str := output.String(); str != test.Output {
+			t.Errorf("test %d: expecting %q, got %q", n+1, test.Output, str)
+		}
+	}
+}
+
+func TestCustomPropertiesSubstituteDeclarations(t *testing.T) {
+	decls := declarations(t, "--gap: 4px; --loop: var(--loop); margin: var(--gap) auto; padding: var(--loop); color: red")
+	p := CustomProperties(nil).Cascade(decls)
+
+	var output []string
+
+	for _, d := range p.SubstituteDeclarations(decls) {
+		output = append(output, d.Name.Data+": "+d.Value.String())
+	}
+
+	if expected := []string{"margin: 4px auto", "padding: unset", "color: red"}; !slices.Equal(output, expected) {
+		t.Errorf("expecting %q, got %q", expected, output)
+	}
+
+	if value := decls[2].Value.String(); value != "var(--gap) auto" {
+		t.Errorf("original declaration modified: %q", value)
+	}
+}